@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// GenerateInviteToken signs a shareable token for an event invite link. The
+// token only identifies the link and never expires by itself; expiry, max uses
+// and revocation are checked against the stored link so they can change after
+// the link is shared.
+func GenerateInviteToken(linkID int64, secret string) (string, error) {
+	claims := jwt.MapClaims{
+		"invite_link_id": linkID,
+		"aud":            inviteAudience,
+		"iat":            time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+func ValidateInviteToken(tokenString string, secret string) (int64, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	}, jwt.WithAudience(inviteAudience))
+
+	if err != nil {
+		return 0, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return 0, fmt.Errorf("invalid token")
+	}
+
+	linkID, ok := claims["invite_link_id"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("invalid invite_link_id in token")
+	}
+
+	return int64(linkID), nil
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestGenerateAndValidateInviteToken(t *testing.T) {
+	secret := "test-secret"
+
+	token, err := GenerateInviteToken(7, secret)
+	if err != nil {
+		t.Fatalf("GenerateInviteToken failed: %v", err)
+	}
+
+	gotID, err := ValidateInviteToken(token, secret)
+	if err != nil {
+		t.Fatalf("ValidateInviteToken failed: %v", err)
+	}
+	if gotID != 7 {
+		t.Errorf("ValidateInviteToken returned link id %d, want 7", gotID)
+	}
+}
+
+func TestValidateInviteToken_WrongSecret(t *testing.T) {
+	token, _ := GenerateInviteToken(7, "secret-a")
+	if _, err := ValidateInviteToken(token, "secret-b"); err == nil {
+		t.Error("ValidateInviteToken should fail with wrong secret")
+	}
+}
+
+func TestValidateInviteToken_RejectsSessionToken(t *testing.T) {
+	token, _ := GenerateToken(1, "test-secret")
+	if _, err := ValidateInviteToken(token, "test-secret"); err == nil {
+		t.Error("ValidateInviteToken should reject a session token")
+	}
+}
+
+func TestValidateInviteToken_RequiresAudience(t *testing.T) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"invite_link_id": float64(7)})
+	tokenString, _ := token.SignedString([]byte("test-secret"))
+	if _, err := ValidateInviteToken(tokenString, "test-secret"); err == nil {
+		t.Error("ValidateInviteToken should reject a token without the invite audience")
+	}
+}
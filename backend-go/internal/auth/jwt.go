@@ -2,14 +2,23 @@ package auth
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Session and invite link tokens are signed with the same secret, so each
+// carries an audience and is only accepted where that audience is expected.
+const (
+	sessionAudience = "session"
+	inviteAudience  = "invite"
+)
+
 func GenerateToken(playerID int64, secret string) (string, error) {
 	claims := jwt.MapClaims{
 		"player_id": playerID,
+		"aud":       sessionAudience,
 		"exp":       time.Now().Add(24 * time.Hour).Unix(),
 		"iat":       time.Now().Unix(),
 	}
@@ -35,6 +44,12 @@ func ValidateToken(tokenString string, secret string) (int64, error) {
 		return 0, fmt.Errorf("invalid token")
 	}
 
+	// Sessions issued before audiences were added have none and are still
+	// accepted until they expire.
+	if aud, err := claims.GetAudience(); err != nil || (len(aud) > 0 && !slices.Contains(aud, sessionAudience)) {
+		return 0, fmt.Errorf("invalid token audience")
+	}
+
 	playerID, ok := claims["player_id"].(float64)
 	if !ok {
 		return 0, fmt.Errorf("invalid player_id in token")
@@ -58,3 +58,18 @@ func TestValidateToken_InvalidFormat(t *testing.T) {
 		t.Error("ValidateToken should fail with invalid token format")
 	}
 }
+
+func TestValidateToken_RejectsOtherAudience(t *testing.T) {
+	secret := "test-secret"
+	claims := jwt.MapClaims{
+		"player_id": float64(1),
+		"aud":       inviteAudience,
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, _ := token.SignedString([]byte(secret))
+
+	if _, err := ValidateToken(tokenString, secret); err == nil {
+		t.Error("ValidateToken should reject a token meant for invite links")
+	}
+}
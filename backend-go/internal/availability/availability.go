@@ -0,0 +1,132 @@
+package availability
+
+import (
+	"sort"
+	"time"
+)
+
+// Step is the minutes between proposed tee times.
+const Step = 10
+
+// MinutesPerDay bounds the minutes used for windows and tee times.
+const MinutesPerDay = 24 * 60
+
+// Window is a weekly stretch of time a player is free, in minutes after
+// midnight.
+type Window struct {
+	Day  time.Weekday
+	From int
+	To   int
+}
+
+// Busy is part of the day a player has already committed to, in minutes
+// after midnight.
+type Busy struct {
+	From int
+	To   int
+}
+
+// Player is one of the group a game is being proposed for.
+type Player struct {
+	ID      int64
+	Windows []Window
+	Busy    []Busy
+	// PrefersCourse is set when the course is one of the player's preferred
+	// courses.
+	PrefersCourse bool
+}
+
+// Proposal is a run of tee times that suit the same players, from TeeTime
+// to Latest inclusive.
+type Proposal struct {
+	TeeTime      int
+	Latest       int
+	Available    []int64
+	Unavailable  []int64
+	PreferCourse int
+}
+
+// free reports whether the player can play from start for the duration.
+func (p Player) free(day time.Weekday, start, duration int) bool {
+	end := start + duration
+	for _, b := range p.Busy {
+		if b.From < end && start < b.To {
+			return false
+		}
+	}
+	for _, w := range p.Windows {
+		if w.Day == day && w.From <= start && end <= w.To {
+			return true
+		}
+	}
+	return false
+}
+
+func sameIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Propose finds the tee times on the day, no earlier than earliest, when
+// players are free for a round of the given minutes. Consecutive tee times
+// that suit the same players are merged into one proposal. Proposals are
+// ordered by how many players are available, then how many of them prefer
+// the course, then by tee time. Tee times nobody can make are left out.
+func Propose(players []Player, day time.Weekday, earliest, duration int) []Proposal {
+	var proposals []Proposal
+	start := (earliest + Step - 1) / Step * Step
+	for t := start; t+duration <= MinutesPerDay; t += Step {
+		var available, unavailable []int64
+		prefer := 0
+		for _, p := range players {
+			if p.free(day, t, duration) {
+				available = append(available, p.ID)
+				if p.PrefersCourse {
+					prefer++
+				}
+			} else {
+				unavailable = append(unavailable, p.ID)
+			}
+		}
+		if len(available) == 0 {
+			continue
+		}
+
+		if n := len(proposals); n > 0 {
+			last := &proposals[n-1]
+			if last.Latest == t-Step && sameIDs(last.Available, available) {
+				last.Latest = t
+				continue
+			}
+		}
+		if unavailable == nil {
+			unavailable = []int64{}
+		}
+		proposals = append(proposals, Proposal{
+			TeeTime:      t,
+			Latest:       t,
+			Available:    available,
+			Unavailable:  unavailable,
+			PreferCourse: prefer,
+		})
+	}
+
+	sort.SliceStable(proposals, func(i, j int) bool {
+		a, b := proposals[i], proposals[j]
+		if len(a.Available) != len(b.Available) {
+			return len(a.Available) > len(b.Available)
+		}
+		if a.PreferCourse != b.PreferCourse {
+			return a.PreferCourse > b.PreferCourse
+		}
+		return a.TeeTime < b.TeeTime
+	})
+	return proposals
+}
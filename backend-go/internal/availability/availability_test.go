@@ -0,0 +1,65 @@
+package availability
+
+import (
+	"testing"
+	"time"
+)
+
+const round = 270 // 4.5 hours
+
+func saturday(from, to int) Window {
+	return Window{Day: time.Saturday, From: from * 60, To: to * 60}
+}
+
+func TestPropose_MaximizesOverlap(t *testing.T) {
+	players := []Player{
+		{ID: 1, Windows: []Window{saturday(7, 13)}},
+		{ID: 2, Windows: []Window{saturday(8, 14)}},
+		{ID: 3, Windows: []Window{saturday(12, 18)}},
+	}
+	proposals := Propose(players, time.Saturday, 0, round)
+
+	best := proposals[0]
+	if best.TeeTime != 8*60 || best.Latest != 8*60+30 {
+		t.Fatalf("expected 08:00 to 08:30 to suit the most players, got %+v", best)
+	}
+	if len(best.Available) != 2 || best.Available[0] != 1 || best.Available[1] != 2 || len(best.Unavailable) != 1 || best.Unavailable[0] != 3 {
+		t.Errorf("expected players 1 and 2 free and 3 not, got %+v", best)
+	}
+	if last := proposals[len(proposals)-1]; len(last.Available) != 1 {
+		t.Errorf("expected single player proposals last, got %+v", last)
+	}
+}
+
+func TestPropose_OtherDaysAndBusyTimes(t *testing.T) {
+	players := []Player{
+		{ID: 1, Windows: []Window{saturday(7, 18), {Day: time.Sunday, From: 0, To: MinutesPerDay}}, Busy: []Busy{{From: 9 * 60, To: 12 * 60}}},
+	}
+	proposals := Propose(players, time.Saturday, 0, round)
+
+	if len(proposals) != 1 || proposals[0].TeeTime != 12*60 || proposals[0].Latest != 13*60+30 {
+		t.Errorf("expected only tee times after the busy morning, got %+v", proposals)
+	}
+	if proposals := Propose(players, time.Monday, 0, round); len(proposals) != 0 {
+		t.Errorf("expected nothing on a day without windows, got %+v", proposals)
+	}
+}
+
+func TestPropose_PreferredCourseAndEarliest(t *testing.T) {
+	players := []Player{
+		{ID: 1, Windows: []Window{saturday(7, 12), saturday(13, 18)}},
+		{ID: 2, Windows: []Window{saturday(13, 18)}, PrefersCourse: true},
+		{ID: 3, Windows: []Window{saturday(7, 12)}},
+	}
+	proposals := Propose(players, time.Saturday, 0, round)
+	if proposals[0].TeeTime != 13*60 || proposals[0].PreferCourse != 1 {
+		t.Errorf("expected the afternoon with the player who prefers the course first, got %+v", proposals[0])
+	}
+
+	proposals = Propose(players, time.Saturday, 7*60+25, round)
+	for _, p := range proposals {
+		if p.TeeTime < 7*60+30 {
+			t.Errorf("expected nothing before 07:30, got %+v", p)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package bracket
+
+import "sort"
+
+// Entrant is a player registered for a tournament.
+type Entrant struct {
+	PlayerID int64
+	Handicap *float64
+}
+
+// Match is a first round pairing. Player2 is 0 when Player1 has a bye.
+type Match struct {
+	Player1 int64
+	Player2 int64
+}
+
+// Seed orders the entrants from first seed to last: lowest handicap first,
+// then players without a handicap, with ties going to the earlier
+// registration.
+func Seed(entrants []Entrant) []int64 {
+	sorted := make([]Entrant, len(entrants))
+	copy(sorted, entrants)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Handicap, sorted[j].Handicap
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+
+	seeds := make([]int64, len(sorted))
+	for i, e := range sorted {
+		seeds[i] = e.PlayerID
+	}
+	return seeds
+}
+
+// Size is the number of first round slots for n players, the next power of
+// two.
+func Size(n int) int {
+	size := 1
+	for size < n {
+		size *= 2
+	}
+	return size
+}
+
+// Rounds is how many rounds it takes to get from n players to a winner.
+func Rounds(n int) int {
+	rounds := 0
+	for size := Size(n); size > 1; size /= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// order is the seed number in each slot of a bracket of the given size, so
+// the top two seeds can only meet in the final: 1, 8, 4, 5, 2, 7, 3, 6 for
+// eight slots.
+func order(size int) []int {
+	slots := []int{1}
+	for n := 2; n <= size; n *= 2 {
+		next := make([]int, 0, n)
+		for _, s := range slots {
+			next = append(next, s, n+1-s)
+		}
+		slots = next
+	}
+	return slots
+}
+
+// FirstRound pairs the seeded players for the first round. The bracket is
+// filled out with byes, which go to the top seeds.
+func FirstRound(seeds []int64) []Match {
+	if len(seeds) < 2 {
+		return nil
+	}
+	slots := order(Size(len(seeds)))
+	player := func(seed int) int64 {
+		if seed > len(seeds) {
+			return 0
+		}
+		return seeds[seed-1]
+	}
+
+	matches := make([]Match, len(slots)/2)
+	for i := range matches {
+		matches[i] = Match{Player1: player(slots[2*i]), Player2: player(slots[2*i+1])}
+	}
+	return matches
+}
+
+// Next is the position in the following round the winner of the match at
+// position plays, and whether they take the first slot.
+func Next(position int) (int, bool) {
+	return position / 2, position%2 == 0
+}
@@ -0,0 +1,65 @@
+package bracket
+
+import "testing"
+
+func handicap(v float64) *float64 { return &v }
+
+func TestSeed(t *testing.T) {
+	seeds := Seed([]Entrant{
+		{PlayerID: 1, Handicap: handicap(12.4)},
+		{PlayerID: 2},
+		{PlayerID: 3, Handicap: handicap(3.1)},
+		{PlayerID: 4, Handicap: handicap(12.4)},
+		{PlayerID: 5, Handicap: handicap(-1.0)},
+	})
+
+	want := []int64{5, 3, 1, 4, 2}
+	for i, id := range want {
+		if seeds[i] != id {
+			t.Fatalf("expected %v, got %v", want, seeds)
+		}
+	}
+}
+
+func TestSizeAndRounds(t *testing.T) {
+	for _, c := range []struct{ n, size, rounds int }{{2, 2, 1}, {3, 4, 2}, {8, 8, 3}, {9, 16, 4}} {
+		if got := Size(c.n); got != c.size {
+			t.Errorf("Size(%d): expected %d, got %d", c.n, c.size, got)
+		}
+		if got := Rounds(c.n); got != c.rounds {
+			t.Errorf("Rounds(%d): expected %d, got %d", c.n, c.rounds, got)
+		}
+	}
+}
+
+func TestFirstRound_Eight(t *testing.T) {
+	matches := FirstRound([]int64{1, 2, 3, 4, 5, 6, 7, 8})
+	want := []Match{{1, 8}, {4, 5}, {2, 7}, {3, 6}}
+	if len(matches) != len(want) {
+		t.Fatalf("expected %v, got %v", want, matches)
+	}
+	for i, m := range want {
+		if matches[i] != m {
+			t.Errorf("match %d: expected %v, got %v", i, m, matches[i])
+		}
+	}
+}
+
+func TestFirstRound_ByesGoToTopSeeds(t *testing.T) {
+	matches := FirstRound([]int64{10, 20, 30, 40, 50})
+	want := []Match{{10, 0}, {40, 50}, {20, 0}, {30, 0}}
+	for i, m := range want {
+		if matches[i] != m {
+			t.Errorf("match %d: expected %v, got %v", i, m, matches[i])
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	if pos, first := Next(0); pos != 0 || !first {
+		t.Errorf("expected position 0 first slot, got %d %v", pos, first)
+	}
+	if pos, first := Next(3); pos != 1 || first {
+		t.Errorf("expected position 1 second slot, got %d %v", pos, first)
+	}
+}
@@ -0,0 +1,303 @@
+package games
+
+import (
+	"sort"
+
+	"github.com/ericrabun/findfore-go/internal/ledger"
+	"github.com/ericrabun/findfore-go/internal/scoring"
+)
+
+// Format is a money game played over a round.
+type Format int
+
+const (
+	Skins Format = iota
+	Nassau
+	Stableford
+	MatchPlay
+)
+
+// HeadToHead reports whether the format is played between exactly two
+// players.
+func (f Format) HeadToHead() bool {
+	return f == Nassau || f == MatchPlay
+}
+
+// Hole is one hole of a player's card. Par and StrokeIndex are zero when the
+// card has no tee set.
+type Hole struct {
+	Strokes     int32
+	Par         int32
+	StrokeIndex int32
+}
+
+// Card is a player's round. CourseHandicap only matters in net games.
+type Card struct {
+	PlayerID       int64
+	CourseHandicap int
+	Holes          map[int32]Hole
+}
+
+// Game is a format with its stake. In skins the stake is per skin, in
+// stableford per point, and in match play and Nassau per match.
+type Game struct {
+	Format     Format
+	Net        bool
+	StakeCents int64
+	// Carryover rolls tied skins into the next hole instead of dropping them.
+	Carryover bool
+}
+
+// Result is how a player did: skins won, stableford points, holes won in
+// match play, or matches won in Nassau, and what they won or lost.
+type Result struct {
+	PlayerID      int64
+	Points        int
+	WinningsCents int64
+}
+
+// Play scores the game from the cards and works out who pays whom. Only
+// holes every player has finished count, so a game can be followed while the
+// round is played.
+func Play(g Game, cards []Card) ([]Result, []ledger.Debt) {
+	holes := sharedHoles(cards)
+	scores := netScores(g, cards, holes)
+
+	var points map[int64]int
+	var debts []ledger.Debt
+	switch g.Format {
+	case Skins:
+		points, debts = skins(g, cards, holes, scores)
+	case Stableford:
+		points, debts = stableford(g, cards, holes, scores)
+	case MatchPlay:
+		points, debts = match(g, cards, holes, scores)
+	case Nassau:
+		points, debts = nassau(g, cards, holes, scores)
+	}
+	debts = ledger.Net(debts)
+
+	winnings := map[int64]int64{}
+	for _, d := range debts {
+		winnings[d.From] -= d.AmountCents
+		winnings[d.To] += d.AmountCents
+	}
+	results := make([]Result, len(cards))
+	for i, c := range cards {
+		results[i] = Result{PlayerID: c.PlayerID, Points: points[c.PlayerID], WinningsCents: winnings[c.PlayerID]}
+	}
+	return results, debts
+}
+
+// sharedHoles is the holes every card has a score on, in order.
+func sharedHoles(cards []Card) []int32 {
+	if len(cards) == 0 {
+		return nil
+	}
+	var holes []int32
+	for number := range cards[0].Holes {
+		shared := true
+		for _, c := range cards[1:] {
+			if _, ok := c.Holes[number]; !ok {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			holes = append(holes, number)
+		}
+	}
+	sort.Slice(holes, func(i, j int) bool { return holes[i] < holes[j] })
+	return holes
+}
+
+// netScores is each player's score per hole, less any strokes received in a
+// net game. Head to head games play off the lower handicap, so only the
+// difference is given.
+func netScores(g Game, cards []Card, holes []int32) map[int64]map[int32]int {
+	low := 0
+	if g.Format.HeadToHead() && len(cards) > 0 {
+		low = cards[0].CourseHandicap
+		for _, c := range cards[1:] {
+			low = min(low, c.CourseHandicap)
+		}
+	}
+
+	scores := make(map[int64]map[int32]int, len(cards))
+	for _, c := range cards {
+		scores[c.PlayerID] = make(map[int32]int, len(holes))
+		for _, number := range holes {
+			h := c.Holes[number]
+			score := int(h.Strokes)
+			if g.Net && h.StrokeIndex > 0 {
+				score -= scoring.StrokesReceived(c.CourseHandicap-low, h.StrokeIndex)
+			}
+			scores[c.PlayerID][number] = score
+		}
+	}
+	return scores
+}
+
+// holeWinner is the player with the outright lowest score on the hole.
+func holeWinner(cards []Card, scores map[int64]map[int32]int, number int32) (int64, bool) {
+	var winner int64
+	best, tied := 0, false
+	for i, c := range cards {
+		score := scores[c.PlayerID][number]
+		switch {
+		case i == 0 || score < best:
+			winner, best, tied = c.PlayerID, score, false
+		case score == best:
+			tied = true
+		}
+	}
+	return winner, !tied
+}
+
+// skins gives each hole to its outright winner, who collects the stake from
+// every other player for each skin.
+func skins(g Game, cards []Card, holes []int32, scores map[int64]map[int32]int) (map[int64]int, []ledger.Debt) {
+	points := map[int64]int{}
+	var debts []ledger.Debt
+	value := 1
+	for _, number := range holes {
+		winner, ok := holeWinner(cards, scores, number)
+		if !ok {
+			if g.Carryover {
+				value++
+			}
+			continue
+		}
+		points[winner] += value
+		for _, c := range cards {
+			if c.PlayerID != winner {
+				debts = append(debts, ledger.Debt{From: c.PlayerID, To: winner, AmountCents: int64(value) * g.StakeCents})
+			}
+		}
+		value = 1
+	}
+	return points, debts
+}
+
+// stableford scores two points for par, one more for each stroke better and
+// one less for each worse, down to zero. Players pay each other the stake for
+// every point between them.
+func stableford(g Game, cards []Card, holes []int32, scores map[int64]map[int32]int) (map[int64]int, []ledger.Debt) {
+	points := map[int64]int{}
+	for _, c := range cards {
+		for _, number := range holes {
+			if par := c.Holes[number].Par; par > 0 {
+				points[c.PlayerID] += max(0, 2+int(par)-scores[c.PlayerID][number])
+			}
+		}
+	}
+
+	var debts []ledger.Debt
+	for i, a := range cards {
+		for _, b := range cards[i+1:] {
+			diff := points[a.PlayerID] - points[b.PlayerID]
+			switch {
+			case diff > 0:
+				debts = append(debts, ledger.Debt{From: b.PlayerID, To: a.PlayerID, AmountCents: int64(diff) * g.StakeCents})
+			case diff < 0:
+				debts = append(debts, ledger.Debt{From: a.PlayerID, To: b.PlayerID, AmountCents: int64(-diff) * g.StakeCents})
+			}
+		}
+	}
+	return points, debts
+}
+
+// standing plays a match over the holes and returns the holes each player
+// won and the winner, if the match wasn't halved.
+func standing(cards []Card, holes []int32, scores map[int64]map[int32]int) (map[int64]int, int64, bool) {
+	won := map[int64]int{}
+	for _, number := range holes {
+		if winner, ok := holeWinner(cards, scores, number); ok {
+			won[winner]++
+		}
+	}
+	a, b := cards[0].PlayerID, cards[1].PlayerID
+	switch {
+	case won[a] > won[b]:
+		return won, a, true
+	case won[b] > won[a]:
+		return won, b, true
+	}
+	return won, 0, false
+}
+
+// Match plays a against b hole by hole over the holes they've both finished
+// and returns the winner, if the match wasn't halved.
+func Match(net bool, a, b Card) (int64, bool) {
+	cards := []Card{a, b}
+	holes := sharedHoles(cards)
+	_, winner, ok := standing(cards, holes, netScores(Game{Format: MatchPlay, Net: net}, cards, holes))
+	return winner, ok
+}
+
+// Score is the card's total over its holes, less strokes received in net
+// play. Cards without stroke indexes take the whole course handicap off the
+// total.
+func Score(net bool, c Card) int {
+	total, received, rated := 0, 0, false
+	for _, h := range c.Holes {
+		total += int(h.Strokes)
+		if h.StrokeIndex > 0 {
+			received += scoring.StrokesReceived(c.CourseHandicap, h.StrokeIndex)
+			rated = true
+		}
+	}
+	switch {
+	case !net:
+		return total
+	case rated:
+		return total - received
+	default:
+		return total - c.CourseHandicap
+	}
+}
+
+func loser(cards []Card, winner int64) int64 {
+	if cards[0].PlayerID == winner {
+		return cards[1].PlayerID
+	}
+	return cards[0].PlayerID
+}
+
+// match is a single match over the round for the stake.
+func match(g Game, cards []Card, holes []int32, scores map[int64]map[int32]int) (map[int64]int, []ledger.Debt) {
+	if len(cards) != 2 {
+		return map[int64]int{}, nil
+	}
+	won, winner, ok := standing(cards, holes, scores)
+	if !ok {
+		return won, nil
+	}
+	return won, []ledger.Debt{{From: loser(cards, winner), To: winner, AmountCents: g.StakeCents}}
+}
+
+// nassau is three matches for the stake each: the front nine, the back nine
+// and the full round.
+func nassau(g Game, cards []Card, holes []int32, scores map[int64]map[int32]int) (map[int64]int, []ledger.Debt) {
+	points := map[int64]int{}
+	if len(cards) != 2 {
+		return points, nil
+	}
+	var front, back []int32
+	for _, number := range holes {
+		if number <= 9 {
+			front = append(front, number)
+		} else {
+			back = append(back, number)
+		}
+	}
+
+	var debts []ledger.Debt
+	for _, matchHoles := range [][]int32{front, back, holes} {
+		if _, winner, ok := standing(cards, matchHoles, scores); ok {
+			points[winner]++
+			debts = append(debts, ledger.Debt{From: loser(cards, winner), To: winner, AmountCents: g.StakeCents})
+		}
+	}
+	return points, debts
+}
@@ -0,0 +1,173 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/ericrabun/findfore-go/internal/ledger"
+)
+
+// card builds a card from strokes on consecutive holes starting at 1, all par
+// 4 with stroke index equal to the hole number.
+func card(playerID int64, courseHandicap int, strokes ...int32) Card {
+	c := Card{PlayerID: playerID, CourseHandicap: courseHandicap, Holes: map[int32]Hole{}}
+	for i, s := range strokes {
+		c.Holes[int32(i+1)] = Hole{Strokes: s, Par: 4, StrokeIndex: int32(i + 1)}
+	}
+	return c
+}
+
+func resultFor(results []Result, playerID int64) Result {
+	for _, r := range results {
+		if r.PlayerID == playerID {
+			return r
+		}
+	}
+	return Result{}
+}
+
+func TestSkins_CarryOver(t *testing.T) {
+	cards := []Card{
+		card(1, 0, 4, 4, 3),
+		card(2, 0, 4, 5, 5),
+		card(3, 0, 5, 4, 5),
+	}
+	results, debts := Play(Game{Format: Skins, StakeCents: 100, Carryover: true}, cards)
+
+	// Hole 1 and 2 are tied, so player 1 takes three skins on hole 3.
+	if r := resultFor(results, 1); r.Points != 3 || r.WinningsCents != 600 {
+		t.Errorf("expected player 1 to win 3 skins for 600, got %+v", r)
+	}
+	want := []ledger.Debt{{From: 2, To: 1, AmountCents: 300}, {From: 3, To: 1, AmountCents: 300}}
+	if len(debts) != 2 || debts[0] != want[0] || debts[1] != want[1] {
+		t.Errorf("expected %+v, got %+v", want, debts)
+	}
+}
+
+func TestSkins_NoCarryOver(t *testing.T) {
+	cards := []Card{card(1, 0, 4, 3), card(2, 0, 4, 5)}
+	results, _ := Play(Game{Format: Skins, StakeCents: 100}, cards)
+
+	if r := resultFor(results, 1); r.Points != 1 || r.WinningsCents != 100 {
+		t.Errorf("expected player 1 to win 1 skin for 100, got %+v", r)
+	}
+}
+
+func TestSkins_Net(t *testing.T) {
+	// Player 2 gets a stroke on the first hole and halves it in net.
+	cards := []Card{card(1, 0, 4, 4), card(2, 1, 5, 3)}
+	results, _ := Play(Game{Format: Skins, Net: true, StakeCents: 100}, cards)
+
+	if r := resultFor(results, 2); r.Points != 1 || r.WinningsCents != 100 {
+		t.Errorf("expected player 2 to win 1 skin for 100, got %+v", r)
+	}
+}
+
+func TestPlay_OnlySharedHoles(t *testing.T) {
+	// Player 2 hasn't finished hole 2 yet.
+	cards := []Card{card(1, 0, 4, 3), card(2, 0, 5)}
+	results, _ := Play(Game{Format: Skins, StakeCents: 100}, cards)
+
+	if r := resultFor(results, 1); r.Points != 1 {
+		t.Errorf("expected player 1 to win only the first hole, got %+v", r)
+	}
+}
+
+func TestStableford(t *testing.T) {
+	cards := []Card{
+		card(1, 0, 3, 4, 7), // 3 + 2 + 0
+		card(2, 0, 4, 4, 5), // 2 + 2 + 1
+	}
+	results, debts := Play(Game{Format: Stableford, StakeCents: 50}, cards)
+
+	if r := resultFor(results, 1); r.Points != 5 || r.WinningsCents != 0 {
+		t.Errorf("expected player 1 to score 5 and break even, got %+v", r)
+	}
+	if len(debts) != 0 {
+		t.Errorf("expected no debts on equal points, got %+v", debts)
+	}
+
+	cards[1] = card(2, 0, 5, 5, 5)
+	results, debts = Play(Game{Format: Stableford, StakeCents: 50}, cards)
+	if r := resultFor(results, 2); r.Points != 3 || r.WinningsCents != -100 {
+		t.Errorf("expected player 2 to score 3 and lose 100, got %+v", r)
+	}
+	if len(debts) != 1 || debts[0] != (ledger.Debt{From: 2, To: 1, AmountCents: 100}) {
+		t.Errorf("expected 2 to owe 1 100, got %+v", debts)
+	}
+}
+
+func TestMatchPlay_OffTheLowHandicap(t *testing.T) {
+	// Player 2 gets one stroke, on hole 1, and wins it.
+	cards := []Card{card(1, 10, 4, 4, 4), card(2, 11, 4, 5, 4)}
+	results, debts := Play(Game{Format: MatchPlay, Net: true, StakeCents: 1000}, cards)
+	if len(debts) != 0 {
+		t.Errorf("expected the match halved, got %+v", debts)
+	}
+	if r := resultFor(results, 2); r.Points != 1 {
+		t.Errorf("expected player 2 to win one hole, got %+v", r)
+	}
+
+	_, debts = Play(Game{Format: MatchPlay, StakeCents: 1000}, cards)
+	if len(debts) != 1 || debts[0] != (ledger.Debt{From: 2, To: 1, AmountCents: 1000}) {
+		t.Errorf("expected 2 to owe 1 1000 in gross, got %+v", debts)
+	}
+}
+
+func TestNassau(t *testing.T) {
+	front := []int32{4, 4, 4, 4, 4, 4, 4, 4, 3}
+	back := []int32{4, 4, 4, 4, 4, 4, 4, 4, 4}
+	a := card(1, 0, append(append([]int32{}, front...), back...)...)
+	worse := append(append([]int32{}, back...), 4, 4, 4, 4, 4, 4, 4, 5, 5)
+	b := card(2, 0, worse...)
+
+	// Player 1 wins the front by one, the back by two and the overall.
+	results, debts := Play(Game{Format: Nassau, StakeCents: 500}, []Card{a, b})
+	if r := resultFor(results, 1); r.Points != 3 || r.WinningsCents != 1500 {
+		t.Errorf("expected player 1 to win all three for 1500, got %+v", r)
+	}
+	if len(debts) != 1 || debts[0] != (ledger.Debt{From: 2, To: 1, AmountCents: 1500}) {
+		t.Errorf("expected 2 to owe 1 1500, got %+v", debts)
+	}
+}
+
+func TestNassau_SplitMatches(t *testing.T) {
+	a := card(1, 0, 3, 4, 4, 4, 4, 4, 4, 4, 4, 5, 4, 4, 4, 4, 4, 4, 4, 4)
+	b := card(2, 0, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4)
+
+	// Player 1 wins the front, player 2 the back, and the overall is halved.
+	results, debts := Play(Game{Format: Nassau, StakeCents: 500}, []Card{a, b})
+	if r := resultFor(results, 1); r.Points != 1 || r.WinningsCents != 0 {
+		t.Errorf("expected player 1 to win one match and break even, got %+v", r)
+	}
+	if len(debts) != 0 {
+		t.Errorf("expected no debts, got %+v", debts)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	a := card(1, 0, 4, 4, 5)
+	b := card(2, 2, 5, 5, 4)
+	if winner, ok := Match(false, a, b); !ok || winner != 1 {
+		t.Errorf("expected player 1 to win gross, got %d %v", winner, ok)
+	}
+	// Player 2 gets strokes on the first two holes and wins all three.
+	if winner, ok := Match(true, a, b); !ok || winner != 2 {
+		t.Errorf("expected player 2 to win net, got %d %v", winner, ok)
+	}
+}
+
+func TestScore(t *testing.T) {
+	c := card(1, 2, 5, 5, 4)
+	if got := Score(false, c); got != 14 {
+		t.Errorf("expected gross 14, got %d", got)
+	}
+	if got := Score(true, c); got != 12 {
+		t.Errorf("expected net 12, got %d", got)
+	}
+	c.Holes[1] = Hole{Strokes: 5, Par: 4}
+	c.Holes[2] = Hole{Strokes: 5, Par: 4}
+	c.Holes[3] = Hole{Strokes: 4, Par: 4}
+	if got := Score(true, c); got != 12 {
+		t.Errorf("expected the course handicap off an unrated card, got %d", got)
+	}
+}
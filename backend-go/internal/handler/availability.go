@@ -0,0 +1,362 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ericrabun/findfore-go/internal/availability"
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+// maxProposals is how many tee time proposals are returned.
+const maxProposals = 5
+
+func weekdayToString(day time.Weekday) string {
+	return strings.ToLower(day.String())
+}
+
+func weekdayToInt(day string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if weekdayToString(d) == day {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// minuteOfDay converts an HH:MM time to minutes after midnight.
+func minuteOfDay(clock string) (int, bool) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func formatMinuteOfDay(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+type availabilityWindowRequest struct {
+	Day  string `json:"day"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type updateAvailabilityRequest struct {
+	Windows            []availabilityWindowRequest `json:"windows"`
+	PreferredCourseIDs []int64                     `json:"preferred_course_ids"`
+}
+
+func (h *Handler) respondAvailability(w http.ResponseWriter, r *http.Request, pid int64) {
+	windows, err := h.queries.ListPlayerAvailability(r.Context(), []int64{pid})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch availability")
+		return
+	}
+	courses, err := h.queries.ListPlayerPreferredCourses(r.Context(), []int64{pid})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch availability")
+		return
+	}
+
+	resp := model.AvailabilityResponse{
+		PlayerID:           pid,
+		Windows:            make([]model.AvailabilityWindowResponse, len(windows)),
+		PreferredCourseIDs: make([]int64, len(courses)),
+	}
+	for i, win := range windows {
+		resp.Windows[i] = model.AvailabilityWindowResponse{
+			Day:  weekdayToString(time.Weekday(win.DayOfWeek)),
+			From: formatMinuteOfDay(int(win.StartMinute)),
+			To:   formatMinuteOfDay(int(win.EndMinute)),
+		}
+	}
+	for i, c := range courses {
+		resp.PreferredCourseIDs[i] = c.CourseID
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetAvailability returns the player's weekly availability and preferred
+// courses.
+func (h *Handler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.queries.GetPlayerByID(r.Context(), pid); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	h.respondAvailability(w, r, pid)
+}
+
+// UpdateAvailability replaces the player's weekly availability windows and
+// preferred courses. Windows are a day of the week with from and to times
+// and can't cross midnight.
+func (h *Handler) UpdateAvailability(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	var req updateAvailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+
+	params := store.SetPlayerAvailabilityParams{PlayerID: pid}
+	for _, win := range req.Windows {
+		day, ok := weekdayToInt(win.Day)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "validation_error", "Day must be a day of the week like saturday")
+			return
+		}
+		from, okFrom := minuteOfDay(win.From)
+		to, okTo := minuteOfDay(win.To)
+		if !okFrom || !okTo {
+			respondError(w, http.StatusBadRequest, "validation_error", "Times must be formatted HH:MM")
+			return
+		}
+		if to <= from {
+			respondError(w, http.StatusBadRequest, "validation_error", "Windows must end after they start")
+			return
+		}
+		params.Windows = append(params.Windows, store.CreatePlayerAvailabilityParams{
+			DayOfWeek:   int32(day),
+			StartMinute: int32(from),
+			EndMinute:   int32(to),
+		})
+	}
+	for _, courseID := range req.PreferredCourseIDs {
+		if _, err := h.queries.GetCourseByID(r.Context(), courseID); err != nil {
+			respondError(w, http.StatusNotFound, "not_found", "Course not found")
+			return
+		}
+	}
+	params.CourseIDs = req.PreferredCourseIDs
+
+	if err := store.SetPlayerAvailability(r.Context(), h.db, h.queries, params); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "not_found", "Player not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to save availability")
+		return
+	}
+
+	h.respondAvailability(w, r, pid)
+}
+
+type proposeGameRequest struct {
+	CourseID      int64   `json:"course_id"`
+	Date          string  `json:"date"`
+	PlayerIDs     []int64 `json:"player_ids"`
+	NumberOfHoles string  `json:"number_of_holes"`
+	Create        bool    `json:"create"`
+	OpenSpots     *int32  `json:"open_spots"`
+	Private       bool    `json:"private"`
+}
+
+// ProposeGame suggests tee times at the course on the date that suit as many
+// of the host and the chosen players as possible, using their weekly
+// availability and leaving out games they've already accepted. Ties go to
+// the times more of the available players list the course as preferred.
+// The players must be ones the host follows. The date and the earliest tee
+// time are in the course's local time. With create set, the event is created
+// at the best tee time the host is free for, with the players invited.
+func (h *Handler) ProposeGame(w http.ResponseWriter, r *http.Request) {
+	hostID, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	var req proposeGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "validation_error", "Date is invalid")
+		return
+	}
+	course, err := h.queries.GetCourseByID(r.Context(), req.CourseID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Course not found")
+		return
+	}
+	if date.Before(courseToday(course.TimeZone)) {
+		respondError(w, http.StatusBadRequest, "validation_error", "Date can't be in the past")
+		return
+	}
+	if req.NumberOfHoles == "" {
+		req.NumberOfHoles = "18"
+	}
+	if req.NumberOfHoles != "9" && req.NumberOfHoles != "18" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Number of holes must be 9 or 18")
+		return
+	}
+
+	seen := map[int64]bool{hostID: true}
+	var friends []int64
+	for _, id := range req.PlayerIDs {
+		if !seen[id] {
+			seen[id] = true
+			friends = append(friends, id)
+		}
+	}
+	if len(friends) == 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Choose at least one player to play with")
+		return
+	}
+	group := append([]int64{hostID}, friends...)
+	openSpots := int32(max(4, len(group)))
+	if req.OpenSpots != nil {
+		if *req.OpenSpots < int32(len(group)) {
+			respondError(w, http.StatusBadRequest, "validation_error", "Open spots must fit the whole group")
+			return
+		}
+		openSpots = *req.OpenSpots
+	}
+
+	followees, err := h.queries.ListFolloweeIDsByFollowerID(r.Context(), sql.NullInt32{Int32: int32(hostID), Valid: true})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch friends")
+		return
+	}
+	followed := make(map[int64]bool, len(followees))
+	for _, id := range followees {
+		followed[int64(id.Int32)] = true
+	}
+	for _, id := range friends {
+		if !followed[id] {
+			respondError(w, http.StatusBadRequest, "validation_error", "You can only propose games with players you follow")
+			return
+		}
+	}
+
+	windows, err := h.queries.ListPlayerAvailability(r.Context(), group)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch availability")
+		return
+	}
+	preferred, err := h.queries.ListPlayerPreferredCourses(r.Context(), group)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch availability")
+		return
+	}
+	commitments, err := h.queries.ListPlayerCommitments(r.Context(), store.ListPlayerCommitmentsParams{
+		PlayerIds:    group,
+		StartsAfter:  date,
+		StartsBefore: date.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch events")
+		return
+	}
+
+	players := make([]availability.Player, len(group))
+	index := make(map[int64]int, len(group))
+	for i, id := range group {
+		players[i] = availability.Player{ID: id}
+		index[id] = i
+	}
+	for _, win := range windows {
+		p := &players[index[win.PlayerID]]
+		p.Windows = append(p.Windows, availability.Window{
+			Day:  time.Weekday(win.DayOfWeek),
+			From: int(win.StartMinute),
+			To:   int(win.EndMinute),
+		})
+	}
+	for _, c := range preferred {
+		if c.CourseID == req.CourseID {
+			players[index[c.PlayerID]].PrefersCourse = true
+		}
+	}
+	for _, c := range commitments {
+		start := c.StartsAt.Time.Hour()*60 + c.StartsAt.Time.Minute()
+		p := &players[index[c.PlayerID.Int64]]
+		p.Busy = append(p.Busy, availability.Busy{
+			From: start,
+			To:   start + int(store.EstimatedDuration(c.NumberOfHoles.String).Minutes()),
+		})
+	}
+
+	earliest := 0
+	if now := courseNow(course.TimeZone); date.Equal(now.Truncate(24 * time.Hour)) {
+		earliest = now.Hour()*60 + now.Minute()
+	}
+	duration := int(store.EstimatedDuration(req.NumberOfHoles).Minutes())
+	proposals := availability.Propose(players, date.Weekday(), earliest, duration)
+	// A created game goes at the best time the host can make, which may not
+	// be among the proposals shown.
+	hostTime := -1
+	for _, p := range proposals {
+		if slices.Contains(p.Available, hostID) {
+			hostTime = p.TeeTime
+			break
+		}
+	}
+	if len(proposals) > maxProposals {
+		proposals = proposals[:maxProposals]
+	}
+
+	resp := model.GameProposalsResponse{Proposals: make([]model.GameProposalResponse, len(proposals))}
+	for i, p := range proposals {
+		resp.Proposals[i] = model.GameProposalResponse{
+			TeeTime:       formatMinuteOfDay(p.TeeTime),
+			LatestTeeTime: formatMinuteOfDay(p.Latest),
+			Available:     p.Available,
+			Unavailable:   p.Unavailable,
+			PreferCourse:  p.PreferCourse,
+		}
+	}
+	if !req.Create {
+		respondJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	if len(proposals) == 0 {
+		respondError(w, http.StatusConflict, "conflict", "Nobody in the group is free that day")
+		return
+	}
+	if hostTime < 0 {
+		respondError(w, http.StatusConflict, "conflict", "You aren't free at any time that day")
+		return
+	}
+	eventID, err := store.CreateEventWithInvites(r.Context(), h.db, h.queries, store.CreateEventWithInvitesParams{
+		CourseID:      int32(req.CourseID),
+		Date:          req.Date,
+		TeeTime:       formatMinuteOfDay(hostTime),
+		OpenSpots:     openSpots,
+		NumberOfHoles: req.NumberOfHoles,
+		Private:       req.Private,
+		HostID:        int32(hostID),
+		Invitees:      friends,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create event")
+		return
+	}
+	resp.Event, err = h.buildEventResponse(r, eventID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build event response")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
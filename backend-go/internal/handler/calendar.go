@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/ical"
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+// calendarFeedWindow is how far back a player's feed reaches. Older rounds
+// have already been synced by any subscribed client.
+const calendarFeedWindow = 90 * 24 * time.Hour
+
+func calendarEvent(row store.ListCalendarEventsRow) ical.Event {
+	location := []string{row.CourseName.String}
+	for _, part := range []string{row.Street.String, row.City.String, strings.TrimSpace(row.State.String + " " + row.ZipCode.String)} {
+		if part != "" {
+			location = append(location, part)
+		}
+	}
+
+	return ical.Event{
+		UID:         fmt.Sprintf("event-%d@findfore", row.ID),
+		Sequence:    row.Sequence,
+		Stamp:       row.UpdatedAt,
+		Start:       row.StartsAt.Time,
+		End:         row.StartsAt.Time.Add(store.EstimatedDuration(row.NumberOfHoles.String)),
+		Summary:     "Golf at " + row.CourseName.String,
+		Location:    strings.Join(location, ", "),
+		Description: fmt.Sprintf("%s holes hosted by %s", row.NumberOfHoles.String, row.HostName.String),
+		Cancelled:   row.CancelledAt.Valid,
+	}
+}
+
+func respondCalendar(w http.ResponseWriter, filename string, cal ical.Calendar) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(cal.Marshal())
+}
+
+// GetEventCalendar serves a single event as an .ics file.
+func (h *Handler) GetEventCalendar(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	rows, err := h.queries.ListCalendarEvents(r.Context(), store.ListCalendarEventsParams{
+		EventID: sql.NullInt64{Int64: id, Valid: true},
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch event")
+		return
+	}
+	if len(rows) == 0 {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return
+	}
+
+	respondCalendar(w, fmt.Sprintf("event-%d.ics", id), ical.Calendar{
+		Events: []ical.Event{calendarEvent(rows[0])},
+	})
+}
+
+// GetPlayerCalendar serves the subscribable feed of a player's accepted
+// rounds. Calendar clients can't send auth headers, so the feed is protected
+// by the player's secret calendar token in the query string instead.
+func (h *Handler) GetPlayerCalendar(w http.ResponseWriter, r *http.Request) {
+	playerIDStr := chi.URLParam(r, "player_id")
+	pid, err := strconv.ParseInt(playerIDStr, 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
+		return
+	}
+
+	// Unknown players and bad tokens look the same so feeds can't be probed.
+	token := r.URL.Query().Get("token")
+	stored, err := h.queries.GetPlayerCalendarToken(r.Context(), pid)
+	if err != nil || !stored.Valid || token == "" ||
+		subtle.ConstantTimeCompare([]byte(token), []byte(stored.String)) != 1 {
+		respondError(w, http.StatusNotFound, "not_found", "Calendar not found")
+		return
+	}
+
+	player, err := h.queries.GetPlayerByID(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch player")
+		return
+	}
+
+	rows, err := h.queries.ListCalendarEvents(r.Context(), store.ListCalendarEventsParams{
+		PlayerID:    sql.NullInt64{Int64: pid, Valid: true},
+		StartsAfter: sql.NullTime{Time: time.Now().Add(-calendarFeedWindow), Valid: true},
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch events")
+		return
+	}
+
+	cal := ical.Calendar{
+		Name:   fmt.Sprintf("FindFore: %s", player.Name.String),
+		Events: make([]ical.Event, len(rows)),
+	}
+	for i, row := range rows {
+		cal.Events[i] = calendarEvent(row)
+	}
+
+	respondCalendar(w, "findfore.ics", cal)
+}
+
+// CreateCalendarToken issues a new calendar feed token for the authenticated
+// player, invalidating any previous feed URL.
+func (h *Handler) CreateCalendarToken(w http.ResponseWriter, r *http.Request) {
+	playerIDStr := chi.URLParam(r, "player_id")
+	pid, err := strconv.ParseInt(playerIDStr, 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
+		return
+	}
+
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return
+	}
+	if authID != pid {
+		respondError(w, http.StatusForbidden, "forbidden", "You can only manage your own calendar")
+		return
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to generate token")
+		return
+	}
+	token := hex.EncodeToString(buf)
+
+	n, err := h.queries.SetPlayerCalendarToken(r.Context(), store.SetPlayerCalendarTokenParams{
+		ID:            pid,
+		CalendarToken: sql.NullString{String: token, Valid: true},
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to save token")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, model.CalendarTokenResponse{
+		Token: token,
+		URL:   fmt.Sprintf("/api/v1/players/%d/calendar.ics?token=%s", pid, token),
+	})
+}
@@ -2,10 +2,35 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
 
 	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
 )
 
+func courseResponse(c store.ListCoursesRow) model.CourseResponse {
+	resp := model.CourseResponse{
+		ID:          c.ID,
+		Name:        c.Name.String,
+		Street:      c.Street.String,
+		City:        c.City.String,
+		State:       c.State.String,
+		ZipCode:     c.ZipCode.String,
+		Phone:       c.Phone.String,
+		Cost:        c.Cost.String,
+		FeeCurrency: c.FeeCurrency,
+		Latitude:    optionalFloat64(c.Latitude),
+		Longitude:   optionalFloat64(c.Longitude),
+		TimeZone:    c.TimeZone,
+	}
+	if c.FeeCents.Valid {
+		resp.FeeCents = &c.FeeCents.Int32
+	}
+	return resp
+}
+
 func (h *Handler) ListCourses(w http.ResponseWriter, r *http.Request) {
 	courses, err := h.queries.ListCourses(r.Context())
 	if err != nil {
@@ -15,17 +40,68 @@ func (h *Handler) ListCourses(w http.ResponseWriter, r *http.Request) {
 
 	resp := make([]model.CourseResponse, len(courses))
 	for i, c := range courses {
-		resp[i] = model.CourseResponse{
-			ID:      c.ID,
-			Name:    c.Name.String,
-			Street:  c.Street.String,
-			City:    c.City.String,
-			State:   c.State.String,
-			ZipCode: c.ZipCode.String,
-			Phone:   c.Phone.String,
-			Cost:    c.Cost.String,
-		}
+		resp[i] = courseResponse(c)
 	}
 
 	respondJSON(w, http.StatusOK, resp)
 }
+
+// respondCourseDetail writes the course with its tee sets, longest first.
+func (h *Handler) respondCourseDetail(w http.ResponseWriter, r *http.Request, courseID int64, status int) {
+	course, err := h.queries.GetCourseByID(r.Context(), courseID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Course not found")
+		return
+	}
+	teeSets, err := h.queries.ListTeeSetsByCourseID(r.Context(), courseID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch tee sets")
+		return
+	}
+	holes, err := h.queries.ListTeeSetHolesByCourseID(r.Context(), courseID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch tee sets")
+		return
+	}
+
+	resp := model.CourseDetailResponse{
+		CourseResponse: courseResponse(store.ListCoursesRow(course)),
+		TeeSets:        make([]model.TeeSetResponse, len(teeSets)),
+	}
+	index := make(map[int64]int, len(teeSets))
+	for i, t := range teeSets {
+		resp.TeeSets[i] = model.TeeSetResponse{
+			ID:           t.ID,
+			Name:         t.Name,
+			Color:        t.Color.String,
+			CourseRating: t.CourseRating,
+			SlopeRating:  t.SlopeRating,
+			Yardage:      optionalInt32(t.Yardage),
+			Holes:        []model.TeeSetHoleResponse{},
+		}
+		index[t.ID] = i
+	}
+	for _, hole := range holes {
+		t := &resp.TeeSets[index[hole.TeeSetID]]
+		t.Holes = append(t.Holes, model.TeeSetHoleResponse{
+			Hole:        hole.HoleNumber,
+			Par:         hole.Par,
+			StrokeIndex: hole.StrokeIndex,
+			Yardage:     optionalInt32(hole.Yardage),
+		})
+		t.Par += hole.Par
+	}
+
+	respondJSON(w, status, resp)
+}
+
+// GetCourse returns a course with its tee sets and their holes.
+func (h *Handler) GetCourse(w http.ResponseWriter, r *http.Request) {
+	courseID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid course ID")
+		return
+	}
+
+	h.respondCourseDetail(w, r, courseID, http.StatusOK)
+}
@@ -2,9 +2,14 @@ package handler
 
 import (
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -12,169 +17,383 @@ import (
 	"github.com/ericrabun/findfore-go/internal/store"
 )
 
-// invite_status enum: 0=pending, 1=accepted, 2=declined, 3=closed
+// invite_status enum: 0=pending, 1=accepted, 2=declined, 3=closed, 4=expired
 const (
 	statusPending  int32 = 0
 	statusAccepted int32 = 1
 	statusDeclined int32 = 2
 	statusClosed   int32 = 3
+	statusExpired  int32 = 4
 )
 
+// events.status enum: 0=scheduled, 1=in_progress, 2=completed, 3=cancelled
+const (
+	eventScheduled  int32 = 0
+	eventInProgress int32 = 1
+	eventCompleted  int32 = 2
+	eventCancelled  int32 = 3
+)
+
+func eventStatusToString(status int32) string {
+	switch status {
+	case eventScheduled:
+		return "scheduled"
+	case eventInProgress:
+		return "in_progress"
+	case eventCompleted:
+		return "completed"
+	case eventCancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
 func (h *Handler) buildEventResponse(r *http.Request, eventID int64) (*model.EventResponse, error) {
-	event, err := h.queries.GetEventByID(r.Context(), eventID)
+	resps, err := h.buildEventResponses(r, []int64{eventID})
 	if err != nil {
 		return nil, err
 	}
+	if len(resps) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &resps[0], nil
+}
 
-	accepted, err := h.queries.ListPlayerIDsByEventAndStatus(r.Context(), store.ListPlayerIDsByEventAndStatusParams{
-		EventID:      sql.NullInt64{Int64: eventID, Valid: true},
-		InviteStatus: sql.NullInt32{Int32: statusAccepted, Valid: true},
-	})
+// buildEventResponses loads the events, their invite lists, guests and
+// co-hosts in four queries regardless of how many IDs are requested.
+// Responses are returned in the order of eventIDs; IDs that don't exist are
+// skipped.
+func (h *Handler) buildEventResponses(r *http.Request, eventIDs []int64) ([]model.EventResponse, error) {
+	if len(eventIDs) == 0 {
+		return []model.EventResponse{}, nil
+	}
+
+	events, err := h.queries.ListEventsByIDs(r.Context(), eventIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	declined, err := h.queries.ListPlayerIDsByEventAndStatus(r.Context(), store.ListPlayerIDsByEventAndStatusParams{
-		EventID:      sql.NullInt64{Int64: eventID, Valid: true},
-		InviteStatus: sql.NullInt32{Int32: statusDeclined, Valid: true},
-	})
+	invites, err := h.queries.ListInvitesByEventIDs(r.Context(), eventIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	pending, err := h.queries.ListPlayerIDsByEventAndStatus(r.Context(), store.ListPlayerIDsByEventAndStatusParams{
-		EventID:      sql.NullInt64{Int64: eventID, Valid: true},
-		InviteStatus: sql.NullInt32{Int32: statusPending, Valid: true},
-	})
+	guests, err := h.queries.ListGuestsByEventIDs(r.Context(), eventIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	closed, err := h.queries.ListPlayerIDsByEventAndStatus(r.Context(), store.ListPlayerIDsByEventAndStatusParams{
-		EventID:      sql.NullInt64{Int64: eventID, Valid: true},
-		InviteStatus: sql.NullInt32{Int32: statusClosed, Valid: true},
-	})
+	cohosts, err := h.queries.ListCohostsByEventIDs(r.Context(), eventIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	acceptedIDs := toInt64Slice(accepted)
-	declinedIDs := toInt64Slice(declined)
-	pendingIDs := toInt64Slice(pending)
-	closedIDs := toInt64Slice(closed)
-
-	remainingSpots := event.OpenSpots.Int32 - int32(len(acceptedIDs))
-
-	return &model.EventResponse{
-		ID:             event.ID,
-		CourseName:     event.CourseName.String,
-		Date:           event.Date.String,
-		TeeTime:        event.TeeTime.String,
-		OpenSpots:      event.OpenSpots.Int32,
-		NumberOfHoles:  event.NumberOfHoles.String,
-		Private:        event.Private.Bool,
-		HostName:       event.HostName.String,
-		HostID:         event.HostID.Int32,
-		Accepted:       acceptedIDs,
-		Declined:       declinedIDs,
-		Pending:        pendingIDs,
-		Closed:         closedIDs,
-		RemainingSpots: remainingSpots,
-	}, nil
-}
+	byID := make(map[int64]*model.EventResponse, len(events))
+	for _, event := range events {
+		byID[event.ID] = &model.EventResponse{
+			ID:            event.ID,
+			CourseName:    event.CourseName.String,
+			Date:          event.Date.String,
+			TeeTime:       event.TeeTime.String,
+			OpenSpots:     event.OpenSpots.Int32,
+			NumberOfHoles: event.NumberOfHoles.String,
+			Private:       event.Private.Bool,
+			HostName:      event.HostName.String,
+			HostID:        event.HostID.Int32,
+			Status:        eventStatusToString(event.Status),
+			Cancelled:     event.CancelledAt.Valid,
+			RespondBy:     optionalTime(event.RespondBy),
+			FeeCurrency:   event.FeeCurrency,
+			Accepted:      []int64{},
+			Declined:      []int64{},
+			Pending:       []int64{},
+			Closed:        []int64{},
+			Expired:       []int64{},
+			Guests:        []model.GuestResponse{},
+			Cohosts:       []int64{},
+		}
+		if event.FeeCents.Valid {
+			byID[event.ID].FeeCents = &event.FeeCents.Int32
+		}
+	}
 
-func toInt64Slice(nullIDs []sql.NullInt64) []int64 {
-	ids := make([]int64, 0, len(nullIDs))
-	for _, nid := range nullIDs {
-		if nid.Valid {
-			ids = append(ids, nid.Int64)
+	for _, inv := range invites {
+		resp, ok := byID[inv.EventID.Int64]
+		if !ok {
+			continue
+		}
+		switch inv.InviteStatus.Int32 {
+		case statusAccepted:
+			resp.Accepted = inv.PlayerIds
+		case statusDeclined:
+			resp.Declined = inv.PlayerIds
+		case statusPending:
+			resp.Pending = inv.PlayerIds
+		case statusClosed:
+			resp.Closed = inv.PlayerIds
+		case statusExpired:
+			resp.Expired = inv.PlayerIds
 		}
 	}
-	return ids
-}
 
-type eventRow interface {
-	getID() int64
-}
+	for _, g := range guests {
+		resp, ok := byID[g.EventID]
+		if !ok {
+			continue
+		}
+		resp.Guests = append(resp.Guests, model.GuestResponse{
+			ID:        g.ID,
+			Name:      g.Name,
+			SponsorID: g.SponsorID,
+		})
+	}
 
-type listAllEventsRowAdapter struct{ store.ListAllEventsRow }
+	for _, c := range cohosts {
+		if resp, ok := byID[c.EventID]; ok {
+			resp.Cohosts = append(resp.Cohosts, c.PlayerID)
+		}
+	}
 
-func (a listAllEventsRowAdapter) getID() int64 { return a.ID }
+	resps := make([]model.EventResponse, 0, len(eventIDs))
+	for _, id := range eventIDs {
+		resp, ok := byID[id]
+		if !ok {
+			continue
+		}
+		resp.RemainingSpots = resp.OpenSpots - int32(len(resp.Accepted)) - int32(len(resp.Guests))
+		resps = append(resps, *resp)
+	}
+	return resps, nil
+}
 
-func (h *Handler) ListEvents(w http.ResponseWriter, r *http.Request) {
-	// Check for player_id path param (nested route: /api/v1/players/:player_id/events)
-	playerIDStr := chi.URLParam(r, "player_id")
+const (
+	defaultEventsLimit = 100
+	maxEventsLimit     = 500
+)
 
-	// Check for query params
-	privateParam := r.URL.Query().Get("private")
-	playerIDQuery := r.URL.Query().Get("player_id")
+// encodeEventCursor packs the sort key of the last event on a page into an
+// opaque token for the next request.
+func encodeEventCursor(row store.SearchEventsRow) string {
+	raw := fmt.Sprintf("%d:%d", row.SortKey.UnixNano(), row.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
 
-	var eventIDs []int64
+func decodeEventCursor(cursor string) (time.Time, int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	nanosStr, idStr, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("malformed cursor")
+	}
+	nanos, err := strconv.ParseInt(nanosStr, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return time.Unix(0, nanos).UTC(), id, nil
+}
 
-	if playerIDStr != "" {
-		// Nested route: GET /api/v1/players/:player_id/events
-		pid, err := strconv.ParseInt(playerIDStr, 10, 64)
+// parseEventSearch turns the GET /events query string into search params.
+// The returned limit is the page size; params.PageLimit is one more so the
+// caller can tell whether another page exists.
+func parseEventSearch(r *http.Request) (store.SearchEventsParams, int32, error) {
+	query := r.URL.Query()
+	params := store.SearchEventsParams{
+		// Past and cancelled events are only listed in a player's history.
+		Statuses: []int32{eventScheduled, eventInProgress},
+	}
+
+	parseID := func(name, value string) (int64, error) {
+		id, err := strconv.ParseInt(value, 10, 64)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
-			return
+			return 0, fmt.Errorf("Invalid %s", name)
 		}
-		events, err := h.queries.ListEventsByPlayerID(r.Context(), sql.NullInt64{Int64: pid, Valid: true})
+		return id, nil
+	}
+
+	// Nested route (/players/:player_id/events) takes precedence over ?player_id=
+	playerID := chi.URLParam(r, "player_id")
+	if playerID == "" {
+		playerID = query.Get("player_id")
+	}
+	if playerID != "" {
+		pid, err := parseID("player_id", playerID)
 		if err != nil {
-			respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch events")
-			return
+			return params, 0, err
 		}
-		eventIDs = make([]int64, len(events))
-		for i, e := range events {
-			eventIDs[i] = e.ID
+		params.PlayerID = sql.NullInt64{Int64: pid, Valid: true}
+	}
+
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return params, 0, fmt.Errorf("Invalid from date")
 		}
-	} else if playerIDQuery != "" {
-		// Query param: GET /api/v1/events?player_id=X
-		pid, err := strconv.ParseInt(playerIDQuery, 10, 64)
+		params.StartsAfter = sql.NullTime{Time: from, Valid: true}
+	}
+	if v := query.Get("to"); v != "" {
+		to, err := time.Parse(dateLayout, v)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
-			return
+			return params, 0, fmt.Errorf("Invalid to date")
 		}
-		events, err := h.queries.ListEventsByPlayerID(r.Context(), sql.NullInt64{Int64: pid, Valid: true})
+		// to is inclusive of the whole day
+		params.StartsBefore = sql.NullTime{Time: to.AddDate(0, 0, 1), Valid: true}
+	}
+	if v := query.Get("course_id"); v != "" {
+		id, err := parseID("course_id", v)
 		if err != nil {
-			respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch events")
-			return
+			return params, 0, err
 		}
-		eventIDs = make([]int64, len(events))
-		for i, e := range events {
-			eventIDs[i] = e.ID
+		params.CourseID = sql.NullInt32{Int32: int32(id), Valid: true}
+	}
+	if v := query.Get("host_id"); v != "" {
+		id, err := parseID("host_id", v)
+		if err != nil {
+			return params, 0, err
 		}
-	} else if privateParam == "false" {
-		// Query param: GET /api/v1/events?private=false
-		events, err := h.queries.ListPublicEvents(r.Context())
+		params.HostID = sql.NullInt32{Int32: int32(id), Valid: true}
+	}
+	if v := query.Get("friends_of"); v != "" {
+		id, err := parseID("friends_of", v)
 		if err != nil {
-			respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch events")
-			return
+			return params, 0, err
 		}
-		eventIDs = make([]int64, len(events))
-		for i, e := range events {
-			eventIDs[i] = e.ID
+		params.FriendsOf = sql.NullInt32{Int32: int32(id), Valid: true}
+	}
+	// available_to: public events the player hasn't answered yet plus
+	// events they're still pending on
+	if v := query.Get("available_to"); v != "" {
+		id, err := parseID("available_to", v)
+		if err != nil {
+			return params, 0, err
 		}
-	} else {
-		// Default: all events
-		events, err := h.queries.ListAllEvents(r.Context())
+		params.AvailableTo = sql.NullInt64{Int64: id, Valid: true}
+	}
+	if v := query.Get("city"); v != "" {
+		params.City = sql.NullString{String: v, Valid: true}
+	}
+	if v := query.Get("state"); v != "" {
+		params.State = sql.NullString{String: v, Valid: true}
+	}
+	if v := query.Get("number_of_holes"); v != "" {
+		params.NumberOfHoles = sql.NullString{String: v, Valid: true}
+	}
+	if v := query.Get("private"); v != "" {
+		private, err := strconv.ParseBool(v)
 		if err != nil {
-			respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch events")
-			return
+			return params, 0, fmt.Errorf("Invalid private")
 		}
-		eventIDs = make([]int64, len(events))
-		for i, e := range events {
-			eventIDs[i] = e.ID
+		params.Private = sql.NullBool{Bool: private, Valid: true}
+	}
+	if v := query.Get("has_open_spots"); v != "" {
+		open, err := strconv.ParseBool(v)
+		if err != nil {
+			return params, 0, fmt.Errorf("Invalid has_open_spots")
 		}
+		params.HasOpenSpots = open
 	}
 
-	resp := make([]model.EventResponse, 0, len(eventIDs))
-	for _, eid := range eventIDs {
-		er, err := h.buildEventResponse(r, eid)
+	switch query.Get("sort") {
+	case "", "starts_at":
+	case "-starts_at":
+		params.SortDesc = true
+	default:
+		return params, 0, fmt.Errorf("Invalid sort")
+	}
+
+	if v := query.Get("cursor"); v != "" {
+		startsAt, id, err := decodeEventCursor(v)
 		if err != nil {
-			respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build event response")
-			return
+			return params, 0, fmt.Errorf("Invalid cursor")
+		}
+		params.CursorStartsAt = sql.NullTime{Time: startsAt, Valid: true}
+		params.CursorID = sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	limit := int32(defaultEventsLimit)
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n < 1 {
+			return params, 0, fmt.Errorf("Invalid limit")
 		}
-		resp = append(resp, *er)
+		limit = int32(min(n, maxEventsLimit))
+	}
+	params.PageLimit = sql.NullInt32{Int32: limit + 1, Valid: true}
+
+	return params, limit, nil
+}
+
+// ListEvents serves both GET /events and GET /players/:player_id/events.
+// Results are sorted by start time and paginated with an opaque cursor; when
+// more results exist the next cursor is returned in the X-Next-Cursor header.
+// A player's events were listed in full before pagination was added, so that
+// route is only paginated when a limit is given.
+func (h *Handler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	params, limit, err := parseEventSearch(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+	if chi.URLParam(r, "player_id") != "" && r.URL.Query().Get("limit") == "" {
+		params.PageLimit = sql.NullInt32{}
+		limit = 0
+	}
+
+	h.respondEventPage(w, r, params, limit)
+}
+
+// ListPlayerHistory serves GET /players/:player_id/history: the completed
+// rounds the player accepted, most recent first. It takes the same filters
+// and pagination as ListEvents.
+func (h *Handler) ListPlayerHistory(w http.ResponseWriter, r *http.Request) {
+	params, limit, err := parseEventSearch(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	// The nested player_id matches any invitation; history only includes
+	// rounds the player actually played.
+	params.AcceptedBy = params.PlayerID
+	params.PlayerID = sql.NullInt64{}
+	params.Statuses = []int32{eventCompleted}
+	if r.URL.Query().Get("sort") == "" {
+		params.SortDesc = true
+	}
+
+	h.respondEventPage(w, r, params, limit)
+}
+
+// respondEventPage runs the search and writes one page of event responses,
+// setting X-Next-Cursor when more results exist. A limit of 0 writes every
+// result.
+func (h *Handler) respondEventPage(w http.ResponseWriter, r *http.Request, params store.SearchEventsParams, limit int32) {
+	rows, err := h.queries.SearchEvents(r.Context(), params)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch events")
+		return
+	}
+
+	if limit > 0 && len(rows) > int(limit) {
+		rows = rows[:limit]
+		w.Header().Set("X-Next-Cursor", encodeEventCursor(rows[len(rows)-1]))
+	}
+
+	eventIDs := make([]int64, len(rows))
+	for i, row := range rows {
+		eventIDs[i] = row.ID
+	}
+
+	resp, err := h.buildEventResponses(r, eventIDs)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build event response")
+		return
 	}
 
 	respondJSON(w, http.StatusOK, resp)
@@ -206,6 +425,9 @@ type createEventRequest struct {
 	Private       bool        `json:"private"`
 	HostID        int64       `json:"host_id"`
 	Invitees      []int64     `json:"invitees"`
+	RespondBy     string      `json:"respond_by"`
+	FeeCents      *int32      `json:"fee_cents"`
+	FeeCurrency   string      `json:"fee_currency"`
 }
 
 func (h *Handler) CreateEvent(w http.ResponseWriter, r *http.Request) {
@@ -239,6 +461,10 @@ func (h *Handler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "validation_error", "Tee time can't be blank")
 		return
 	}
+	if _, err := store.EventStartsAt(req.Date, req.TeeTime); err != nil {
+		respondError(w, http.StatusBadRequest, "validation_error", "Date or tee time is invalid")
+		return
+	}
 	if req.NumberOfHoles == "" {
 		respondError(w, http.StatusBadRequest, "validation_error", "Number of holes can't be blank")
 		return
@@ -248,6 +474,33 @@ func (h *Handler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var respondBy sql.NullTime
+	if req.RespondBy != "" {
+		t, err := time.Parse(time.RFC3339, req.RespondBy)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "validation_error", "Respond by is invalid")
+			return
+		}
+		if !t.After(time.Now()) {
+			respondError(w, http.StatusBadRequest, "validation_error", "Respond by must be in the future")
+			return
+		}
+		respondBy = sql.NullTime{Time: t.UTC(), Valid: true}
+	}
+
+	var feeCents sql.NullInt32
+	if req.FeeCents != nil {
+		if *req.FeeCents < 0 {
+			respondError(w, http.StatusBadRequest, "validation_error", "Fee can't be negative")
+			return
+		}
+		feeCents = sql.NullInt32{Int32: *req.FeeCents, Valid: true}
+	}
+	if req.FeeCurrency != "" && !validCurrency(req.FeeCurrency) {
+		respondError(w, http.StatusBadRequest, "validation_error", "Fee currency must be a three letter code like USD")
+		return
+	}
+
 	eventID, err := store.CreateEventWithInvites(r.Context(), h.db, h.queries, store.CreateEventWithInvitesParams{
 		CourseID:      int32(courseID),
 		Date:          req.Date,
@@ -257,6 +510,9 @@ func (h *Handler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		Private:       req.Private,
 		HostID:        int32(req.HostID),
 		Invitees:      req.Invitees,
+		RespondBy:     respondBy,
+		FeeCents:      feeCents,
+		FeeCurrency:   req.FeeCurrency,
 	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create event")
@@ -289,19 +545,18 @@ func (h *Handler) ListFriendsEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := make([]model.EventResponse, 0, len(eventIDs))
-	for _, eid := range eventIDs {
-		er, err := h.buildEventResponse(r, eid)
-		if err != nil {
-			respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build event response")
-			return
-		}
-		resp = append(resp, *er)
+	resp, err := h.buildEventResponses(r, eventIDs)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build event response")
+		return
 	}
 
 	respondJSON(w, http.StatusOK, resp)
 }
 
+// DeleteEvent removes the event and everyone's RSVPs and scores, and
+// recalculates the handicaps the round counted toward. The host or a co-host
+// can delete.
 func (h *Handler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -310,10 +565,55 @@ func (h *Handler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.queries.DeleteEvent(r.Context(), id); err != nil {
+	if !h.requireEventManager(w, r, id) {
+		return
+	}
+
+	err = store.DeleteEvent(r.Context(), h.db, h.queries, id)
+	if errors.Is(err, sql.ErrNoRows) {
 		respondError(w, http.StatusNotFound, "not_found", "Event not found")
 		return
 	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to delete event")
+		return
+	}
 
 	respondJSON(w, http.StatusOK, nil)
 }
+
+// CancelEvent marks the event as cancelled instead of deleting it, so
+// subscribed calendars receive STATUS:CANCELLED. Cancelled events drop out of
+// event listings and can no longer be joined. The host or a co-host can cancel.
+func (h *Handler) CancelEvent(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	if !h.requireEventManager(w, r, id) {
+		return
+	}
+
+	n, err := store.CancelEvent(r.Context(), h.db, h.queries, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to cancel event")
+		return
+	}
+
+	resp, err := h.buildEventResponse(r, id)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return
+	}
+	// Cancelling an already cancelled event is a no-op, but completed
+	// rounds stay in players' history.
+	if n == 0 && resp.Status == eventStatusToString(eventCompleted) {
+		respondError(w, http.StatusConflict, "conflict", "Event has already been played")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,399 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/ledger"
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+var currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// validCurrency checks for an ISO 4217 style code such as USD.
+func validCurrency(currency string) bool {
+	return currencyRegex.MatchString(currency)
+}
+
+type createExpenseRequest struct {
+	PayerID     int64  `json:"payer_id"`
+	AmountCents int32  `json:"amount_cents"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+}
+
+type createSettlementRequest struct {
+	PayeeID     int64  `json:"payee_id"`
+	AmountCents int32  `json:"amount_cents"`
+	Currency    string `json:"currency"`
+	Note        string `json:"note"`
+}
+
+func expenseResponse(e store.EventExpense) model.ExpenseResponse {
+	return model.ExpenseResponse{
+		ID:          e.ID,
+		EventID:     e.EventID,
+		PayerID:     e.PayerID,
+		AmountCents: e.AmountCents,
+		Currency:    e.Currency,
+		Description: e.Description,
+		CreatedAt:   e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func settlementResponse(s store.Settlement) model.SettlementResponse {
+	return model.SettlementResponse{
+		ID:          s.ID,
+		PayerID:     s.PayerID,
+		PayeeID:     s.PayeeID,
+		AmountCents: s.AmountCents,
+		Currency:    s.Currency,
+		Note:        s.Note.String,
+		CreatedAt:   s.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// eventLedger is the expenses and shares of one event, ready to split.
+type eventLedger struct {
+	expenses []store.EventExpense
+	shares   []ledger.Share
+}
+
+func (l eventLedger) split() []ledger.Expense {
+	out := make([]ledger.Expense, len(l.expenses))
+	for i, e := range l.expenses {
+		out[i] = ledger.Expense{PayerID: e.PayerID, AmountCents: int64(e.AmountCents)}
+	}
+	return out
+}
+
+// loadLedgers loads the expenses and shares for the events in two queries.
+func (h *Handler) loadLedgers(r *http.Request, eventIDs []int64) (map[int64]*eventLedger, error) {
+	ledgers := make(map[int64]*eventLedger, len(eventIDs))
+	for _, id := range eventIDs {
+		ledgers[id] = &eventLedger{}
+	}
+
+	expenses, err := h.queries.ListExpensesByEventIDs(r.Context(), eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range expenses {
+		ledgers[e.EventID].expenses = append(ledgers[e.EventID].expenses, e)
+	}
+
+	shares, err := h.queries.ListExpenseSharesByEventIDs(r.Context(), eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range shares {
+		l := ledgers[s.EventID.Int64]
+		l.shares = append(l.shares, ledger.Share{PlayerID: s.PlayerID.Int64, Weight: s.Weight})
+	}
+	return ledgers, nil
+}
+
+func respondExpenseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrPlayerNotAccepted):
+		respondError(w, http.StatusConflict, "conflict", "Payer must have accepted the event")
+	case errors.Is(err, store.ErrCurrencyMismatch):
+		respondError(w, http.StatusBadRequest, "validation_error", "Currency must match the event's fee currency")
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+	default:
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to record expense")
+	}
+}
+
+// GetEventExpenses returns the event's expense ledger with each player's
+// balance and who owes whom. Costs are split between the accepted players,
+// and players pay for the guests they bring.
+func (h *Handler) GetEventExpenses(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	if !h.requireEventParticipant(w, r, eventID, 0) {
+		return
+	}
+
+	fee, err := h.queries.GetEventFee(r.Context(), eventID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return
+	}
+	ledgers, err := h.loadLedgers(r, []int64{eventID})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch expenses")
+		return
+	}
+	l := ledgers[eventID]
+
+	resp := model.EventLedgerResponse{
+		EventID:  eventID,
+		Currency: fee.FeeCurrency,
+		Expenses: make([]model.ExpenseResponse, len(l.expenses)),
+		Balances: []model.BalanceResponse{},
+		Debts:    []model.DebtResponse{},
+	}
+	for i, e := range l.expenses {
+		resp.Expenses[i] = expenseResponse(e)
+		resp.TotalCents += int64(e.AmountCents)
+	}
+	for _, b := range ledger.Balances(l.split(), l.shares) {
+		resp.Balances = append(resp.Balances, model.BalanceResponse{
+			PlayerID:     b.PlayerID,
+			PaidCents:    b.PaidCents,
+			ShareCents:   b.ShareCents,
+			BalanceCents: b.BalanceCents,
+		})
+	}
+	for _, d := range ledger.Debts(l.split(), l.shares) {
+		resp.Debts = append(resp.Debts, model.DebtResponse{
+			FromPlayerID: d.From,
+			ToPlayerID:   d.To,
+			AmountCents:  d.AmountCents,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// CreateEventExpense lets the host or a co-host record a payment a player
+// made for the group, such as the tee times or carts.
+func (h *Handler) CreateEventExpense(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	var req createExpenseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	if req.PayerID <= 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Payer can't be blank")
+		return
+	}
+	if req.AmountCents <= 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Amount must be greater than 0")
+		return
+	}
+	if req.Description == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Description can't be blank")
+		return
+	}
+	if req.Currency != "" && !validCurrency(req.Currency) {
+		respondError(w, http.StatusBadRequest, "validation_error", "Currency must be a three letter code like USD")
+		return
+	}
+
+	if !h.requireEventManager(w, r, eventID) {
+		return
+	}
+
+	expense, err := store.RecordEventExpense(r.Context(), h.queries, store.CreateEventExpenseParams{
+		EventID:     eventID,
+		PayerID:     req.PayerID,
+		AmountCents: req.AmountCents,
+		Currency:    req.Currency,
+		Description: req.Description,
+	})
+	if err != nil {
+		respondExpenseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, expenseResponse(expense))
+}
+
+// DeleteEventExpense removes a mistaken entry from the ledger.
+func (h *Handler) DeleteEventExpense(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+	expenseID, err := strconv.ParseInt(chi.URLParam(r, "expense_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid expense ID")
+		return
+	}
+
+	if !h.requireEventManager(w, r, eventID) {
+		return
+	}
+
+	n, err := h.queries.DeleteEventExpense(r.Context(), store.DeleteEventExpenseParams{
+		ID:      expenseID,
+		EventID: eventID,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to delete expense")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "not_found", "Expense not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
+
+// GetPlayerBalances sums up who owes the player and whom they owe across
+// every event with expenses, plus settled game winnings, net of settle-ups.
+// Amounts in different currencies are kept apart.
+func (h *Handler) GetPlayerBalances(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	eventIDs, err := h.queries.ListExpenseEventIDsByPlayerID(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch expenses")
+		return
+	}
+	ledgers, err := h.loadLedgers(r, eventIDs)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch expenses")
+		return
+	}
+	settlements, err := h.queries.ListSettlementsByPlayerID(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch settlements")
+		return
+	}
+	payouts, err := h.queries.ListGamePayoutsByPlayerID(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch game payouts")
+		return
+	}
+
+	// net is keyed by currency, then the other player. Positive amounts are
+	// owed to the player.
+	net := map[string]map[int64]int64{}
+	add := func(currency string, other, amount int64) {
+		if net[currency] == nil {
+			net[currency] = map[int64]int64{}
+		}
+		net[currency][other] += amount
+	}
+
+	for _, l := range ledgers {
+		if len(l.expenses) == 0 {
+			continue
+		}
+		// Expenses share the event's currency.
+		currency := l.expenses[0].Currency
+		for _, d := range ledger.Debts(l.split(), l.shares) {
+			switch pid {
+			case d.From:
+				add(currency, d.To, -d.AmountCents)
+			case d.To:
+				add(currency, d.From, d.AmountCents)
+			}
+		}
+	}
+	for _, p := range payouts {
+		if p.PayerID == pid {
+			add(p.Currency, p.PayeeID, -int64(p.AmountCents))
+		} else {
+			add(p.Currency, p.PayerID, int64(p.AmountCents))
+		}
+	}
+	for _, s := range settlements {
+		if s.PayerID == pid {
+			add(s.Currency, s.PayeeID, int64(s.AmountCents))
+		} else {
+			add(s.Currency, s.PayerID, -int64(s.AmountCents))
+		}
+	}
+
+	resp := model.PlayerLedgerResponse{
+		Balances:    []model.PlayerBalanceResponse{},
+		Settlements: make([]model.SettlementResponse, len(settlements)),
+	}
+	for currency, others := range net {
+		for other, amount := range others {
+			if amount == 0 {
+				continue
+			}
+			b := model.PlayerBalanceResponse{PlayerID: other, Currency: currency, AmountCents: amount, Direction: "owes_you"}
+			if amount < 0 {
+				b.AmountCents, b.Direction = -amount, "you_owe"
+			}
+			resp.Balances = append(resp.Balances, b)
+		}
+	}
+	sort.Slice(resp.Balances, func(i, j int) bool {
+		if resp.Balances[i].Currency != resp.Balances[j].Currency {
+			return resp.Balances[i].Currency < resp.Balances[j].Currency
+		}
+		return resp.Balances[i].PlayerID < resp.Balances[j].PlayerID
+	})
+	for i, s := range settlements {
+		resp.Settlements[i] = settlementResponse(s)
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// CreateSettlement records the player paying another player back.
+func (h *Handler) CreateSettlement(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	var req createSettlementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	if req.PayeeID <= 0 || req.PayeeID == pid {
+		respondError(w, http.StatusBadRequest, "validation_error", "Payee must be another player")
+		return
+	}
+	if req.AmountCents <= 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Amount must be greater than 0")
+		return
+	}
+	if !validCurrency(req.Currency) {
+		respondError(w, http.StatusBadRequest, "validation_error", "Currency must be a three letter code like USD")
+		return
+	}
+
+	if _, err := h.queries.GetPlayerByID(r.Context(), req.PayeeID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	settlement, err := h.queries.CreateSettlement(r.Context(), store.CreateSettlementParams{
+		PayerID:     pid,
+		PayeeID:     req.PayeeID,
+		AmountCents: req.AmountCents,
+		Currency:    req.Currency,
+		Note:        sql.NullString{String: req.Note, Valid: req.Note != ""},
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to record settlement")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, settlementResponse(settlement))
+}
@@ -84,22 +84,24 @@ func (h *Handler) CreateFriendship(w http.ResponseWriter, r *http.Request) {
 		FollowerID: friendshipFollowerID,
 		FolloweeID: followeeID,
 		Follower: model.PlayerResponse{
-			ID:       followerDetails.ID,
-			Name:     followerDetails.Name,
-			Phone:    followerDetails.Phone,
-			Email:    followerDetails.Email,
-			Username: followerDetails.Username,
-			Friends:  followerDetails.Friends,
-			Events:   followerDetails.Events,
+			ID:            followerDetails.ID,
+			Name:          followerDetails.Name,
+			Phone:         followerDetails.Phone,
+			Email:         followerDetails.Email,
+			Username:      followerDetails.Username,
+			Friends:       followerDetails.Friends,
+			Events:        followerDetails.Events,
+			HandicapIndex: followerDetails.HandicapIndex,
 		},
 		Followee: model.PlayerResponse{
-			ID:       followeeDetails.ID,
-			Name:     followeeDetails.Name,
-			Phone:    followeeDetails.Phone,
-			Email:    followeeDetails.Email,
-			Username: followeeDetails.Username,
-			Friends:  followeeDetails.Friends,
-			Events:   followeeDetails.Events,
+			ID:            followeeDetails.ID,
+			Name:          followeeDetails.Name,
+			Phone:         followeeDetails.Phone,
+			Email:         followeeDetails.Email,
+			Username:      followeeDetails.Username,
+			Friends:       followeeDetails.Friends,
+			Events:        followeeDetails.Events,
+			HandicapIndex: followeeDetails.HandicapIndex,
 		},
 	}
 
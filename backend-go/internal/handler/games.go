@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/games"
+	"github.com/ericrabun/findfore-go/internal/ledger"
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+type createGameRequest struct {
+	Format     string  `json:"format"`
+	Net        bool    `json:"net"`
+	StakeCents int32   `json:"stake_cents"`
+	Carryover  *bool   `json:"carryover"`
+	PlayerIDs  []int64 `json:"player_ids"`
+}
+
+func gameFormatToString(format int32) string {
+	switch games.Format(format) {
+	case games.Skins:
+		return "skins"
+	case games.Nassau:
+		return "nassau"
+	case games.Stableford:
+		return "stableford"
+	case games.MatchPlay:
+		return "match_play"
+	default:
+		return "unknown"
+	}
+}
+
+func gameFormatToInt(format string) int32 {
+	switch format {
+	case "skins":
+		return int32(games.Skins)
+	case "nassau":
+		return int32(games.Nassau)
+	case "stableford":
+		return int32(games.Stableford)
+	case "match_play":
+		return int32(games.MatchPlay)
+	default:
+		return -1
+	}
+}
+
+func debtResponses(debts []ledger.Debt) []model.DebtResponse {
+	out := make([]model.DebtResponse, len(debts))
+	for i, d := range debts {
+		out[i] = model.DebtResponse{FromPlayerID: d.From, ToPlayerID: d.To, AmountCents: d.AmountCents}
+	}
+	return out
+}
+
+func respondGameError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrGamePlayerNotAccepted):
+		respondError(w, http.StatusConflict, "conflict", "Game players must have accepted the event")
+	case errors.Is(err, store.ErrTooFewGamePlayers):
+		respondError(w, http.StatusConflict, "conflict", "Games need at least two players")
+	case errors.Is(err, store.ErrGameSettled):
+		respondError(w, http.StatusConflict, "conflict", "Game has already been settled")
+	case errors.Is(err, store.ErrRoundNotCompleted):
+		respondError(w, http.StatusConflict, "conflict", "Games can be settled once the round is completed")
+	case errors.Is(err, store.ErrCardsIncomplete):
+		respondError(w, http.StatusConflict, "conflict", "Every player in the game needs a complete scorecard")
+	case errors.Is(err, store.ErrEventCancelled):
+		respondError(w, http.StatusConflict, "conflict", "Event is cancelled")
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "not_found", "Game not found")
+	default:
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to save game")
+	}
+}
+
+// respondEventGames writes the event's games with their results so far and
+// the settlement for the round: each player's share of the expenses plus
+// their game winnings, netted into who owes whom.
+func (h *Handler) respondEventGames(w http.ResponseWriter, r *http.Request, eventID int64, status int) {
+	fee, err := h.queries.GetEventFee(r.Context(), eventID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return
+	}
+	played, err := store.PlayEventGames(r.Context(), h.queries, eventID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch games")
+		return
+	}
+	ledgers, err := h.loadLedgers(r, []int64{eventID})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch expenses")
+		return
+	}
+	l := ledgers[eventID]
+
+	balances := map[int64]*model.RoundBalanceResponse{}
+	balance := func(playerID int64) *model.RoundBalanceResponse {
+		if balances[playerID] == nil {
+			balances[playerID] = &model.RoundBalanceResponse{PlayerID: playerID}
+		}
+		return balances[playerID]
+	}
+	debts := ledger.Debts(l.split(), l.shares)
+	for _, b := range ledger.Balances(l.split(), l.shares) {
+		balance(b.PlayerID).ExpensesCents = b.BalanceCents
+	}
+
+	resp := model.EventGamesResponse{
+		EventID:  eventID,
+		Currency: fee.FeeCurrency,
+		Games:    make([]model.GameResponse, len(played)),
+		Settlement: model.RoundSettlementResponse{
+			Balances: []model.RoundBalanceResponse{},
+		},
+	}
+	for i, g := range played {
+		resp.Games[i] = model.GameResponse{
+			ID:         g.ID,
+			Format:     gameFormatToString(g.Format),
+			Net:        g.Net,
+			StakeCents: g.StakeCents,
+			Carryover:  g.Carryover,
+			PlayerIDs:  g.PlayerIDs,
+			SettledAt:  optionalTime(g.SettledAt),
+			Results:    make([]model.GameResultResponse, len(g.Results)),
+			Debts:      debtResponses(g.Debts),
+		}
+		if resp.Games[i].PlayerIDs == nil {
+			resp.Games[i].PlayerIDs = []int64{}
+		}
+		for j, res := range g.Results {
+			resp.Games[i].Results[j] = model.GameResultResponse{
+				PlayerID:      res.PlayerID,
+				Points:        res.Points,
+				WinningsCents: res.WinningsCents,
+			}
+			balance(res.PlayerID).GamesCents += res.WinningsCents
+		}
+		debts = append(debts, g.Debts...)
+	}
+
+	for _, b := range balances {
+		b.TotalCents = b.ExpensesCents + b.GamesCents
+		resp.Settlement.Balances = append(resp.Settlement.Balances, *b)
+	}
+	sort.Slice(resp.Settlement.Balances, func(i, j int) bool {
+		return resp.Settlement.Balances[i].PlayerID < resp.Settlement.Balances[j].PlayerID
+	})
+	resp.Settlement.Debts = debtResponses(ledger.Net(debts))
+
+	respondJSON(w, status, resp)
+}
+
+// ListEventGames returns the event's games and the round's settlement.
+func (h *Handler) ListEventGames(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	if !h.requireEventParticipant(w, r, eventID, 0) {
+		return
+	}
+
+	h.respondEventGames(w, r, eventID, http.StatusOK)
+}
+
+// CreateEventGame lets the host or a co-host add a money game to the round.
+// Match play and Nassau are between two players; skins and stableford are
+// open to everyone who has accepted unless players are named.
+func (h *Handler) CreateEventGame(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	var req createGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	format := gameFormatToInt(req.Format)
+	if format < 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Format must be skins, nassau, stableford or match_play")
+		return
+	}
+	if req.StakeCents <= 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Stake must be greater than 0")
+		return
+	}
+	if games.Format(format).HeadToHead() && len(req.PlayerIDs) != 2 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Match play and Nassau need exactly two players")
+		return
+	}
+	seen := map[int64]bool{}
+	for _, id := range req.PlayerIDs {
+		if seen[id] {
+			respondError(w, http.StatusBadRequest, "validation_error", "Players can only be in a game once")
+			return
+		}
+		seen[id] = true
+	}
+	carryover := true
+	if req.Carryover != nil {
+		carryover = *req.Carryover
+	}
+
+	if !h.requireEventManager(w, r, eventID) {
+		return
+	}
+
+	if _, err := store.CreateEventGame(r.Context(), h.db, h.queries, store.CreateEventGameParams{
+		EventID:    eventID,
+		Format:     format,
+		Net:        req.Net,
+		StakeCents: req.StakeCents,
+		Carryover:  carryover,
+	}, req.PlayerIDs); err != nil {
+		respondGameError(w, err)
+		return
+	}
+
+	h.respondEventGames(w, r, eventID, http.StatusCreated)
+}
+
+// DeleteEventGame removes a game that hasn't been settled.
+func (h *Handler) DeleteEventGame(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+	gameID, err := strconv.ParseInt(chi.URLParam(r, "game_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid game ID")
+		return
+	}
+
+	if !h.requireEventManager(w, r, eventID) {
+		return
+	}
+
+	if err := store.DeleteEventGame(r.Context(), h.db, h.queries, eventID, gameID); err != nil {
+		respondGameError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
+
+// SettleEventGames records what the completed round's games owe so the
+// winnings show up in each player's balances.
+func (h *Handler) SettleEventGames(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	if !h.requireEventManager(w, r, eventID) {
+		return
+	}
+
+	if err := store.SettleEventGames(r.Context(), h.db, h.queries, eventID); err != nil {
+		respondGameError(w, err)
+		return
+	}
+
+	h.respondEventGames(w, r, eventID, http.StatusOK)
+}
@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/pairing"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+// maxGroupSize is the most spots, guests included, one tee time can hold.
+const maxGroupSize = 4
+
+// pairing_preferences.preference enum: 0=play_with, 1=avoid
+const (
+	preferencePlayWith int32 = 0
+	preferenceAvoid    int32 = 1
+)
+
+type generateGroupsRequest struct {
+	TeeTimes  []string `json:"tee_times"`
+	GroupSize int      `json:"group_size"`
+}
+
+type groupRequest struct {
+	TeeTime   string  `json:"tee_time"`
+	PlayerIDs []int64 `json:"player_ids"`
+}
+
+type replaceGroupsRequest struct {
+	Groups []groupRequest `json:"groups"`
+}
+
+func validTeeTime(teeTime string) bool {
+	_, err := time.Parse("15:04", teeTime)
+	return err == nil
+}
+
+func respondGroupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrGroupMemberNotAccepted):
+		respondError(w, http.StatusConflict, "conflict", "Grouped players must have accepted the event")
+	case errors.Is(err, pairing.ErrTooManyPlayers):
+		respondError(w, http.StatusConflict, "conflict", "Not enough tee times for the accepted players")
+	case errors.Is(err, store.ErrEventCancelled):
+		respondError(w, http.StatusConflict, "conflict", "Event is cancelled")
+	case errors.Is(err, store.ErrEventCompleted):
+		respondError(w, http.StatusConflict, "conflict", "Event has already been played")
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+	default:
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to save groups")
+	}
+}
+
+// guestCounts maps each accepted player to how many guests they're bringing.
+func guestCounts(candidates []store.ListPairingCandidatesRow) map[int64]int {
+	counts := make(map[int64]int, len(candidates))
+	for _, c := range candidates {
+		counts[c.PlayerID.Int64] = int(c.GuestCount)
+	}
+	return counts
+}
+
+// respondEventGroups writes the event's groups along with the accepted
+// players who haven't been placed in one yet, such as anyone who joined after
+// pairings were made.
+func (h *Handler) respondEventGroups(w http.ResponseWriter, r *http.Request, eventID int64, status int) {
+	groups, err := h.queries.ListEventGroups(r.Context(), eventID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch groups")
+		return
+	}
+	members, err := h.queries.ListEventGroupMembers(r.Context(), eventID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch groups")
+		return
+	}
+	candidates, err := h.queries.ListPairingCandidates(r.Context(), sql.NullInt64{Int64: eventID, Valid: true})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch players")
+		return
+	}
+	guests, err := h.queries.ListGuestsByEventIDs(r.Context(), []int64{eventID})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch guests")
+		return
+	}
+
+	resp := model.EventGroupsResponse{
+		Groups:    make([]model.GroupResponse, len(groups)),
+		Ungrouped: []int64{},
+	}
+	index := make(map[int64]int, len(groups))
+	for i, g := range groups {
+		resp.Groups[i] = model.GroupResponse{
+			ID:        g.ID,
+			TeeTime:   g.TeeTime,
+			PlayerIDs: []int64{},
+			GuestIDs:  []int64{},
+		}
+		index[g.ID] = i
+	}
+
+	groupOf := make(map[int64]int, len(members))
+	for _, m := range members {
+		i := index[m.GroupID]
+		resp.Groups[i].PlayerIDs = append(resp.Groups[i].PlayerIDs, m.PlayerID)
+		resp.Groups[i].Spots++
+		groupOf[m.PlayerID] = i
+	}
+	// Guests play in their sponsor's group.
+	for _, g := range guests {
+		if i, ok := groupOf[g.SponsorID]; ok {
+			resp.Groups[i].GuestIDs = append(resp.Groups[i].GuestIDs, g.ID)
+			resp.Groups[i].Spots++
+		}
+	}
+	for _, c := range candidates {
+		if _, ok := groupOf[c.PlayerID.Int64]; !ok {
+			resp.Ungrouped = append(resp.Ungrouped, c.PlayerID.Int64)
+		}
+	}
+
+	respondJSON(w, status, resp)
+}
+
+// ListEventGroups returns the event's groups by tee time.
+func (h *Handler) ListEventGroups(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	if _, err := h.queries.GetEventByID(r.Context(), eventID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return
+	}
+
+	h.respondEventGroups(w, r, eventID, http.StatusOK)
+}
+
+// GenerateEventGroups splits the accepted players across the given tee times,
+// balancing average handicaps and honoring play-with and avoid preferences
+// and friendships. Any existing groups are replaced. Tee times that aren't
+// needed are left unused.
+func (h *Handler) GenerateEventGroups(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	var req generateGroupsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	if len(req.TeeTimes) == 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Tee times can't be blank")
+		return
+	}
+	for _, t := range req.TeeTimes {
+		if !validTeeTime(t) {
+			respondError(w, http.StatusBadRequest, "validation_error", fmt.Sprintf("Tee time %q is invalid", t))
+			return
+		}
+	}
+	if req.GroupSize == 0 {
+		req.GroupSize = maxGroupSize
+	}
+	if req.GroupSize < 2 || req.GroupSize > maxGroupSize {
+		respondError(w, http.StatusBadRequest, "validation_error", fmt.Sprintf("Group size must be between 2 and %d", maxGroupSize))
+		return
+	}
+
+	if !h.requireEventManager(w, r, eventID) {
+		return
+	}
+
+	candidates, err := h.queries.ListPairingCandidates(r.Context(), sql.NullInt64{Int64: eventID, Valid: true})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch players")
+		return
+	}
+
+	players := make([]pairing.Player, len(candidates))
+	ids := make([]int64, len(candidates))
+	ids32 := make([]int32, len(candidates))
+	for i, c := range candidates {
+		players[i] = pairing.Player{ID: c.PlayerID.Int64, Guests: int(c.GuestCount)}
+		if c.HandicapIndex.Valid {
+			handicap := c.HandicapIndex.Float64
+			players[i].Handicap = &handicap
+		}
+		ids[i] = c.PlayerID.Int64
+		ids32[i] = int32(c.PlayerID.Int64)
+	}
+
+	var prefs pairing.Preferences
+	preferences, err := h.queries.ListPairingPreferencesAmong(r.Context(), ids)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch preferences")
+		return
+	}
+	for _, p := range preferences {
+		pair := pairing.Pair{p.PlayerID, p.OtherPlayerID}
+		if p.Preference == preferenceAvoid {
+			prefs.Avoid = append(prefs.Avoid, pair)
+		} else {
+			prefs.PlayWith = append(prefs.PlayWith, pair)
+		}
+	}
+	friendships, err := h.queries.ListFriendshipsAmong(r.Context(), ids32)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch friendships")
+		return
+	}
+	for _, f := range friendships {
+		prefs.Friends = append(prefs.Friends, pairing.Pair{int64(f.FollowerID.Int32), int64(f.FolloweeID.Int32)})
+	}
+
+	groups, err := pairing.Generate(players, prefs, req.GroupSize, len(req.TeeTimes))
+	if err != nil {
+		respondGroupError(w, err)
+		return
+	}
+
+	inputs := make([]store.EventGroupInput, len(groups))
+	for i, g := range groups {
+		inputs[i] = store.EventGroupInput{TeeTime: req.TeeTimes[i], PlayerIDs: g}
+	}
+	if err := store.ReplaceEventGroups(r.Context(), h.db, h.queries, eventID, inputs); err != nil {
+		respondGroupError(w, err)
+		return
+	}
+
+	h.respondEventGroups(w, r, eventID, http.StatusCreated)
+}
+
+// ReplaceEventGroups sets the event's groups by hand, overriding generated
+// pairings. Players left out stay ungrouped.
+func (h *Handler) ReplaceEventGroups(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	var req replaceGroupsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+
+	if !h.requireEventManager(w, r, eventID) {
+		return
+	}
+
+	candidates, err := h.queries.ListPairingCandidates(r.Context(), sql.NullInt64{Int64: eventID, Valid: true})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch players")
+		return
+	}
+	guests := guestCounts(candidates)
+
+	seen := map[int64]bool{}
+	inputs := make([]store.EventGroupInput, len(req.Groups))
+	for i, g := range req.Groups {
+		if !validTeeTime(g.TeeTime) {
+			respondError(w, http.StatusBadRequest, "validation_error", fmt.Sprintf("Tee time %q is invalid", g.TeeTime))
+			return
+		}
+		if len(g.PlayerIDs) == 0 {
+			respondError(w, http.StatusBadRequest, "validation_error", "Groups can't be empty")
+			return
+		}
+		spots := 0
+		for _, id := range g.PlayerIDs {
+			if seen[id] {
+				respondError(w, http.StatusBadRequest, "validation_error", fmt.Sprintf("Player %d is in more than one group", id))
+				return
+			}
+			seen[id] = true
+			spots += 1 + guests[id]
+		}
+		if spots > maxGroupSize {
+			respondError(w, http.StatusBadRequest, "validation_error", fmt.Sprintf("Groups can't have more than %d players including guests", maxGroupSize))
+			return
+		}
+		inputs[i] = store.EventGroupInput{TeeTime: g.TeeTime, PlayerIDs: g.PlayerIDs}
+	}
+
+	if err := store.ReplaceEventGroups(r.Context(), h.db, h.queries, eventID, inputs); err != nil {
+		respondGroupError(w, err)
+		return
+	}
+
+	h.respondEventGroups(w, r, eventID, http.StatusOK)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+type addGuestRequest struct {
+	Name string `json:"name"`
+}
+
+// AddEventGuest adds a named non-member guest to the event on behalf of the
+// logged-in player, who must have accepted. The guest takes one of the
+// event's open spots.
+func (h *Handler) AddEventGuest(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	sponsorID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return
+	}
+
+	var req addGuestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Name can't be blank")
+		return
+	}
+
+	guest, err := store.AddEventGuest(r.Context(), h.db, h.queries, store.AddEventGuestParams{
+		EventID:   eventID,
+		SponsorID: sponsorID,
+		Name:      req.Name,
+	})
+	if errors.Is(err, store.ErrSponsorNotAccepted) {
+		respondError(w, http.StatusConflict, "conflict", "Only players who have accepted can bring guests")
+		return
+	}
+	if errors.Is(err, store.ErrEventFull) {
+		respondError(w, http.StatusConflict, "conflict", "Event is full")
+		return
+	}
+	if errors.Is(err, store.ErrEventCancelled) {
+		respondError(w, http.StatusConflict, "conflict", "Event is cancelled")
+		return
+	}
+	if errors.Is(err, store.ErrEventCompleted) {
+		respondError(w, http.StatusConflict, "conflict", "Event has already been played")
+		return
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to add guest")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, model.GuestResponse{
+		ID:        guest.ID,
+		Name:      guest.Name,
+		SponsorID: guest.SponsorID,
+	})
+}
+
+// RemoveEventGuest removes a guest from the event. Only the guest's sponsor
+// or the event's host or co-hosts can remove them.
+func (h *Handler) RemoveEventGuest(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+	guestID, err := strconv.ParseInt(chi.URLParam(r, "guest_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid guest ID")
+		return
+	}
+
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return
+	}
+	guest, err := h.queries.GetEventGuest(r.Context(), store.GetEventGuestParams{ID: guestID, EventID: eventID})
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Guest not found")
+		return
+	}
+	if guest.SponsorID != authID && !h.requireEventManager(w, r, eventID) {
+		return
+	}
+
+	err = store.RemoveEventGuest(r.Context(), h.db, h.queries, eventID, guestID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusNotFound, "not_found", "Guest not found")
+		return
+	}
+	if errors.Is(err, store.ErrEventCompleted) {
+		respondError(w, http.StatusConflict, "conflict", "Event has already been played")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to remove guest")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
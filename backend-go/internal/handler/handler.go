@@ -2,7 +2,9 @@ package handler
 
 import (
 	"database/sql"
+	"net/http"
 
+	"github.com/ericrabun/findfore-go/internal/middleware"
 	"github.com/ericrabun/findfore-go/internal/store"
 )
 
@@ -19,3 +21,10 @@ func New(queries *store.Queries, db *sql.DB, jwtSecret string) *Handler {
 		jwtSecret: jwtSecret,
 	}
 }
+
+// authPlayerID returns the player authenticated by the request's bearer
+// token, if any.
+func authPlayerID(r *http.Request) (int64, bool) {
+	id, ok := r.Context().Value(middleware.PlayerIDKey).(int64)
+	return id, ok && id > 0
+}
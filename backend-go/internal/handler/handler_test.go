@@ -9,13 +9,17 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 
 	"github.com/ericrabun/findfore-go/internal/auth"
+	"github.com/ericrabun/findfore-go/internal/middleware"
 	"github.com/ericrabun/findfore-go/internal/model"
 	"github.com/ericrabun/findfore-go/internal/store"
 )
@@ -95,6 +99,233 @@ func createTables(db *sql.DB) {
 	);
 	CREATE INDEX IF NOT EXISTS idx_pe_event_id ON player_events (event_id);
 	CREATE INDEX IF NOT EXISTS idx_pe_player_id ON player_events (player_id);
+	CREATE TABLE IF NOT EXISTS event_series (
+		id BIGSERIAL PRIMARY KEY,
+		host_id BIGINT NOT NULL, course_id BIGINT NOT NULL, starts_on DATE NOT NULL, tee_time VARCHAR NOT NULL,
+		open_spots INTEGER NOT NULL, number_of_holes VARCHAR NOT NULL, private BOOLEAN NOT NULL DEFAULT FALSE, rrule VARCHAR NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS event_series_invitees (
+		id BIGSERIAL PRIMARY KEY,
+		series_id BIGINT NOT NULL REFERENCES event_series(id) ON DELETE CASCADE, player_id BIGINT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (series_id, player_id)
+	);
+	CREATE TABLE IF NOT EXISTS event_series_exceptions (
+		id BIGSERIAL PRIMARY KEY,
+		series_id BIGINT NOT NULL REFERENCES event_series(id) ON DELETE CASCADE, occurrence_date DATE NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (series_id, occurrence_date)
+	);
+	CREATE TABLE IF NOT EXISTS event_series_rsvps (
+		id BIGSERIAL PRIMARY KEY,
+		series_id BIGINT NOT NULL REFERENCES event_series(id) ON DELETE CASCADE, player_id BIGINT NOT NULL, invite_status INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (series_id, player_id)
+	);
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS series_id BIGINT;
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_events_series_occurrence ON events (series_id, occurrence_date);
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS starts_at TIMESTAMP;
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS sequence INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP;
+	ALTER TABLE players ADD COLUMN IF NOT EXISTS calendar_token VARCHAR;
+	CREATE TABLE IF NOT EXISTS event_invite_links (
+		id BIGSERIAL PRIMARY KEY,
+		event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE, created_by BIGINT NOT NULL,
+		expires_at TIMESTAMP, max_uses INTEGER, uses INTEGER NOT NULL DEFAULT 0, revoked_at TIMESTAMP,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS event_guests (
+		id BIGSERIAL PRIMARY KEY,
+		event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE, sponsor_id BIGINT NOT NULL, name VARCHAR NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS respond_by TIMESTAMP;
+	ALTER TABLE player_events ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMP;
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS status INTEGER NOT NULL DEFAULT 0;
+	CREATE TABLE IF NOT EXISTS event_cohosts (
+		id BIGSERIAL PRIMARY KEY,
+		event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE, player_id BIGINT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (event_id, player_id)
+	);
+	ALTER TABLE players ADD COLUMN IF NOT EXISTS handicap_index DOUBLE PRECISION;
+	CREATE TABLE IF NOT EXISTS event_groups (
+		id BIGSERIAL PRIMARY KEY,
+		event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE, tee_time VARCHAR NOT NULL, position INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS event_group_members (
+		id BIGSERIAL PRIMARY KEY,
+		event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+		group_id BIGINT NOT NULL REFERENCES event_groups(id) ON DELETE CASCADE, player_id BIGINT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (event_id, player_id)
+	);
+	CREATE TABLE IF NOT EXISTS pairing_preferences (
+		id BIGSERIAL PRIMARY KEY,
+		player_id BIGINT NOT NULL, other_player_id BIGINT NOT NULL, preference INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (player_id, other_player_id)
+	);
+	CREATE TABLE IF NOT EXISTS posts (
+		id BIGSERIAL PRIMARY KEY,
+		player_id BIGINT NOT NULL, body TEXT NOT NULL, event_id BIGINT REFERENCES events(id) ON DELETE CASCADE,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS reactions (
+		id BIGSERIAL PRIMARY KEY,
+		post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE, player_id BIGINT NOT NULL, emoji VARCHAR(32) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (post_id, player_id, emoji)
+	);
+	CREATE TABLE IF NOT EXISTS replies (
+		id BIGSERIAL PRIMARY KEY,
+		post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE, player_id BIGINT NOT NULL, body TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	ALTER TABLE courses ADD COLUMN IF NOT EXISTS fee_cents INTEGER;
+	ALTER TABLE courses ADD COLUMN IF NOT EXISTS fee_currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS fee_cents INTEGER;
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS fee_currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+	CREATE TABLE IF NOT EXISTS event_expenses (
+		id BIGSERIAL PRIMARY KEY,
+		event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE, payer_id BIGINT NOT NULL,
+		amount_cents INTEGER NOT NULL, currency VARCHAR(3) NOT NULL, description VARCHAR NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS settlements (
+		id BIGSERIAL PRIMARY KEY,
+		payer_id BIGINT NOT NULL, payee_id BIGINT NOT NULL, amount_cents INTEGER NOT NULL, currency VARCHAR(3) NOT NULL, note VARCHAR,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS scorecards (
+		id BIGSERIAL PRIMARY KEY,
+		player_event_id BIGINT NOT NULL UNIQUE REFERENCES player_events(id) ON DELETE CASCADE, entered_by BIGINT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS scorecard_holes (
+		id BIGSERIAL PRIMARY KEY,
+		scorecard_id BIGINT NOT NULL REFERENCES scorecards(id) ON DELETE CASCADE, hole_number INTEGER NOT NULL,
+		strokes INTEGER NOT NULL, putts INTEGER, fairway_hit BOOLEAN, green_in_regulation BOOLEAN, par INTEGER,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (scorecard_id, hole_number)
+	);
+	ALTER TABLE players ADD COLUMN IF NOT EXISTS admin BOOLEAN NOT NULL DEFAULT FALSE;
+	ALTER TABLE players ADD COLUMN IF NOT EXISTS scores_visibility INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE courses ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+	ALTER TABLE courses ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+	ALTER TABLE courses ADD COLUMN IF NOT EXISTS time_zone VARCHAR NOT NULL DEFAULT 'America/Denver';
+	ALTER TABLE players ADD COLUMN IF NOT EXISTS home_course_id INTEGER REFERENCES courses(id) ON DELETE SET NULL;
+	CREATE TABLE IF NOT EXISTS tee_sets (
+		id BIGSERIAL PRIMARY KEY,
+		course_id BIGINT NOT NULL REFERENCES courses(id) ON DELETE CASCADE, name VARCHAR NOT NULL, color VARCHAR,
+		course_rating DOUBLE PRECISION NOT NULL, slope_rating INTEGER NOT NULL, yardage INTEGER,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (course_id, name)
+	);
+	CREATE TABLE IF NOT EXISTS tee_set_holes (
+		id BIGSERIAL PRIMARY KEY,
+		tee_set_id BIGINT NOT NULL REFERENCES tee_sets(id) ON DELETE CASCADE, hole_number INTEGER NOT NULL,
+		par INTEGER NOT NULL, stroke_index INTEGER NOT NULL, yardage INTEGER,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (tee_set_id, hole_number)
+	);
+	ALTER TABLE scorecards ADD COLUMN IF NOT EXISTS tee_set_id BIGINT REFERENCES tee_sets(id) ON DELETE SET NULL;
+	CREATE TABLE IF NOT EXISTS handicap_revisions (
+		id BIGSERIAL PRIMARY KEY,
+		player_id BIGINT NOT NULL, scorecard_id BIGINT NOT NULL REFERENCES scorecards(id) ON DELETE CASCADE,
+		played_at TIMESTAMP NOT NULL, adjusted_gross_score INTEGER NOT NULL, score_differential DOUBLE PRECISION NOT NULL,
+		handicap_index DOUBLE PRECISION, low_handicap_index DOUBLE PRECISION,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS event_games (
+		id BIGSERIAL PRIMARY KEY,
+		event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE, format INTEGER NOT NULL,
+		net BOOLEAN NOT NULL DEFAULT FALSE, stake_cents INTEGER NOT NULL CHECK (stake_cents > 0),
+		carryover BOOLEAN NOT NULL DEFAULT TRUE, settled_at TIMESTAMP,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS event_game_players (
+		id BIGSERIAL PRIMARY KEY,
+		game_id BIGINT NOT NULL REFERENCES event_games(id) ON DELETE CASCADE, player_id BIGINT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (game_id, player_id)
+	);
+	CREATE TABLE IF NOT EXISTS game_payouts (
+		id BIGSERIAL PRIMARY KEY,
+		game_id BIGINT NOT NULL REFERENCES event_games(id) ON DELETE CASCADE,
+		payer_id BIGINT NOT NULL, payee_id BIGINT NOT NULL, amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+		currency VARCHAR(3) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS leagues (
+		id BIGSERIAL PRIMARY KEY,
+		name VARCHAR NOT NULL, commissioner_id BIGINT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS league_members (
+		id BIGSERIAL PRIMARY KEY,
+		league_id BIGINT NOT NULL REFERENCES leagues(id) ON DELETE CASCADE, player_id BIGINT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (league_id, player_id)
+	);
+	CREATE TABLE IF NOT EXISTS league_seasons (
+		id BIGSERIAL PRIMARY KEY,
+		league_id BIGINT NOT NULL REFERENCES leagues(id) ON DELETE CASCADE, name VARCHAR NOT NULL,
+		course_id BIGINT NOT NULL, starts_on DATE NOT NULL, tee_time VARCHAR NOT NULL, open_spots INTEGER NOT NULL,
+		number_of_holes VARCHAR NOT NULL, rrule VARCHAR NOT NULL, net BOOLEAN NOT NULL DEFAULT FALSE,
+		finish_points INTEGER[] NOT NULL DEFAULT '{}', match_win_points INTEGER NOT NULL DEFAULT 0,
+		match_halve_points INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS league_season_id BIGINT REFERENCES league_seasons(id) ON DELETE SET NULL;
+	CREATE TABLE IF NOT EXISTS tournaments (
+		id BIGSERIAL PRIMARY KEY,
+		name VARCHAR NOT NULL, host_id BIGINT NOT NULL, course_id BIGINT NOT NULL,
+		number_of_holes VARCHAR NOT NULL, net BOOLEAN NOT NULL DEFAULT FALSE,
+		status INTEGER NOT NULL DEFAULT 0, winner_id BIGINT,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS tournament_players (
+		id BIGSERIAL PRIMARY KEY,
+		tournament_id BIGINT NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE, player_id BIGINT NOT NULL,
+		seed INTEGER, created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (tournament_id, player_id)
+	);
+	CREATE TABLE IF NOT EXISTS tournament_matches (
+		id BIGSERIAL PRIMARY KEY,
+		tournament_id BIGINT NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+		round INTEGER NOT NULL, position INTEGER NOT NULL, player1_id BIGINT, player2_id BIGINT,
+		event_id BIGINT REFERENCES events(id) ON DELETE SET NULL, winner_id BIGINT, result INTEGER,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (tournament_id, round, position)
+	);
+	CREATE TABLE IF NOT EXISTS player_stats (
+		id BIGSERIAL PRIMARY KEY,
+		player_id BIGINT NOT NULL REFERENCES players(id) ON DELETE CASCADE, filter VARCHAR NOT NULL,
+		summary JSONB NOT NULL, created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (player_id, filter)
+	);
+	CREATE TABLE IF NOT EXISTS player_availability (
+		id BIGSERIAL PRIMARY KEY,
+		player_id BIGINT NOT NULL REFERENCES players(id) ON DELETE CASCADE, day_of_week INTEGER NOT NULL,
+		start_minute INTEGER NOT NULL, end_minute INTEGER NOT NULL, created_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS player_preferred_courses (
+		id BIGSERIAL PRIMARY KEY,
+		player_id BIGINT NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+		course_id BIGINT NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE (player_id, course_id)
+	);
+	CREATE TABLE IF NOT EXISTS notifications (
+		id BIGSERIAL PRIMARY KEY,
+		player_id BIGINT NOT NULL, event_id BIGINT REFERENCES events(id) ON DELETE CASCADE,
+		kind VARCHAR NOT NULL, message VARCHAR NOT NULL, read_at TIMESTAMP,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(), updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
 	`
 	db.Exec(schema)
 
@@ -103,17 +334,17 @@ func createTables(db *sql.DB) {
 	db.Exec("ALTER TABLE player_events ADD CONSTRAINT fk_pe_players FOREIGN KEY (player_id) REFERENCES players(id)")
 }
 
-func cleanDB(t *testing.T) {
+func cleanDB(t testing.TB) {
 	t.Helper()
-	for _, table := range []string{"player_events", "friendships", "events", "courses", "players"} {
+	for _, table := range []string{"player_availability", "player_preferred_courses", "player_stats", "tournament_matches", "tournament_players", "tournaments", "league_seasons", "league_members", "leagues", "game_payouts", "event_game_players", "event_games", "handicap_revisions", "tee_set_holes", "tee_sets", "scorecard_holes", "scorecards", "settlements", "event_expenses", "replies", "reactions", "posts", "pairing_preferences", "event_group_members", "event_groups", "event_cohosts", "notifications", "event_guests", "event_invite_links", "event_series_rsvps", "event_series_exceptions", "event_series_invitees", "player_events", "friendships", "events", "event_series", "courses", "players"} {
 		testDB.Exec(fmt.Sprintf("DELETE FROM %s", table))
 	}
-	for _, table := range []string{"players", "courses", "events", "friendships", "player_events"} {
+	for _, table := range []string{"players", "courses", "events", "friendships", "player_events", "event_series", "event_series_invitees", "event_series_exceptions", "event_series_rsvps", "event_invite_links", "event_guests", "notifications", "event_cohosts", "event_groups", "event_group_members", "pairing_preferences", "posts", "reactions", "replies", "event_expenses", "settlements", "scorecards", "scorecard_holes", "tee_sets", "tee_set_holes", "handicap_revisions", "event_games", "event_game_players", "game_payouts", "leagues", "league_members", "league_seasons", "tournaments", "tournament_players", "tournament_matches", "player_stats", "player_availability", "player_preferred_courses"} {
 		testDB.Exec(fmt.Sprintf("ALTER SEQUENCE %s_id_seq RESTART WITH 1", table))
 	}
 }
 
-func seedPlayer(t *testing.T, name, email, password string) int64 {
+func seedPlayer(t testing.TB, name, email, password string) int64 {
 	t.Helper()
 	hash, _ := auth.HashPassword(password)
 	row := testDB.QueryRow(
@@ -127,7 +358,7 @@ func seedPlayer(t *testing.T, name, email, password string) int64 {
 	return id
 }
 
-func seedCourse(t *testing.T, name string) int64 {
+func seedCourse(t testing.TB, name string) int64 {
 	t.Helper()
 	row := testDB.QueryRow(
 		"INSERT INTO courses (name, street, city, state, zip_code, phone, cost, created_at, updated_at) VALUES ($1, '123 Main St', 'Denver', 'CO', '80202', '555-1234', '80', NOW(), NOW()) RETURNING id",
@@ -140,11 +371,16 @@ func seedCourse(t *testing.T, name string) int64 {
 	return id
 }
 
-func seedEvent(t *testing.T, courseID, hostID int64, openSpots int, private bool) int64 {
+func seedEvent(t testing.TB, courseID, hostID int64, openSpots int, private bool) int64 {
+	t.Helper()
+	return seedEventAt(t, courseID, hostID, openSpots, private, "2025-01-01", "10:00")
+}
+
+func seedEventAt(t testing.TB, courseID, hostID int64, openSpots int, private bool, date, teeTime string) int64 {
 	t.Helper()
 	row := testDB.QueryRow(
-		"INSERT INTO events (course_id, date, tee_time, open_spots, number_of_holes, private, host_id, created_at, updated_at) VALUES ($1, '2025-01-01', '10:00', $2, '18', $3, $4, NOW(), NOW()) RETURNING id",
-		courseID, openSpots, private, hostID,
+		"INSERT INTO events (course_id, date, tee_time, open_spots, number_of_holes, private, host_id, starts_at, created_at, updated_at) VALUES ($1, $5, $6, $2, '18', $3, $4, $7, NOW(), NOW()) RETURNING id",
+		courseID, openSpots, private, hostID, date, teeTime, date+" "+teeTime,
 	)
 	var id int64
 	if err := row.Scan(&id); err != nil {
@@ -153,7 +389,7 @@ func seedEvent(t *testing.T, courseID, hostID int64, openSpots int, private bool
 	return id
 }
 
-func seedPlayerEvent(t *testing.T, playerID, eventID int64, status int) {
+func seedPlayerEvent(t testing.TB, playerID, eventID int64, status int) {
 	t.Helper()
 	_, err := testDB.Exec(
 		"INSERT INTO player_events (player_id, event_id, invite_status, created_at, updated_at) VALUES ($1, $2, $3, NOW(), NOW())",
@@ -208,6 +444,31 @@ func doRequestWithChiCtx(t *testing.T, method, path string, body interface{}, ha
 	return rr
 }
 
+// doAuthRequestWithChiCtx is doRequestWithChiCtx as if authPlayerID had sent a
+// valid bearer token. An authPlayerID of 0 sends the request unauthenticated.
+func doAuthRequestWithChiCtx(t *testing.T, method, path string, body interface{}, handler http.HandlerFunc, params map[string]string, authPlayerID int64) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+
+	rctx := chi.NewRouteContext()
+	for k, v := range params {
+		rctx.URLParams.Add(k, v)
+	}
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	if authPlayerID > 0 {
+		ctx = context.WithValue(ctx, middleware.PlayerIDKey, authPlayerID)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req.WithContext(ctx))
+	return rr
+}
+
 // ===================== COURSES =====================
 
 func TestListCourses(t *testing.T) {
@@ -231,6 +492,145 @@ func TestListCourses(t *testing.T) {
 	}
 }
 
+func seedAdmin(t *testing.T) int64 {
+	t.Helper()
+	id := seedPlayer(t, "Admin", "admin@test.com", "password")
+	testDB.Exec("UPDATE players SET admin = TRUE WHERE id = $1", id)
+	return id
+}
+
+// teeSetBody is an 18 hole par 72 tee set with 350 yards a hole.
+func teeSetBody(name string, courseRating float64, slopeRating int) map[string]interface{} {
+	pars := []int{4, 4, 3, 5, 4, 4, 3, 4, 5, 4, 3, 4, 5, 4, 4, 3, 5, 4}
+	holes := make([]map[string]interface{}, len(pars))
+	for i, par := range pars {
+		holes[i] = map[string]interface{}{"hole": i + 1, "par": par, "stroke_index": i + 1, "yardage": 350}
+	}
+	return map[string]interface{}{
+		"name":          name,
+		"color":         strings.ToLower(name),
+		"course_rating": courseRating,
+		"slope_rating":  slopeRating,
+		"holes":         holes,
+	}
+}
+
+func createTeeSet(t *testing.T, courseID, authID int64, body map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/courses/%d/tee-sets", courseID), body,
+		testHandler.CreateTeeSet, map[string]string{"id": fmt.Sprintf("%d", courseID)}, authID)
+}
+
+func getCourse(t *testing.T, courseID int64) model.CourseDetailResponse {
+	t.Helper()
+	rr := doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/courses/%d", courseID), nil,
+		testHandler.GetCourse, map[string]string{"id": fmt.Sprintf("%d", courseID)})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.CourseDetailResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	return resp
+}
+
+func TestTeeSets(t *testing.T) {
+	cleanDB(t)
+	admin := seedAdmin(t)
+	player := seedPlayer(t, "Amy", "amy@test.com", "password")
+	c1 := seedCourse(t, "Green Valley Ranch")
+
+	if rr := createTeeSet(t, c1, player, teeSetBody("White", 70.4, 128)); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a non-admin, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := createTeeSet(t, c1, admin, teeSetBody("White", 70.4, 128)); rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := createTeeSet(t, c1, admin, teeSetBody("Blue", 72.1, 135)); rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := createTeeSet(t, c1, admin, teeSetBody("Blue", 72.1, 135)); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for a duplicate name, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	course := getCourse(t, c1)
+	if course.Name != "Green Valley Ranch" || len(course.TeeSets) != 2 {
+		t.Fatalf("expected the course with 2 tee sets, got %+v", course)
+	}
+	blue := course.TeeSets[0]
+	if blue.Name != "Blue" || blue.CourseRating != 72.1 || blue.SlopeRating != 135 || blue.Par != 72 || len(blue.Holes) != 18 {
+		t.Errorf("expected the blue tees first, got %+v", blue)
+	}
+	if blue.Yardage == nil || *blue.Yardage != 6300 {
+		t.Errorf("expected the yardage to add up to 6300, got %v", blue.Yardage)
+	}
+
+	body := teeSetBody("Blue", 72.4, 137)
+	rr := doAuthRequestWithChiCtx(t, "PUT", fmt.Sprintf("/api/v1/courses/%d/tee-sets/%d", c1, blue.ID), body,
+		testHandler.UpdateTeeSet, map[string]string{"id": fmt.Sprintf("%d", c1), "tee_set_id": fmt.Sprintf("%d", blue.ID)}, admin)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "DELETE", fmt.Sprintf("/api/v1/courses/%d/tee-sets/%d", c1, blue.ID), nil,
+		testHandler.DeleteTeeSet, map[string]string{"id": fmt.Sprintf("%d", c1), "tee_set_id": fmt.Sprintf("%d", blue.ID)}, admin)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if course := getCourse(t, c1); len(course.TeeSets) != 1 || course.TeeSets[0].Name != "White" {
+		t.Errorf("expected only the white tees left, got %+v", course.TeeSets)
+	}
+}
+
+func TestCreateTeeSet_Validation(t *testing.T) {
+	cleanDB(t)
+	admin := seedAdmin(t)
+	c1 := seedCourse(t, "Green Valley Ranch")
+
+	repeatedIndex := teeSetBody("White", 70.4, 128)
+	repeatedIndex["holes"].([]map[string]interface{})[1]["stroke_index"] = 1
+	missingHole := teeSetBody("White", 70.4, 128)
+	missingHole["holes"] = missingHole["holes"].([]map[string]interface{})[:17]
+
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{"slope too high", teeSetBody("White", 70.4, 160)},
+		{"no name", teeSetBody("", 70.4, 128)},
+		{"repeated stroke index", repeatedIndex},
+		{"17 holes", missingHole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rr := createTeeSet(t, c1, admin, tt.body); rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestImportTeeSets(t *testing.T) {
+	cleanDB(t)
+	admin := seedAdmin(t)
+	c1 := seedCourse(t, "Green Valley Ranch")
+	createTeeSet(t, c1, admin, teeSetBody("White", 70.4, 128))
+
+	rr := doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/courses/%d/tee-sets/import", c1), map[string]interface{}{
+		"tee_sets": []map[string]interface{}{teeSetBody("White", 69.8, 125), teeSetBody("Red", 67.0, 115)},
+	}, testHandler.ImportTeeSets, map[string]string{"id": fmt.Sprintf("%d", c1)}, admin)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var course model.CourseDetailResponse
+	json.NewDecoder(rr.Body).Decode(&course)
+	if len(course.TeeSets) != 2 {
+		t.Fatalf("expected the white tees updated and red added, got %+v", course.TeeSets)
+	}
+	if course.TeeSets[0].Name != "White" || course.TeeSets[0].CourseRating != 69.8 || len(course.TeeSets[0].Holes) != 18 {
+		t.Errorf("expected the white tees to be rerated, got %+v", course.TeeSets[0])
+	}
+}
+
 // ===================== PLAYERS =====================
 
 func TestListPlayers(t *testing.T) {
@@ -442,6 +842,138 @@ func TestListEvents_PublicOnly(t *testing.T) {
 	}
 }
 
+func TestListEvents_Filters(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	c2 := seedCourse(t, "City Park")
+	e1 := seedEventAt(t, c1, p1, 2, false, "2025-06-03", "17:00")
+	e2 := seedEventAt(t, c2, p2, 1, false, "2025-06-10", "17:00")
+	e3 := seedEventAt(t, c1, p2, 4, false, "2025-07-01", "09:00")
+	seedPlayerEvent(t, p1, e1, 1)
+	seedPlayerEvent(t, p2, e2, 1) // e2 is full
+	seedPlayerEvent(t, p2, e3, 1)
+	seedFriendship(t, p1, p2)
+
+	tests := []struct {
+		query string
+		want  []int64
+	}{
+		{"course_id=" + fmt.Sprint(c1), []int64{e1, e3}},
+		{"from=2025-06-05&to=2025-06-30", []int64{e2}},
+		{"to=2025-06-10", []int64{e1, e2}},
+		{"has_open_spots=true", []int64{e1, e3}},
+		{"host_id=" + fmt.Sprint(p2) + "&sort=-starts_at", []int64{e3, e2}},
+		{"friends_of=" + fmt.Sprint(p1), []int64{e2, e3}},
+		{"city=denver&state=co&number_of_holes=18", []int64{e1, e2, e3}},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/api/v1/events?"+tt.query, nil)
+		rr := httptest.NewRecorder()
+		testHandler.ListEvents(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d: %s", tt.query, rr.Code, rr.Body.String())
+			continue
+		}
+
+		var events []model.EventResponse
+		json.NewDecoder(rr.Body).Decode(&events)
+		got := make([]int64, len(events))
+		for i, e := range events {
+			got[i] = e.ID
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: expected events %v, got %v", tt.query, tt.want, got)
+		}
+	}
+}
+
+func TestListEvents_CursorPagination(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	var want []int64
+	for _, date := range []string{"2025-06-03", "2025-06-10", "2025-06-17", "2025-06-24", "2025-07-01"} {
+		want = append(want, seedEventAt(t, c1, p1, 4, false, date, "17:00"))
+	}
+
+	var got []int64
+	cursor := ""
+	for pages := 0; pages < 10; pages++ {
+		path := "/api/v1/events?limit=2"
+		if cursor != "" {
+			path += "&cursor=" + cursor
+		}
+		req := httptest.NewRequest("GET", path, nil)
+		rr := httptest.NewRecorder()
+		testHandler.ListEvents(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+
+		var events []model.EventResponse
+		json.NewDecoder(rr.Body).Decode(&events)
+		for _, e := range events {
+			got = append(got, e.ID)
+		}
+
+		cursor = rr.Header().Get("X-Next-Cursor")
+		if cursor == "" {
+			break
+		}
+	}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected events %v across pages, got %v", want, got)
+	}
+}
+
+func TestListEvents_InvalidFilter(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/events?from=yesterday", nil)
+	rr := httptest.NewRecorder()
+	testHandler.ListEvents(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestListEvents_AvailableTo(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	open := seedEventAt(t, c1, host, 4, false, "2025-06-03", "17:00")
+	declined := seedEventAt(t, c1, host, 4, false, "2025-06-04", "17:00")
+	invited := seedEventAt(t, c1, host, 4, true, "2025-06-05", "17:00")
+	seedEventAt(t, c1, host, 4, true, "2025-06-06", "17:00") // private, not invited
+	seedPlayerEvent(t, p2, declined, 2)
+	seedPlayerEvent(t, p2, invited, 0)
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/events?available_to=%d", p2), nil)
+	rr := httptest.NewRecorder()
+	testHandler.ListEvents(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var events []model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&events)
+	got := make([]int64, len(events))
+	for i, e := range events {
+		got[i] = e.ID
+	}
+	want := []int64{open, invited}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected events %v, got %v", want, got)
+	}
+}
+
 func TestGetEvent(t *testing.T) {
 	cleanDB(t)
 	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
@@ -481,8 +1013,7 @@ func TestGetEvent_NotFound(t *testing.T) {
 func TestCreateEvent_Public(t *testing.T) {
 	cleanDB(t)
 	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
-	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
-	_ = p2
+	seedPlayer(t, "Bob", "bob@test.com", "password")
 	c1 := seedCourse(t, "Green Valley")
 
 	body := map[string]interface{}{
@@ -511,9 +1042,9 @@ func TestCreateEvent_Public(t *testing.T) {
 	if len(event.Accepted) != 1 || event.Accepted[0] != p1 {
 		t.Errorf("expected host in accepted list, got %v", event.Accepted)
 	}
-	// Other player should be pending (public event invites all)
-	if len(event.Pending) != 1 || event.Pending[0] != p2 {
-		t.Errorf("expected other player in pending list, got %v", event.Pending)
+	// Public events don't create a row per player; only targeted invites are pending
+	if len(event.Pending) != 0 {
+		t.Errorf("expected no pending players, got %v", event.Pending)
 	}
 }
 
@@ -570,12 +1101,22 @@ func TestCreateEvent_MissingFields(t *testing.T) {
 func TestDeleteEvent(t *testing.T) {
 	cleanDB(t)
 	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
 	c1 := seedCourse(t, "Green Valley")
 	eid := seedEvent(t, c1, p1, 3, false)
 	seedPlayerEvent(t, p1, eid, 1)
+	params := map[string]string{"id": fmt.Sprintf("%d", eid)}
 
-	rr := doRequestWithChiCtx(t, "DELETE", fmt.Sprintf("/api/v1/event/%d", eid), nil, testHandler.DeleteEvent, map[string]string{"id": fmt.Sprintf("%d", eid)})
+	rr := doRequestWithChiCtx(t, "DELETE", fmt.Sprintf("/api/v1/event/%d", eid), nil, testHandler.DeleteEvent, params)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 when logged out, got %d", rr.Code)
+	}
+	rr = doAuthRequestWithChiCtx(t, "DELETE", fmt.Sprintf("/api/v1/event/%d", eid), nil, testHandler.DeleteEvent, params, p2)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for someone other than the host, got %d", rr.Code)
+	}
 
+	rr = doAuthRequestWithChiCtx(t, "DELETE", fmt.Sprintf("/api/v1/event/%d", eid), nil, testHandler.DeleteEvent, params, p1)
 	if rr.Code != http.StatusOK {
 		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
 	}
@@ -621,77 +1162,3365 @@ func TestListEventsByPlayer(t *testing.T) {
 	}
 }
 
-// ===================== FRIENDSHIPS =====================
-
-func TestCreateFriendship(t *testing.T) {
+func TestListEventsByPlayer_Unbounded(t *testing.T) {
 	cleanDB(t)
 	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
-	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
-
-	body := map[string]int64{
-		"follower_id": p1,
-		"followee_id": p2,
+	c1 := seedCourse(t, "Green Valley")
+	for i := 0; i <= defaultEventsLimit; i++ {
+		seedPlayerEvent(t, p1, seedEvent(t, c1, p1, 4, false), 1)
 	}
+	params := map[string]string{"player_id": fmt.Sprintf("%d", p1)}
 
-	rr := doRequest(t, "POST", "/api/v1/friendship", body, testHandler.CreateFriendship)
+	rr := doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/events", p1), nil, testHandler.ListEvents, params)
+	var events []model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&events)
+	if len(events) != defaultEventsLimit+1 || rr.Header().Get("X-Next-Cursor") != "" {
+		t.Errorf("expected every event without a cursor, got %d", len(events))
+	}
 
-	if rr.Code != http.StatusCreated {
-		t.Errorf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	rr = doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/events?limit=2", p1), nil, testHandler.ListEvents, params)
+	events = nil
+	json.NewDecoder(rr.Body).Decode(&events)
+	if len(events) != 2 || rr.Header().Get("X-Next-Cursor") == "" {
+		t.Errorf("expected a page of 2 with a cursor when a limit is given, got %d", len(events))
 	}
+}
 
-	var friendship model.FriendshipResponse
-	json.NewDecoder(rr.Body).Decode(&friendship)
-	if friendship.Followee.Name != "Bob" {
-		t.Errorf("expected followee name 'Bob', got '%s'", friendship.Followee.Name)
+// ===================== EVENT SERIES =====================
+
+func createTestSeries(t *testing.T, body map[string]interface{}) model.SeriesResponse {
+	t.Helper()
+	rr := doRequest(t, "POST", "/api/v1/series", body, testHandler.CreateEventSeries)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
 	}
+	var series model.SeriesResponse
+	json.NewDecoder(rr.Body).Decode(&series)
+	return series
 }
 
-func TestCreateFriendship_Idempotent(t *testing.T) {
+func TestCreateEventSeries_MaterializesOccurrences(t *testing.T) {
 	cleanDB(t)
 	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
 	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
-	seedFriendship(t, p1, p2)
-
-	body := map[string]int64{
-		"follower_id": p1,
-		"followee_id": p2,
-	}
+	c1 := seedCourse(t, "Green Valley")
 
-	rr := doRequest(t, "POST", "/api/v1/friendship", body, testHandler.CreateFriendship)
+	start := today()
+	series := createTestSeries(t, map[string]interface{}{
+		"course_id":       c1,
+		"starts_on":       start.Format(dateLayout),
+		"tee_time":        "17:00",
+		"open_spots":      4,
+		"number_of_holes": "9",
+		"private":         true,
+		"host_id":         p1,
+		"invitees":        []int64{p2},
+		"rrule":           "FREQ=WEEKLY;COUNT=3",
+		"exceptions":      []string{start.AddDate(0, 0, 7).Format(dateLayout)},
+	})
 
-	if rr.Code != http.StatusCreated {
-		t.Errorf("expected status 201 (idempotent), got %d", rr.Code)
+	if len(series.Occurrences) != 2 {
+		t.Fatalf("expected 2 occurrences, got %d", len(series.Occurrences))
 	}
+	if series.Occurrences[1].Date != start.AddDate(0, 0, 14).Format(dateLayout) {
+		t.Errorf("expected second occurrence two weeks out, got %s", series.Occurrences[1].Date)
+	}
+	for _, occ := range series.Occurrences {
+		if len(occ.Pending) != 1 || occ.Pending[0] != p2 {
+			t.Errorf("expected invitee pending on every occurrence, got %v", occ.Pending)
+		}
+	}
+}
+
+func TestCreateEventSeries_InvalidRRule(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+
+	body := map[string]interface{}{
+		"course_id":       c1,
+		"starts_on":       today().Format(dateLayout),
+		"tee_time":        "17:00",
+		"open_spots":      4,
+		"number_of_holes": "9",
+		"host_id":         p1,
+		"rrule":           "FREQ=DAILY",
+	}
+
+	rr := doRequest(t, "POST", "/api/v1/series", body, testHandler.CreateEventSeries)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestRsvpEventSeries_AcceptAll(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+
+	series := createTestSeries(t, map[string]interface{}{
+		"course_id":       c1,
+		"starts_on":       today().Format(dateLayout),
+		"tee_time":        "17:00",
+		"open_spots":      4,
+		"number_of_holes": "9",
+		"private":         true,
+		"host_id":         p1,
+		"invitees":        []int64{p2},
+		"rrule":           "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+	})
+
+	body := map[string]interface{}{
+		"player_id":     p2,
+		"invite_status": "accepted",
+	}
+	rr := doRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/series/%d/rsvp", series.ID), body, testHandler.RsvpEventSeries, map[string]string{"id": fmt.Sprintf("%d", series.ID)})
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var updated model.SeriesResponse
+	json.NewDecoder(rr.Body).Decode(&updated)
+	if len(updated.Occurrences) != 3 {
+		t.Fatalf("expected 3 occurrences, got %d", len(updated.Occurrences))
+	}
+	for _, occ := range updated.Occurrences {
+		if len(occ.Accepted) != 2 {
+			t.Errorf("expected host and invitee accepted on %s, got %v", occ.Date, occ.Accepted)
+		}
+	}
+}
+
+func TestOverrideSeriesOccurrence(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+
+	start := today()
+	series := createTestSeries(t, map[string]interface{}{
+		"course_id":       c1,
+		"starts_on":       start.Format(dateLayout),
+		"tee_time":        "17:00",
+		"open_spots":      4,
+		"number_of_holes": "9",
+		"host_id":         p1,
+		"rrule":           "FREQ=WEEKLY;COUNT=2",
+	})
+
+	date := start.AddDate(0, 0, 7).Format(dateLayout)
+	body := map[string]interface{}{"tee_time": "16:30"}
+	params := map[string]string{"id": fmt.Sprintf("%d", series.ID), "date": date}
+	rr := doAuthRequestWithChiCtx(t, "PATCH", "/api/v1/series/1/occurrences/"+date, body, testHandler.OverrideSeriesOccurrence, params, p2)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for someone other than the host, got %d", rr.Code)
+	}
+	rr = doRequestWithChiCtx(t, "PATCH", "/api/v1/series/1/occurrences/"+date, body, testHandler.OverrideSeriesOccurrence, params)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 when logged out, got %d", rr.Code)
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "PATCH", "/api/v1/series/1/occurrences/"+date, body, testHandler.OverrideSeriesOccurrence, params, p1)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var event model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&event)
+	if event.TeeTime != "16:30" {
+		t.Errorf("expected overridden tee time 16:30, got %s", event.TeeTime)
+	}
+	if event.ID != series.Occurrences[1].ID {
+		t.Errorf("expected override to edit event %d, got %d", series.Occurrences[1].ID, event.ID)
+	}
+}
+
+func TestCancelSeriesOccurrence(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+
+	start := today()
+	series := createTestSeries(t, map[string]interface{}{
+		"course_id":       c1,
+		"starts_on":       start.Format(dateLayout),
+		"tee_time":        "17:00",
+		"open_spots":      4,
+		"number_of_holes": "9",
+		"host_id":         p1,
+		"rrule":           "FREQ=WEEKLY;COUNT=2",
+	})
+
+	date := start.AddDate(0, 0, 7).Format(dateLayout)
+	params := map[string]string{"id": fmt.Sprintf("%d", series.ID), "date": date}
+	rr := doAuthRequestWithChiCtx(t, "DELETE", "/api/v1/series/1/occurrences/"+date, nil, testHandler.CancelSeriesOccurrence, params, p2)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for someone other than the host, got %d", rr.Code)
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "DELETE", "/api/v1/series/1/occurrences/"+date, nil, testHandler.CancelSeriesOccurrence, params, p1)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// The occurrence is cancelled rather than deleted so calendars pick it
+	// up, and re-reading the series must not re-materialize the date.
+	if event := getEvent(t, series.Occurrences[1].ID); event.Status != "cancelled" {
+		t.Errorf("expected the occurrence cancelled, got %s", event.Status)
+	}
+	rr = doRequestWithChiCtx(t, "GET", "/api/v1/series/1", nil, testHandler.GetEventSeries, map[string]string{"id": fmt.Sprintf("%d", series.ID)})
+	var updated model.SeriesResponse
+	json.NewDecoder(rr.Body).Decode(&updated)
+	if len(updated.Occurrences) != 2 || updated.Occurrences[1].Status != "cancelled" {
+		t.Errorf("expected the cancelled occurrence kept, got %+v", updated.Occurrences)
+	}
+	if len(updated.Exceptions) != 1 || updated.Exceptions[0] != date {
+		t.Errorf("expected %s recorded as exception, got %v", date, updated.Exceptions)
+	}
+}
+
+// ===================== CALENDAR =====================
+
+func TestGetEventCalendar(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEventAt(t, c1, p1, 4, false, "2025-06-03", "17:00")
+
+	rr := doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/event/%d.ics", eid), nil, testHandler.GetEventCalendar, map[string]string{"id": fmt.Sprintf("%d", eid)})
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "text/calendar; charset=utf-8" {
+		t.Errorf("expected text/calendar content type, got '%s'", ct)
+	}
+	body := rr.Body.String()
+	for _, want := range []string{
+		fmt.Sprintf("UID:event-%d@findfore\r\n", eid),
+		"SEQUENCE:0\r\n",
+		"DTSTART:20250603T170000\r\n",
+		"LOCATION:Green Valley\\, 123 Main St\\, Denver\\, CO 80202\r\n",
+		"STATUS:CONFIRMED\r\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected calendar to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestGetEventCalendar_NotFound(t *testing.T) {
+	cleanDB(t)
+
+	rr := doRequestWithChiCtx(t, "GET", "/api/v1/event/999.ics", nil, testHandler.GetEventCalendar, map[string]string{"id": "999"})
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestCancelEvent(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEventAt(t, c1, host, 4, false, "2025-06-03", "17:00")
+	seedPlayerEvent(t, host, eid, 1)
+	params := map[string]string{"id": fmt.Sprintf("%d", eid)}
+
+	rr := doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/cancel", eid), nil, testHandler.CancelEvent, params, host)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var event model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&event)
+	if !event.Cancelled {
+		t.Error("expected event to be cancelled")
+	}
+
+	// Cancelling again is a no-op and doesn't bump the sequence twice
+	doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/cancel", eid), nil, testHandler.CancelEvent, params, host)
+
+	rr = doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/event/%d.ics", eid), nil, testHandler.GetEventCalendar, params)
+	body := rr.Body.String()
+	if !strings.Contains(body, "SEQUENCE:1\r\n") || !strings.Contains(body, "STATUS:CANCELLED\r\n") {
+		t.Errorf("expected cancelled event with sequence 1, got:\n%s", body)
+	}
+
+	rr = doRequest(t, "POST", "/api/v1/player-event/join", map[string]interface{}{
+		"player_id": p2,
+		"event_id":  eid,
+	}, testHandler.JoinEvent)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 joining a cancelled event, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func requestCalendarToken(t *testing.T, authPlayerID, playerID int64) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/players/%d/calendar-token", playerID), nil, testHandler.CreateCalendarToken, map[string]string{"player_id": fmt.Sprintf("%d", playerID)}, authPlayerID)
+}
+
+func TestPlayerCalendar(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	starts := time.Now().AddDate(0, 0, 7)
+	accepted := seedEventAt(t, c1, p2, 4, false, starts.Format(dateLayout), "08:00")
+	pending := seedEventAt(t, c1, p2, 4, true, starts.Format(dateLayout), "12:00")
+	seedPlayerEvent(t, p1, accepted, 1)
+	seedPlayerEvent(t, p1, pending, 0)
+
+	rr := requestCalendarToken(t, p1, p1)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var token model.CalendarTokenResponse
+	json.NewDecoder(rr.Body).Decode(&token)
+	if token.Token == "" {
+		t.Fatal("expected a calendar token")
+	}
+
+	feed := func(tok string) *httptest.ResponseRecorder {
+		return doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/calendar.ics?token=%s", p1, tok), nil, testHandler.GetPlayerCalendar, map[string]string{"player_id": fmt.Sprintf("%d", p1)})
+	}
+
+	rr = feed(token.Token)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	body := rr.Body.String()
+	if !strings.Contains(body, fmt.Sprintf("UID:event-%d@findfore", accepted)) {
+		t.Errorf("expected accepted event in feed, got:\n%s", body)
+	}
+	if strings.Contains(body, fmt.Sprintf("UID:event-%d@findfore", pending)) {
+		t.Errorf("expected pending event to be left out of feed, got:\n%s", body)
+	}
+
+	if rr := feed("wrong"); rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 with a bad token, got %d", rr.Code)
+	}
+
+	// Rotating the token revokes the old feed URL
+	requestCalendarToken(t, p1, p1)
+	if rr := feed(token.Token); rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 with a rotated token, got %d", rr.Code)
+	}
+}
+
+func TestCreateCalendarToken_RequiresOwner(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+
+	if rr := requestCalendarToken(t, 0, p1); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without auth, got %d", rr.Code)
+	}
+	if rr := requestCalendarToken(t, p2, p1); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for another player, got %d", rr.Code)
+	}
+}
+
+// ===================== INVITE LINKS =====================
+
+func createInviteLink(t *testing.T, hostID, eventID int64, body map[string]interface{}) model.InviteLinkResponse {
+	t.Helper()
+	rr := doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/invite-links", eventID), body, testHandler.CreateInviteLink, map[string]string{"id": fmt.Sprintf("%d", eventID)}, hostID)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var link model.InviteLinkResponse
+	json.NewDecoder(rr.Body).Decode(&link)
+	return link
+}
+
+func redeemInvite(t *testing.T, playerID int64, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", "/api/v1/invites/"+token+"/redeem", nil, testHandler.RedeemInvite, map[string]string{"token": token}, playerID)
+}
+
+func TestCreateInviteLink_HostOnly(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, true)
+	params := map[string]string{"id": fmt.Sprintf("%d", eid)}
+
+	rr := doAuthRequestWithChiCtx(t, "POST", "/api/v1/event/1/invite-links", nil, testHandler.CreateInviteLink, params, 0)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without auth, got %d", rr.Code)
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "POST", "/api/v1/event/1/invite-links", nil, testHandler.CreateInviteLink, params, p2)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a non-host, got %d", rr.Code)
+	}
+
+	link := createInviteLink(t, host, eid, map[string]interface{}{"max_uses": 2})
+	if link.Token == "" || link.MaxUses == nil || *link.MaxUses != 2 {
+		t.Errorf("unexpected invite link %+v", link)
+	}
+}
+
+func TestPreviewInvite(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, true)
+	link := createInviteLink(t, host, eid, map[string]interface{}{"max_uses": 3})
+
+	// No auth needed to preview
+	rr := doRequestWithChiCtx(t, "GET", "/api/v1/invites/"+link.Token, nil, testHandler.PreviewInvite, map[string]string{"token": link.Token})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var preview model.InvitePreviewResponse
+	json.NewDecoder(rr.Body).Decode(&preview)
+	if preview.Event.ID != eid || preview.Event.CourseName != "Green Valley" {
+		t.Errorf("unexpected preview event %+v", preview.Event)
+	}
+	if preview.UsesRemaining == nil || *preview.UsesRemaining != 3 {
+		t.Errorf("expected 3 uses remaining, got %v", preview.UsesRemaining)
+	}
+
+	rr = doRequestWithChiCtx(t, "GET", "/api/v1/invites/bogus", nil, testHandler.PreviewInvite, map[string]string{"token": "bogus"})
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for a bad token, got %d", rr.Code)
+	}
+}
+
+func TestRedeemInvite(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, true)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p3, eid, 0) // already invited directly
+	link := createInviteLink(t, host, eid, nil)
+
+	if rr := redeemInvite(t, 0, link.Token); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without auth, got %d", rr.Code)
+	}
+
+	for _, pid := range []int64{p2, p3} {
+		rr := redeemInvite(t, pid, link.Token)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+		}
+		var pe model.PlayerEventResponse
+		json.NewDecoder(rr.Body).Decode(&pe)
+		if pe.InviteStatus != "accepted" || pe.EventID != eid {
+			t.Errorf("unexpected player event %+v", pe)
+		}
+	}
+
+	if rr := redeemInvite(t, p2, link.Token); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 redeeming twice, got %d", rr.Code)
+	}
+
+	var uses int
+	testDB.QueryRow("SELECT uses FROM event_invite_links WHERE id = $1", link.ID).Scan(&uses)
+	if uses != 2 {
+		t.Errorf("expected 2 uses, got %d", uses)
+	}
+}
+
+func TestRedeemInvite_ScheduleConflict(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	c2 := seedCourse(t, "Pine Hills")
+	morning := seedEventAt(t, c1, host, 4, false, "2030-06-03", "08:00")
+	overlapping := seedEventAt(t, c2, host, 4, true, "2030-06-03", "11:00")
+	seedPlayerEvent(t, p2, morning, 1)
+	link := createInviteLink(t, host, overlapping, nil)
+
+	rr := redeemInvite(t, p2, link.Token)
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var conflict model.ScheduleConflictResponse
+	json.NewDecoder(rr.Body).Decode(&conflict)
+	if conflict.ConflictingEvent == nil || conflict.ConflictingEvent.ID != morning {
+		t.Errorf("expected conflicting event %d, got %+v", morning, conflict.ConflictingEvent)
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "POST", "/api/v1/invites/"+link.Token+"/redeem", map[string]interface{}{
+		"allow_conflicts": true,
+	}, testHandler.RedeemInvite, map[string]string{"token": link.Token}, p2)
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected status 201 with allow_conflicts, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestRedeemInvite_MaxUses(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, true)
+	link := createInviteLink(t, host, eid, map[string]interface{}{"max_uses": 1})
+
+	if rr := redeemInvite(t, p2, link.Token); rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := redeemInvite(t, p3, link.Token); rr.Code != http.StatusGone {
+		t.Errorf("expected status 410 once uses run out, got %d", rr.Code)
+	}
+}
+
+func TestRedeemInvite_Expired(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, true)
+	link := createInviteLink(t, host, eid, map[string]interface{}{
+		"expires_at": time.Now().Add(time.Hour).Format(time.RFC3339),
+	})
+	testDB.Exec("UPDATE event_invite_links SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1", link.ID)
+
+	if rr := redeemInvite(t, p2, link.Token); rr.Code != http.StatusGone {
+		t.Errorf("expected status 410 for an expired link, got %d", rr.Code)
+	}
+}
+
+func TestRedeemInvite_Full(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 1, true)
+	seedPlayerEvent(t, host, eid, 1) // host takes the only spot
+	link := createInviteLink(t, host, eid, nil)
+
+	if rr := redeemInvite(t, p2, link.Token); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 when full, got %d", rr.Code)
+	}
+}
+
+func TestRevokeInviteLink(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, true)
+	link := createInviteLink(t, host, eid, nil)
+
+	rr := doAuthRequestWithChiCtx(t, "DELETE", "/api/v1/event/1/invite-links/1", nil, testHandler.RevokeInviteLink, map[string]string{
+		"id":      fmt.Sprintf("%d", eid),
+		"link_id": fmt.Sprintf("%d", link.ID),
+	}, host)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	if rr := redeemInvite(t, p2, link.Token); rr.Code != http.StatusGone {
+		t.Errorf("expected status 410 for a revoked link, got %d", rr.Code)
+	}
+}
+
+// ===================== GUESTS =====================
+
+func addGuest(t *testing.T, eventID, sponsorID int64, name string) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/guests", eventID), map[string]interface{}{
+		"name": name,
+	}, testHandler.AddEventGuest, map[string]string{"id": fmt.Sprintf("%d", eventID)}, sponsorID)
+}
+
+func getEvent(t *testing.T, eventID int64) model.EventResponse {
+	t.Helper()
+	rr := doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/event/%d", eventID), nil, testHandler.GetEvent, map[string]string{"id": fmt.Sprintf("%d", eventID)})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var event model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&event)
+	return event
+}
+
+func TestAddEventGuest(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+
+	rr := addGuest(t, eid, host, "Uncle Rick")
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	event := getEvent(t, eid)
+	if len(event.Guests) != 1 || event.Guests[0].Name != "Uncle Rick" || event.Guests[0].SponsorID != host {
+		t.Errorf("unexpected guests %+v", event.Guests)
+	}
+	if event.RemainingSpots != 2 {
+		t.Errorf("expected 2 remaining spots, got %d", event.RemainingSpots)
+	}
+}
+
+func TestAddEventGuest_SponsorNotAccepted(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, p2, eid, 0) // pending
+
+	if rr := addGuest(t, eid, p2, "Uncle Rick"); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr := doRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/guests", eid), map[string]interface{}{
+		"name": "Uncle Rick",
+	}, testHandler.AddEventGuest, map[string]string{"id": fmt.Sprintf("%d", eid)})
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 when logged out, got %d", rr.Code)
+	}
+}
+
+func TestAddEventGuest_CountsAgainstCapacity(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 2, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 0) // pending
+
+	if rr := addGuest(t, eid, host, "Uncle Rick"); rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// The guest fills the last spot, closing pending invitations
+	event := getEvent(t, eid)
+	if len(event.Closed) != 1 || event.Closed[0] != p2 {
+		t.Errorf("expected Bob's invitation to be closed, got %v", event.Closed)
+	}
+
+	if rr := addGuest(t, eid, host, "Aunt Sue"); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 adding a guest to a full event, got %d", rr.Code)
+	}
+
+	rr := doRequest(t, "POST", "/api/v1/player-event/join", map[string]interface{}{
+		"player_id": p3,
+		"event_id":  eid,
+	}, testHandler.JoinEvent)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 joining a full event, got %d", rr.Code)
+	}
+}
+
+func TestRemoveEventGuest(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 2, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 0)
+
+	rr := addGuest(t, eid, host, "Uncle Rick")
+	var guest model.GuestResponse
+	json.NewDecoder(rr.Body).Decode(&guest)
+
+	params := map[string]string{
+		"id":       fmt.Sprintf("%d", eid),
+		"guest_id": fmt.Sprintf("%d", guest.ID),
+	}
+	rr = doRequestWithChiCtx(t, "DELETE", "/api/v1/event/1/guests/1", nil, testHandler.RemoveEventGuest, params)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 when logged out, got %d", rr.Code)
+	}
+	rr = doAuthRequestWithChiCtx(t, "DELETE", "/api/v1/event/1/guests/1", nil, testHandler.RemoveEventGuest, params, p2)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for someone else's guest, got %d", rr.Code)
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "DELETE", "/api/v1/event/1/guests/1", nil, testHandler.RemoveEventGuest, params, host)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	event := getEvent(t, eid)
+	if len(event.Guests) != 0 {
+		t.Errorf("expected no guests, got %+v", event.Guests)
+	}
+	if len(event.Pending) != 1 || event.Pending[0] != p2 {
+		t.Errorf("expected Bob's invitation to be reopened, got %v", event.Pending)
+	}
+}
+
+func TestDeclineRemovesSponsoredGuests(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+	addGuest(t, eid, p2, "Uncle Rick")
+	addGuest(t, eid, host, "Aunt Sue")
+
+	rr := doRequest(t, "PATCH", "/api/v1/player-event", map[string]interface{}{
+		"player_id":     p2,
+		"event_id":      eid,
+		"invite_status": "declined",
+	}, testHandler.UpdatePlayerEvent)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	event := getEvent(t, eid)
+	if len(event.Guests) != 1 || event.Guests[0].Name != "Aunt Sue" {
+		t.Errorf("expected only the host's guest to remain, got %+v", event.Guests)
+	}
+}
+
+// ===================== FRIENDSHIPS =====================
+
+func TestCreateFriendship(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+
+	body := map[string]int64{
+		"follower_id": p1,
+		"followee_id": p2,
+	}
+
+	rr := doRequest(t, "POST", "/api/v1/friendship", body, testHandler.CreateFriendship)
+
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var friendship model.FriendshipResponse
+	json.NewDecoder(rr.Body).Decode(&friendship)
+	if friendship.Followee.Name != "Bob" {
+		t.Errorf("expected followee name 'Bob', got '%s'", friendship.Followee.Name)
+	}
+}
+
+func TestCreateFriendship_Idempotent(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	seedFriendship(t, p1, p2)
+
+	body := map[string]int64{
+		"follower_id": p1,
+		"followee_id": p2,
+	}
+
+	rr := doRequest(t, "POST", "/api/v1/friendship", body, testHandler.CreateFriendship)
+
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected status 201 (idempotent), got %d", rr.Code)
+	}
+
+	// Should still be only one friendship
+	var count int
+	testDB.QueryRow("SELECT COUNT(*) FROM friendships WHERE follower_id = $1 AND followee_id = $2", p1, p2).Scan(&count)
+	if count != 1 {
+		t.Errorf("expected 1 friendship, got %d", count)
+	}
+}
+
+func TestDeleteFriendship(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	seedFriendship(t, p1, p2)
+
+	body := map[string]int64{
+		"follower_id": p1,
+		"followee_id": p2,
+	}
+
+	rr := doRequest(t, "DELETE", "/api/v1/friendship", body, testHandler.DeleteFriendship)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+
+	var count int
+	testDB.QueryRow("SELECT COUNT(*) FROM friendships WHERE follower_id = $1 AND followee_id = $2", p1, p2).Scan(&count)
+	if count != 0 {
+		t.Error("friendship should be deleted")
+	}
+}
+
+// ===================== RSVP DEADLINES =====================
+
+func setRespondBy(t *testing.T, eventID int64, respondBy time.Time) {
+	t.Helper()
+	if _, err := testDB.Exec("UPDATE events SET respond_by = $1 WHERE id = $2", respondBy.UTC(), eventID); err != nil {
+		t.Fatalf("failed to set respond_by: %v", err)
+	}
+}
+
+func TestCreateEvent_RespondBy(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	respondBy := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+
+	rr := doRequest(t, "POST", "/api/v1/event", map[string]interface{}{
+		"course_id":       c1,
+		"date":            "2030-06-03",
+		"tee_time":        "08:00",
+		"open_spots":      4,
+		"number_of_holes": "18",
+		"host_id":         host,
+		"respond_by":      respondBy.Format(time.RFC3339),
+	}, testHandler.CreateEvent)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var event model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&event)
+	if event.RespondBy == nil || *event.RespondBy != respondBy.Format(time.RFC3339) {
+		t.Errorf("expected respond_by %s, got %v", respondBy.Format(time.RFC3339), event.RespondBy)
+	}
+}
+
+func TestCreateEvent_RespondByInPast(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+
+	rr := doRequest(t, "POST", "/api/v1/event", map[string]interface{}{
+		"course_id":       c1,
+		"date":            "2030-06-03",
+		"tee_time":        "08:00",
+		"open_spots":      4,
+		"number_of_holes": "18",
+		"host_id":         host,
+		"respond_by":      time.Now().Add(-time.Hour).Format(time.RFC3339),
+	}, testHandler.CreateEvent)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestUpdatePlayerEvent_AcceptAfterDeadline(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 0)
+	seedPlayerEvent(t, p3, eid, 0)
+	setRespondBy(t, eid, time.Now().Add(-time.Hour))
+
+	rr := doRequest(t, "PATCH", "/api/v1/player-event", map[string]interface{}{
+		"player_id":     p2,
+		"event_id":      eid,
+		"invite_status": "accepted",
+	}, testHandler.UpdatePlayerEvent)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// Declining is still allowed after the deadline
+	rr = doRequest(t, "PATCH", "/api/v1/player-event", map[string]interface{}{
+		"player_id":     p3,
+		"event_id":      eid,
+		"invite_status": "declined",
+	}, testHandler.UpdatePlayerEvent)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestUpdatePlayerEvent_CannotSetExpired(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, p2, eid, 0)
+
+	rr := doRequest(t, "PATCH", "/api/v1/player-event", map[string]interface{}{
+		"player_id":     p2,
+		"event_id":      eid,
+		"invite_status": "expired",
+	}, testHandler.UpdatePlayerEvent)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestJoinEvent_AfterDeadline(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	setRespondBy(t, eid, time.Now().Add(-time.Hour))
+
+	rr := doRequest(t, "POST", "/api/v1/player-event/join", map[string]interface{}{
+		"player_id": p2,
+		"event_id":  eid,
+	}, testHandler.JoinEvent)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestExpirePendingInvitations(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	past := seedEvent(t, c1, host, 4, false)
+	future := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, past, 1)
+	seedPlayerEvent(t, p2, past, 0)
+	seedPlayerEvent(t, p3, future, 0)
+	setRespondBy(t, past, time.Now().Add(-time.Hour))
+	setRespondBy(t, future, time.Now().Add(time.Hour))
+
+	n, err := testQueries.ExpirePendingInvitations(context.Background(), time.Now().UTC())
+	if err != nil {
+		t.Fatalf("ExpirePendingInvitations failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 invitation expired, got %d", n)
+	}
+
+	event := getEvent(t, past)
+	if len(event.Expired) != 1 || event.Expired[0] != p2 {
+		t.Errorf("expected Bob's invitation to be expired, got %v", event.Expired)
+	}
+	if len(event.Accepted) != 1 {
+		t.Errorf("expected the host to stay accepted, got %v", event.Accepted)
+	}
+
+	event = getEvent(t, future)
+	if len(event.Pending) != 1 || event.Pending[0] != p3 {
+		t.Errorf("expected Cleo's invitation to stay pending, got %v", event.Pending)
+	}
+}
+
+func TestSendRsvpReminders(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	soon := seedEvent(t, c1, host, 4, false)
+	later := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, p2, soon, 0)
+	seedPlayerEvent(t, p3, later, 0)
+	setRespondBy(t, soon, time.Now().Add(2*time.Hour))
+	setRespondBy(t, later, time.Now().Add(72*time.Hour))
+
+	for i := 0; i < 2; i++ {
+		n, err := store.SendRsvpReminders(context.Background(), testDB, testQueries, time.Now(), 24*time.Hour)
+		if err != nil {
+			t.Fatalf("SendRsvpReminders failed: %v", err)
+		}
+		want := 1
+		if i == 1 {
+			want = 0 // already reminded
+		}
+		if n != want {
+			t.Errorf("run %d: expected %d reminders, got %d", i+1, want, n)
+		}
+	}
+
+	rr := doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/notifications", p2), nil,
+		testHandler.ListNotifications, map[string]string{"player_id": fmt.Sprintf("%d", p2)}, p2)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var notifications []model.NotificationResponse
+	json.NewDecoder(rr.Body).Decode(&notifications)
+	if len(notifications) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(notifications))
+	}
+	if notifications[0].Kind != store.NotificationKindRsvpReminder || notifications[0].EventID == nil || *notifications[0].EventID != soon {
+		t.Errorf("unexpected notification %+v", notifications[0])
+	}
+	if notifications[0].Read {
+		t.Errorf("expected notification to be unread")
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "POST", "/api/v1/players/1/notifications/1/read", nil, testHandler.MarkNotificationRead, map[string]string{
+		"player_id":       fmt.Sprintf("%d", p2),
+		"notification_id": fmt.Sprintf("%d", notifications[0].ID),
+	}, p2)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestListNotifications_OtherPlayer(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+
+	rr := doAuthRequestWithChiCtx(t, "GET", "/api/v1/players/1/notifications", nil,
+		testHandler.ListNotifications, map[string]string{"player_id": fmt.Sprintf("%d", p1)}, p2)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+// ===================== EVENT LIFECYCLE =====================
+
+func setEventStatus(t *testing.T, eventID int64, status int) {
+	t.Helper()
+	if _, err := testDB.Exec("UPDATE events SET status = $1 WHERE id = $2", status, eventID); err != nil {
+		t.Fatalf("failed to set status: %v", err)
+	}
+}
+
+func TestAdvanceEventStatuses(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	// Tee times are local to the course, hours behind UTC.
+	testDB.Exec("UPDATE courses SET time_zone = 'America/Los_Angeles' WHERE id = $1", c1)
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("failed to load time zone: %v", err)
+	}
+	now := time.Now()
+	at := func(d time.Duration) (string, string) {
+		ts := now.In(loc).Add(d)
+		return ts.Format("2006-01-02"), ts.Format("15:04")
+	}
+	date, tee := at(-6 * time.Hour)
+	finished := seedEventAt(t, c1, host, 4, false, date, tee)
+	date, tee = at(-time.Hour)
+	playing := seedEventAt(t, c1, host, 4, false, date, tee)
+	date, tee = at(time.Hour)
+	upcoming := seedEventAt(t, c1, host, 4, false, date, tee)
+
+	if _, err := testQueries.CompleteFinishedEvents(context.Background(), now); err != nil {
+		t.Fatalf("CompleteFinishedEvents failed: %v", err)
+	}
+	if _, err := testQueries.StartDueEvents(context.Background(), now); err != nil {
+		t.Fatalf("StartDueEvents failed: %v", err)
+	}
+
+	for id, want := range map[int64]string{finished: "completed", playing: "in_progress", upcoming: "scheduled"} {
+		if got := getEvent(t, id).Status; got != want {
+			t.Errorf("event %d: expected status %q, got %q", id, want, got)
+		}
+	}
+}
+
+func TestCompletedEvent_BlocksRsvps(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 0)
+	setEventStatus(t, eid, 2)
+
+	rr := doRequest(t, "PATCH", "/api/v1/player-event", map[string]interface{}{
+		"player_id":     p2,
+		"event_id":      eid,
+		"invite_status": "accepted",
+	}, testHandler.UpdatePlayerEvent)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 accepting a completed event, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRequest(t, "POST", "/api/v1/player-event/join", map[string]interface{}{
+		"player_id": p3,
+		"event_id":  eid,
+	}, testHandler.JoinEvent)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 joining a completed event, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/cancel", eid), nil, testHandler.CancelEvent, map[string]string{"id": fmt.Sprintf("%d", eid)}, host)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 cancelling a completed event, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestListEvents_ExcludesCompleted(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	past := seedEventAt(t, c1, host, 4, false, "2025-01-01", "10:00")
+	playing := seedEventAt(t, c1, host, 4, false, "2025-01-02", "10:00")
+	upcoming := seedEventAt(t, c1, host, 4, false, "2025-01-03", "10:00")
+	setEventStatus(t, past, 2)
+	setEventStatus(t, playing, 1)
+
+	rr := doRequest(t, "GET", "/api/v1/events", nil, testHandler.ListEvents)
+	var events []model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&events)
+	if len(events) != 2 || events[0].ID != playing || events[1].ID != upcoming {
+		t.Errorf("expected only the in-progress and upcoming events, got %+v", events)
+	}
+}
+
+func TestListPlayerHistory(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	older := seedEventAt(t, c1, host, 4, false, "2025-01-01", "10:00")
+	newer := seedEventAt(t, c1, host, 4, false, "2025-02-01", "10:00")
+	declined := seedEventAt(t, c1, host, 4, false, "2025-03-01", "10:00")
+	upcoming := seedEventAt(t, c1, host, 4, false, "2030-01-01", "10:00")
+	seedPlayerEvent(t, p2, older, 1)
+	seedPlayerEvent(t, p2, newer, 1)
+	seedPlayerEvent(t, p2, declined, 2)
+	seedPlayerEvent(t, p2, upcoming, 1)
+	setEventStatus(t, older, 2)
+	setEventStatus(t, newer, 2)
+	setEventStatus(t, declined, 2)
+
+	rr := doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/history", p2), nil, testHandler.ListPlayerHistory, map[string]string{"player_id": fmt.Sprintf("%d", p2)})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var events []model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&events)
+	if len(events) != 2 || events[0].ID != newer || events[1].ID != older {
+		t.Errorf("expected the two played rounds newest first, got %+v", events)
+	}
+	for _, e := range events {
+		if e.Status != "completed" {
+			t.Errorf("expected status completed, got %q", e.Status)
+		}
+	}
+}
+
+// ===================== HOSTS =====================
+
+func postHostChange(t *testing.T, path string, handler http.HandlerFunc, eventID, playerID, authID int64) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/%s", eventID, path), map[string]interface{}{
+		"player_id": playerID,
+	}, handler, map[string]string{"id": fmt.Sprintf("%d", eventID)}, authID)
+}
+
+func TestTransferEventHost(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+
+	// Only the host can transfer
+	if rr := postHostChange(t, "transfer", testHandler.TransferEventHost, eid, p2, p2); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr := postHostChange(t, "transfer", testHandler.TransferEventHost, eid, p2, host)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var event model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&event)
+	if int64(event.HostID) != p2 || event.HostName != "Bob" {
+		t.Errorf("expected Bob to host, got %d (%s)", event.HostID, event.HostName)
+	}
+	if len(event.Accepted) != 2 {
+		t.Errorf("expected the previous host to keep their spot, got %v", event.Accepted)
+	}
+}
+
+func TestTransferEventHost_NotAccepted(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 0) // pending
+
+	if rr := postHostChange(t, "transfer", testHandler.TransferEventHost, eid, p2, host); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestEventCohosts(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+	seedPlayerEvent(t, p3, eid, 1)
+	params := map[string]string{"id": fmt.Sprintf("%d", eid)}
+
+	rr := postHostChange(t, "cohosts", testHandler.AddEventCohost, eid, p2, host)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var event model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&event)
+	if len(event.Cohosts) != 1 || event.Cohosts[0] != p2 {
+		t.Errorf("expected Bob as co-host, got %v", event.Cohosts)
+	}
+
+	// Other players can't cancel, co-hosts can
+	rr = doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/cancel", eid), nil, testHandler.CancelEvent, params, p3)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/cancel", eid), nil, testHandler.CancelEvent, params, p2)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestEventCohosts_RemovedOnDecline(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+	postHostChange(t, "cohosts", testHandler.AddEventCohost, eid, p2, host)
+
+	rr := doRequest(t, "PATCH", "/api/v1/player-event", map[string]interface{}{
+		"player_id":     p2,
+		"event_id":      eid,
+		"invite_status": "declined",
+	}, testHandler.UpdatePlayerEvent)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	if event := getEvent(t, eid); len(event.Cohosts) != 0 {
+		t.Errorf("expected no co-hosts, got %v", event.Cohosts)
+	}
+}
+
+func TestRemoveEventCohost_StepDown(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+	postHostChange(t, "cohosts", testHandler.AddEventCohost, eid, p2, host)
+
+	rr := doAuthRequestWithChiCtx(t, "DELETE", fmt.Sprintf("/api/v1/event/%d/cohosts/%d", eid, p2), nil, testHandler.RemoveEventCohost, map[string]string{
+		"id":        fmt.Sprintf("%d", eid),
+		"player_id": fmt.Sprintf("%d", p2),
+	}, p2)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	if event := getEvent(t, eid); len(event.Cohosts) != 0 {
+		t.Errorf("expected no co-hosts, got %v", event.Cohosts)
+	}
+}
+
+// ===================== DOUBLE BOOKING =====================
+
+func TestJoinEvent_ScheduleConflict(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	c2 := seedCourse(t, "Pine Hills")
+	morning := seedEventAt(t, c1, host, 4, false, "2030-06-03", "08:00")
+	overlapping := seedEventAt(t, c2, host, 4, false, "2030-06-03", "11:00")
+	seedPlayerEvent(t, p2, morning, 1)
+
+	rr := doRequest(t, "POST", "/api/v1/player-event/join", map[string]interface{}{
+		"player_id": p2,
+		"event_id":  overlapping,
+	}, testHandler.JoinEvent)
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var conflict model.ScheduleConflictResponse
+	json.NewDecoder(rr.Body).Decode(&conflict)
+	if conflict.ConflictingEvent == nil || conflict.ConflictingEvent.ID != morning {
+		t.Errorf("expected conflicting event %d, got %+v", morning, conflict.ConflictingEvent)
+	}
+
+	rr = doRequest(t, "POST", "/api/v1/player-event/join", map[string]interface{}{
+		"player_id":       p2,
+		"event_id":        overlapping,
+		"allow_conflicts": true,
+	}, testHandler.JoinEvent)
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected status 201 with allow_conflicts, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestUpdatePlayerEvent_ScheduleConflict(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	c2 := seedCourse(t, "Pine Hills")
+	morning := seedEventAt(t, c1, host, 4, false, "2030-06-03", "08:00")
+	overlapping := seedEventAt(t, c2, host, 4, false, "2030-06-03", "12:00")
+	afternoon := seedEventAt(t, c2, host, 4, false, "2030-06-03", "12:30")
+	seedPlayerEvent(t, p2, morning, 1)
+	seedPlayerEvent(t, p2, overlapping, 0)
+	seedPlayerEvent(t, p2, afternoon, 0)
+
+	rr := doRequest(t, "PATCH", "/api/v1/player-event", map[string]interface{}{
+		"player_id":     p2,
+		"event_id":      overlapping,
+		"invite_status": "accepted",
+	}, testHandler.UpdatePlayerEvent)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// An 18 hole round at 08:00 is expected to finish by 12:30
+	rr = doRequest(t, "PATCH", "/api/v1/player-event", map[string]interface{}{
+		"player_id":     p2,
+		"event_id":      afternoon,
+		"invite_status": "accepted",
+	}, testHandler.UpdatePlayerEvent)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+// ===================== GROUPS =====================
+
+func seedHandicap(t *testing.T, playerID int64, index float64) {
+	t.Helper()
+	if _, err := testDB.Exec("UPDATE players SET handicap_index = $2 WHERE id = $1", playerID, index); err != nil {
+		t.Fatalf("seedHandicap failed: %v", err)
+	}
+}
+
+func generateGroups(t *testing.T, eventID, authID int64, body map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/groups/generate", eventID), body,
+		testHandler.GenerateEventGroups, map[string]string{"id": fmt.Sprintf("%d", eventID)}, authID)
+}
+
+func groupIndex(groups []model.GroupResponse, playerID int64) int {
+	for i, g := range groups {
+		for _, id := range g.PlayerIDs {
+			if id == playerID {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func TestGenerateEventGroups(t *testing.T) {
+	cleanDB(t)
+	c1 := seedCourse(t, "Green Valley")
+	var players []int64
+	for i := 0; i < 8; i++ {
+		p := seedPlayer(t, fmt.Sprintf("Player%d", i), fmt.Sprintf("p%d@test.com", i), "password")
+		seedHandicap(t, p, float64(i*4))
+		players = append(players, p)
+	}
+	eid := seedEvent(t, c1, players[0], 16, false)
+	for _, p := range players {
+		seedPlayerEvent(t, p, eid, 1)
+	}
+	// players[1] wants to play with players[2], players[3] avoids players[4]
+	testDB.Exec("INSERT INTO pairing_preferences (player_id, other_player_id, preference) VALUES ($1, $2, 0), ($3, $4, 1)",
+		players[1], players[2], players[3], players[4])
+
+	// Only managers can generate
+	if rr := generateGroups(t, eid, players[1], map[string]interface{}{"tee_times": []string{"08:00", "08:10"}}); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr := generateGroups(t, eid, players[0], map[string]interface{}{"tee_times": []string{"08:00", "08:10", "08:20"}})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.EventGroupsResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if len(resp.Groups) != 2 {
+		t.Fatalf("expected 2 groups, got %+v", resp.Groups)
+	}
+	if resp.Groups[0].TeeTime != "08:00" || resp.Groups[1].TeeTime != "08:10" {
+		t.Errorf("expected the first tee times to be used, got %s and %s", resp.Groups[0].TeeTime, resp.Groups[1].TeeTime)
+	}
+	for _, g := range resp.Groups {
+		if g.Spots != 4 {
+			t.Errorf("expected foursomes, got %+v", g)
+		}
+	}
+	if groupIndex(resp.Groups, players[1]) != groupIndex(resp.Groups, players[2]) {
+		t.Errorf("expected play-with pair together, got %+v", resp.Groups)
+	}
+	if groupIndex(resp.Groups, players[3]) == groupIndex(resp.Groups, players[4]) {
+		t.Errorf("expected avoided pair apart, got %+v", resp.Groups)
+	}
+	if len(resp.Ungrouped) != 0 {
+		t.Errorf("expected everyone grouped, got %v", resp.Ungrouped)
+	}
+}
+
+func TestGenerateEventGroups_NotEnoughTeeTimes(t *testing.T) {
+	cleanDB(t)
+	c1 := seedCourse(t, "Green Valley")
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	eid := seedEvent(t, c1, host, 8, false)
+	seedPlayerEvent(t, host, eid, 1)
+	for i := 0; i < 4; i++ {
+		p := seedPlayer(t, fmt.Sprintf("Player%d", i), fmt.Sprintf("p%d@test.com", i), "password")
+		seedPlayerEvent(t, p, eid, 1)
+	}
+
+	if rr := generateGroups(t, eid, host, map[string]interface{}{"tee_times": []string{"08:00"}}); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := generateGroups(t, eid, host, map[string]interface{}{"tee_times": []string{"8am"}}); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestReplaceEventGroups(t *testing.T) {
+	cleanDB(t)
+	c1 := seedCourse(t, "Green Valley")
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	p4 := seedPlayer(t, "Dee", "dee@test.com", "password")
+	eid := seedEvent(t, c1, host, 8, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+	seedPlayerEvent(t, p3, eid, 1)
+	seedPlayerEvent(t, p4, eid, 0) // pending
+	addGuest(t, eid, p2, "Guest One")
+	addGuest(t, eid, p2, "Guest Two")
+	params := map[string]string{"id": fmt.Sprintf("%d", eid)}
+	path := fmt.Sprintf("/api/v1/event/%d/groups", eid)
+
+	// Bob and his two guests plus two more is five
+	rr := doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{
+		"groups": []map[string]interface{}{{"tee_time": "08:00", "player_ids": []int64{host, p2, p3}}},
+	}, testHandler.ReplaceEventGroups, params, host)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{
+		"groups": []map[string]interface{}{{"tee_time": "08:00", "player_ids": []int64{host, p4}}},
+	}, testHandler.ReplaceEventGroups, params, host)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for a pending player, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{
+		"groups": []map[string]interface{}{
+			{"tee_time": "08:00", "player_ids": []int64{p2}},
+			{"tee_time": "08:10", "player_ids": []int64{host}},
+		},
+	}, testHandler.ReplaceEventGroups, params, host)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.EventGroupsResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if len(resp.Groups) != 2 || resp.Groups[0].Spots != 3 || len(resp.Groups[0].GuestIDs) != 2 {
+		t.Errorf("expected Bob's guests in his group, got %+v", resp.Groups)
+	}
+	if len(resp.Ungrouped) != 1 || resp.Ungrouped[0] != p3 {
+		t.Errorf("expected Cleo ungrouped, got %v", resp.Ungrouped)
+	}
+
+	// Declining takes the player out of their group
+	doRequest(t, "PATCH", "/api/v1/player-event", map[string]interface{}{
+		"player_id":     p2,
+		"event_id":      eid,
+		"invite_status": "declined",
+	}, testHandler.UpdatePlayerEvent)
+	rr = doRequestWithChiCtx(t, "GET", path, nil, testHandler.ListEventGroups, params)
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if len(resp.Groups[0].PlayerIDs) != 0 || resp.Groups[0].Spots != 0 {
+		t.Errorf("expected Bob's group to be empty, got %+v", resp.Groups[0])
+	}
+}
+
+func TestPairingPreferences(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Alice", "alice@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	path := fmt.Sprintf("/api/v1/players/%d/pairing-preferences/%d", p1, p2)
+	params := map[string]string{"player_id": fmt.Sprintf("%d", p1), "other_player_id": fmt.Sprintf("%d", p2)}
+
+	rr := doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"preference": "avoid"}, testHandler.SetPairingPreference, params, p2)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"preference": "sometimes"}, testHandler.SetPairingPreference, params, p1)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+	doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"preference": "avoid"}, testHandler.SetPairingPreference, params, p1)
+	rr = doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"preference": "play_with"}, testHandler.SetPairingPreference, params, p1)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/pairing-preferences", p1), nil,
+		testHandler.ListPairingPreferences, map[string]string{"player_id": fmt.Sprintf("%d", p1)}, p1)
+	var prefs []model.PairingPreferenceResponse
+	json.NewDecoder(rr.Body).Decode(&prefs)
+	if len(prefs) != 1 || prefs[0].OtherPlayerID != p2 || prefs[0].Preference != "play_with" {
+		t.Errorf("expected one play_with preference, got %+v", prefs)
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "DELETE", path, nil, testHandler.DeletePairingPreference, params, p1)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = doAuthRequestWithChiCtx(t, "DELETE", path, nil, testHandler.DeletePairingPreference, params, p1)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestUpdateHandicap(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Alice", "alice@test.com", "password")
+	path := fmt.Sprintf("/api/v1/players/%d/handicap", p1)
+	params := map[string]string{"player_id": fmt.Sprintf("%d", p1)}
+
+	rr := doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"handicap_index": 60}, testHandler.UpdateHandicap, params, p1)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"handicap_index": 12.4}, testHandler.UpdateHandicap, params, p1)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var index sql.NullFloat64
+	testDB.QueryRow("SELECT handicap_index FROM players WHERE id = $1", p1).Scan(&index)
+	if !index.Valid || index.Float64 != 12.4 {
+		t.Errorf("expected handicap 12.4, got %v", index)
+	}
+}
+
+// ===================== EVENT POSTS =====================
+
+func createEventPost(t *testing.T, eventID, playerID, authID int64, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", "/api/v1/posts", map[string]interface{}{
+		"player_id": playerID,
+		"event_id":  eventID,
+		"body":      body,
+	}, testHandler.CreatePost, nil, authID)
+}
+
+func TestEventPosts(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	outsider := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, p2, eid, 0) // pending invitees can join the conversation
+
+	if rr := createEventPost(t, eid, outsider, outsider, "Can I come?"); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := createEventPost(t, eid, host, p2, "Posting as the host"); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr := createEventPost(t, eid, p2, p2, "Who's bringing the cart?")
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var post model.PostResponse
+	json.NewDecoder(rr.Body).Decode(&post)
+	if post.EventID == nil || *post.EventID != eid {
+		t.Errorf("expected event_id %d, got %v", eid, post.EventID)
+	}
+
+	// Event posts stay out of the global feed
+	rr = doRequest(t, "GET", "/api/v1/posts", nil, testHandler.ListPosts)
+	var feed []model.PostResponse
+	json.NewDecoder(rr.Body).Decode(&feed)
+	if len(feed) != 0 {
+		t.Errorf("expected an empty global feed, got %d posts", len(feed))
+	}
+
+	path := fmt.Sprintf("/api/v1/event/%d/posts", eid)
+	params := map[string]string{"id": fmt.Sprintf("%d", eid)}
+	rr = doAuthRequestWithChiCtx(t, "GET", path, nil, testHandler.ListEventPosts, params, host)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var thread []model.PostResponse
+	json.NewDecoder(rr.Body).Decode(&thread)
+	if len(thread) != 1 || thread[0].Body != "Who's bringing the cart?" {
+		t.Errorf("expected the cart post, got %+v", thread)
+	}
+
+	if rr := doAuthRequestWithChiCtx(t, "GET", path, nil, testHandler.ListEventPosts, params, outsider); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := doRequestWithChiCtx(t, "GET", path, nil, testHandler.ListEventPosts, params); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestEventPosts_RepliesAndReactions(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	outsider := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+
+	rr := createEventPost(t, eid, host, host, "I'll bring the cart")
+	var post model.PostResponse
+	json.NewDecoder(rr.Body).Decode(&post)
+	postParams := map[string]string{"post_id": fmt.Sprintf("%d", post.ID)}
+
+	rr = doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/posts/%d/replies", post.ID), map[string]interface{}{
+		"player_id": outsider,
+		"body":      "Nice",
+	}, testHandler.CreateReply, postParams, outsider)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/posts/%d/reactions", post.ID), map[string]interface{}{
+		"player_id": outsider,
+		"emoji":     "👍",
+	}, testHandler.ToggleReaction, postParams, outsider)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/posts/%d/replies", post.ID), map[string]interface{}{
+		"player_id": host,
+		"body":      "And the snacks",
+	}, testHandler.CreateReply, postParams, host)
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+// ===================== EXPENSES =====================
+
+func createExpense(t *testing.T, eventID, payerID, amount, authID int64) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/expenses", eventID), map[string]interface{}{
+		"payer_id":     payerID,
+		"amount_cents": amount,
+		"description":  "Tee times",
+	}, testHandler.CreateEventExpense, map[string]string{"id": fmt.Sprintf("%d", eventID)}, authID)
+}
+
+func getPlayerBalances(t *testing.T, playerID int64) model.PlayerLedgerResponse {
+	t.Helper()
+	rr := doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/balances", playerID), nil,
+		testHandler.GetPlayerBalances, map[string]string{"player_id": fmt.Sprintf("%d", playerID)}, playerID)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.PlayerLedgerResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	return resp
+}
+
+func TestCreateEvent_DefaultsToCourseFee(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	testDB.Exec("UPDATE courses SET fee_cents = 6500, fee_currency = 'CAD' WHERE id = $1", c1)
+
+	rr := doRequest(t, "POST", "/api/v1/event", map[string]interface{}{
+		"course_id":       c1,
+		"date":            "2030-06-01",
+		"tee_time":        "08:00",
+		"open_spots":      4,
+		"number_of_holes": "18",
+		"host_id":         host,
+	}, testHandler.CreateEvent)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var event model.EventResponse
+	json.NewDecoder(rr.Body).Decode(&event)
+	if event.FeeCents == nil || *event.FeeCents != 6500 || event.FeeCurrency != "CAD" {
+		t.Errorf("expected the course's 6500 CAD fee, got %v %s", event.FeeCents, event.FeeCurrency)
+	}
+
+	rr = doRequest(t, "POST", "/api/v1/event", map[string]interface{}{
+		"course_id":       c1,
+		"date":            "2030-06-01",
+		"tee_time":        "08:00",
+		"open_spots":      4,
+		"number_of_holes": "18",
+		"host_id":         host,
+		"fee_cents":       4000,
+		"fee_currency":    "usd",
+	}, testHandler.CreateEvent)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a lowercase currency, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestEventExpenses(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	outsider := seedPlayer(t, "Dee", "dee@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 6, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+	seedPlayerEvent(t, p3, eid, 1)
+	seedPlayerEvent(t, outsider, eid, 2) // declined
+	addGuest(t, eid, p2, "Bob's Guest")
+
+	if rr := createExpense(t, eid, host, 20000, p2); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := createExpense(t, eid, outsider, 20000, host); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for a payer who declined, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := createExpense(t, eid, host, 20000, host); rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr := doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/event/%d/expenses", eid), nil,
+		testHandler.GetEventExpenses, map[string]string{"id": fmt.Sprintf("%d", eid)}, p3)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var ledger model.EventLedgerResponse
+	json.NewDecoder(rr.Body).Decode(&ledger)
+	if ledger.TotalCents != 20000 || ledger.Currency != "USD" {
+		t.Errorf("expected 20000 USD, got %d %s", ledger.TotalCents, ledger.Currency)
+	}
+	// Four spots at 5000 each; Bob pays for his guest
+	want := map[int64]int64{host: 15000, p2: -10000, p3: -5000}
+	for _, b := range ledger.Balances {
+		if b.BalanceCents != want[b.PlayerID] {
+			t.Errorf("player %d: expected balance %d, got %d", b.PlayerID, want[b.PlayerID], b.BalanceCents)
+		}
+	}
+	if len(ledger.Debts) != 2 {
+		t.Errorf("expected 2 debts, got %+v", ledger.Debts)
+	}
+}
+
+func TestPlayerBalances_AcrossEventsAndSettlements(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Alice", "alice@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	first := seedEventAt(t, c1, p1, 4, false, "2030-06-01", "08:00")
+	second := seedEventAt(t, c1, p2, 4, false, "2030-06-08", "08:00")
+	for _, eid := range []int64{first, second} {
+		seedPlayerEvent(t, p1, eid, 1)
+		seedPlayerEvent(t, p2, eid, 1)
+	}
+	createExpense(t, first, p1, 10000, p1)
+	createExpense(t, second, p2, 4000, p2)
+
+	// Bob owes 5000 for the first round, Alice 2000 for the second
+	resp := getPlayerBalances(t, p1)
+	if len(resp.Balances) != 1 || resp.Balances[0].PlayerID != p2 || resp.Balances[0].AmountCents != 3000 || resp.Balances[0].Direction != "owes_you" {
+		t.Fatalf("expected Bob to owe 3000, got %+v", resp.Balances)
+	}
+
+	rr := doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/players/%d/settlements", p2), map[string]interface{}{
+		"payee_id":     p1,
+		"amount_cents": 3000,
+		"currency":     "USD",
+	}, testHandler.CreateSettlement, map[string]string{"player_id": fmt.Sprintf("%d", p2)}, p2)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	resp = getPlayerBalances(t, p2)
+	if len(resp.Balances) != 0 || len(resp.Settlements) != 1 {
+		t.Errorf("expected Bob to be settled up, got %+v", resp)
+	}
+}
+
+// ===================== SCORES =====================
+
+func saveScores(t *testing.T, eventID, playerID, authID int64, holes []map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "PUT", fmt.Sprintf("/api/v1/event/%d/scores/%d", eventID, playerID), map[string]interface{}{
+		"holes": holes,
+	}, testHandler.SaveScorecard, map[string]string{"id": fmt.Sprintf("%d", eventID), "player_id": fmt.Sprintf("%d", playerID)}, authID)
+}
+
+func TestSaveScorecard(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+	seedPlayerEvent(t, p3, eid, 2) // declined
+
+	hole1 := []map[string]interface{}{{"hole": 1, "strokes": 5, "putts": 2, "fairway_hit": true, "green_in_regulation": false, "par": 4}}
+	if rr := saveScores(t, eid, p2, p2, hole1); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 before the round starts, got %d: %s", rr.Code, rr.Body.String())
+	}
+	setEventStatus(t, eid, 1)
+
+	if rr := saveScores(t, eid, p2, p2, hole1); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	// The host keeps score for the group
+	rr := saveScores(t, eid, p2, host, []map[string]interface{}{
+		{"hole": 2, "strokes": 3, "putts": 1, "par": 3},
+		{"hole": 3, "strokes": 6},
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.EventScoresResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if resp.NumberOfHoles != 18 || len(resp.Scorecards) != 2 {
+		t.Fatalf("expected two cards for an 18 hole round, got %+v", resp)
+	}
+	var card model.ScorecardResponse
+	for _, c := range resp.Scorecards {
+		if c.PlayerID == p2 {
+			card = c
+		} else if len(c.Holes) != 0 || c.EnteredBy != nil {
+			t.Errorf("expected the host's card to be empty, got %+v", c)
+		}
+	}
+	if len(card.Holes) != 3 || card.EnteredBy == nil || *card.EnteredBy != host {
+		t.Fatalf("expected 3 holes entered by the host, got %+v", card)
+	}
+	totals := card.Totals
+	if totals.Strokes != 14 || totals.Putts != 3 || totals.Par != 7 || totals.ToPar == nil || *totals.ToPar != 1 {
+		t.Errorf("unexpected totals %+v", totals)
+	}
+	if totals.FairwaysHit != 1 || totals.Fairways != 1 || totals.GreensInRegulation != 0 || totals.Greens != 1 {
+		t.Errorf("unexpected stats %+v", totals)
+	}
+}
+
+func TestSaveScorecard_Validation(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	testDB.Exec("UPDATE events SET number_of_holes = '9' WHERE id = $1", eid)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+	seedPlayerEvent(t, p3, eid, 2) // declined
+	setEventStatus(t, eid, 1)
+
+	tests := []struct {
+		name     string
+		playerID int64
+		authID   int64
+		hole     map[string]interface{}
+		status   int
+	}{
+		{"beyond the round's holes", p2, p2, map[string]interface{}{"hole": 10, "strokes": 4}, http.StatusBadRequest},
+		{"no strokes", p2, p2, map[string]interface{}{"hole": 1, "strokes": 0}, http.StatusBadRequest},
+		{"more putts than strokes", p2, p2, map[string]interface{}{"hole": 1, "strokes": 3, "putts": 4}, http.StatusBadRequest},
+		{"impossible par", p2, p2, map[string]interface{}{"hole": 1, "strokes": 3, "par": 7}, http.StatusBadRequest},
+		{"another player's card", host, p2, map[string]interface{}{"hole": 1, "strokes": 4}, http.StatusForbidden},
+		{"declined player", p3, host, map[string]interface{}{"hole": 1, "strokes": 4}, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := saveScores(t, eid, tt.playerID, tt.authID, []map[string]interface{}{tt.hole})
+			if rr.Code != tt.status {
+				t.Errorf("expected status %d, got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetEventScores_ParticipantsOnly(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	outsider := seedPlayer(t, "Dee", "dee@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+
+	rr := doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/event/%d/scores", eid), nil,
+		testHandler.GetEventScores, map[string]string{"id": fmt.Sprintf("%d", eid)}, outsider)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/event/%d/scores", eid), nil,
+		testHandler.GetEventScores, map[string]string{"id": fmt.Sprintf("%d", eid)}, host)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.EventScoresResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if len(resp.Scorecards) != 1 || resp.Scorecards[0].Totals.ToPar != nil {
+		t.Errorf("expected one empty card, got %+v", resp.Scorecards)
+	}
+}
+
+func TestSaveScorecard_TeeSet(t *testing.T) {
+	cleanDB(t)
+	admin := seedAdmin(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	c2 := seedCourse(t, "City Park")
+	createTeeSet(t, c1, admin, teeSetBody("White", 72.0, 113))
+	createTeeSet(t, c2, admin, teeSetBody("White", 72.0, 113))
+	white, other := getCourse(t, c1).TeeSets[0].ID, getCourse(t, c2).TeeSets[0].ID
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	setEventStatus(t, eid, 1)
+
+	hole := []map[string]interface{}{{"hole": 3, "strokes": 4}}
+	rr := doAuthRequestWithChiCtx(t, "PUT", fmt.Sprintf("/api/v1/event/%d/scores/%d", eid, host), map[string]interface{}{
+		"tee_set_id": other,
+		"holes":      hole,
+	}, testHandler.SaveScorecard, map[string]string{"id": fmt.Sprintf("%d", eid), "player_id": fmt.Sprintf("%d", host)}, host)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for another course's tees, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "PUT", fmt.Sprintf("/api/v1/event/%d/scores/%d", eid, host), map[string]interface{}{
+		"tee_set_id": white,
+		"holes":      hole,
+	}, testHandler.SaveScorecard, map[string]string{"id": fmt.Sprintf("%d", eid), "player_id": fmt.Sprintf("%d", host)}, host)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.EventScoresResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	card := resp.Scorecards[0]
+	if card.TeeSetID == nil || *card.TeeSetID != white || card.Holes[0].Par == nil || *card.Holes[0].Par != 3 {
+		t.Errorf("expected the white tees' par 3, got %+v", card)
+	}
+	if card.Totals.ToPar == nil || *card.Totals.ToPar != 1 {
+		t.Errorf("expected +1, got %v", card.Totals.ToPar)
+	}
+}
+
+// ===================== HANDICAPS =====================
+
+// postRound saves a full 18 holes from the tee set, each a bogey.
+func postRound(t *testing.T, eventID, playerID, teeSetID int64) {
+	t.Helper()
+	pars := []int{4, 4, 3, 5, 4, 4, 3, 4, 5, 4, 3, 4, 5, 4, 4, 3, 5, 4}
+	holes := make([]map[string]interface{}, len(pars))
+	for i, par := range pars {
+		holes[i] = map[string]interface{}{"hole": i + 1, "strokes": par + 1}
+	}
+	rr := doAuthRequestWithChiCtx(t, "PUT", fmt.Sprintf("/api/v1/event/%d/scores/%d", eventID, playerID), map[string]interface{}{
+		"tee_set_id": teeSetID,
+		"holes":      holes,
+	}, testHandler.SaveScorecard, map[string]string{"id": fmt.Sprintf("%d", eventID), "player_id": fmt.Sprintf("%d", playerID)}, playerID)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func getHandicap(t *testing.T, playerID int64) model.HandicapDetailResponse {
+	t.Helper()
+	rr := doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/handicap", playerID), nil,
+		testHandler.GetHandicap, map[string]string{"player_id": fmt.Sprintf("%d", playerID)})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.HandicapDetailResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	return resp
+}
+
+func TestHandicap_CalculatedFromPostedRounds(t *testing.T) {
+	cleanDB(t)
+	admin := seedAdmin(t)
+	p1 := seedPlayer(t, "Alice", "alice@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	createTeeSet(t, c1, admin, teeSetBody("White", 72.0, 113))
+	white := getCourse(t, c1).TeeSets[0].ID
+	seedHandicap(t, p1, 25.0)
+
+	for i, date := range []string{"2030-06-01", "2030-06-08", "2030-06-15"} {
+		eid := seedEventAt(t, c1, p1, 4, false, date, "08:00")
+		seedPlayerEvent(t, p1, eid, 1)
+		setEventStatus(t, eid, 2)
+		postRound(t, eid, p1, white)
+
+		resp := getHandicap(t, p1)
+		if len(resp.Scores) != i+1 {
+			t.Fatalf("expected %d scores, got %+v", i+1, resp.Scores)
+		}
+		if i < 2 && (resp.Calculated || resp.HandicapIndex == nil || *resp.HandicapIndex != 25.0) {
+			t.Errorf("expected the self-reported 25.0 to stand, got %+v", resp)
+		}
+	}
+
+	// Three 18.0 differentials: the lowest less 2.0
+	resp := getHandicap(t, p1)
+	if !resp.Calculated || resp.HandicapIndex == nil || *resp.HandicapIndex != 16.0 {
+		t.Fatalf("expected a calculated 16.0, got %+v", resp)
+	}
+	if s := resp.Scores[0]; s.AdjustedGrossScore != 90 || s.ScoreDifferential != 18.0 || !s.Counted {
+		t.Errorf("expected the first 18.0 to count, got %+v", s)
+	}
+	if resp.Scores[1].Counted || resp.Scores[2].Counted {
+		t.Errorf("expected only one score to count, got %+v", resp.Scores)
+	}
+
+	rr := doRequest(t, "GET", "/api/v1/players", nil, testHandler.ListPlayers)
+	var players []model.PlayerResponse
+	json.NewDecoder(rr.Body).Decode(&players)
+	for _, p := range players {
+		if p.ID == p1 && (p.HandicapIndex == nil || *p.HandicapIndex != 16.0) {
+			t.Errorf("expected the profile to show 16.0, got %v", p.HandicapIndex)
+		}
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "PUT", fmt.Sprintf("/api/v1/players/%d/handicap", p1), map[string]interface{}{
+		"handicap_index": 10.0,
+	}, testHandler.UpdateHandicap, map[string]string{"player_id": fmt.Sprintf("%d", p1)}, p1)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 once calculated, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestHandicap_ClearedWhenRoundCancelled(t *testing.T) {
+	cleanDB(t)
+	admin := seedAdmin(t)
+	p1 := seedPlayer(t, "Alice", "alice@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	createTeeSet(t, c1, admin, teeSetBody("White", 72.0, 113))
+	white := getCourse(t, c1).TeeSets[0].ID
+
+	var last int64
+	for i, date := range []string{"2030-06-01", "2030-06-08", "2030-06-15"} {
+		last = seedEventAt(t, c1, p1, 4, false, date, "08:00")
+		seedPlayerEvent(t, p1, last, 1)
+		if i < 2 {
+			setEventStatus(t, last, 2)
+		} else {
+			setEventStatus(t, last, 1) // still being played
+		}
+		postRound(t, last, p1, white)
+	}
+	if resp := getHandicap(t, p1); !resp.Calculated || resp.HandicapIndex == nil {
+		t.Fatalf("expected a calculated index, got %+v", resp)
+	}
+
+	rr := doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/cancel", last), nil,
+		testHandler.CancelEvent, map[string]string{"id": fmt.Sprintf("%d", last)}, p1)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// Two rounds aren't enough for an index, so the calculated one is cleared.
+	resp := getHandicap(t, p1)
+	if len(resp.Scores) != 2 || resp.Calculated || resp.HandicapIndex != nil {
+		t.Errorf("expected the index cleared with two rounds left, got %+v", resp)
+	}
+}
+
+func TestHandicap_ClearedWhenEventDeleted(t *testing.T) {
+	cleanDB(t)
+	admin := seedAdmin(t)
+	p1 := seedPlayer(t, "Alice", "alice@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	createTeeSet(t, c1, admin, teeSetBody("White", 72.0, 113))
+	white := getCourse(t, c1).TeeSets[0].ID
+
+	var last int64
+	for _, date := range []string{"2030-06-01", "2030-06-08", "2030-06-15"} {
+		last = seedEventAt(t, c1, p1, 4, false, date, "08:00")
+		seedPlayerEvent(t, p1, last, 1)
+		setEventStatus(t, last, 2)
+		postRound(t, last, p1, white)
+	}
+	if resp := getHandicap(t, p1); !resp.Calculated || resp.HandicapIndex == nil {
+		t.Fatalf("expected a calculated index, got %+v", resp)
+	}
+
+	rr := doAuthRequestWithChiCtx(t, "DELETE", fmt.Sprintf("/api/v1/event/%d", last), nil,
+		testHandler.DeleteEvent, map[string]string{"id": fmt.Sprintf("%d", last)}, p1)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	resp := getHandicap(t, p1)
+	if len(resp.Scores) != 2 || resp.Calculated || resp.HandicapIndex != nil {
+		t.Errorf("expected the index cleared with two rounds left, got %+v", resp)
+	}
+	var index sql.NullFloat64
+	testDB.QueryRow("SELECT handicap_index FROM players WHERE id = $1", p1).Scan(&index)
+	if index.Valid {
+		t.Errorf("expected players.handicap_index cleared, got %v", index.Float64)
+	}
+}
+
+func TestHandicap_RecalculatedWhenTeeSetChanges(t *testing.T) {
+	cleanDB(t)
+	admin := seedAdmin(t)
+	p1 := seedPlayer(t, "Alice", "alice@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	createTeeSet(t, c1, admin, teeSetBody("White", 72.0, 113))
+	white := getCourse(t, c1).TeeSets[0].ID
+
+	for _, date := range []string{"2030-06-01", "2030-06-08", "2030-06-15"} {
+		eid := seedEventAt(t, c1, p1, 4, false, date, "08:00")
+		seedPlayerEvent(t, p1, eid, 1)
+		setEventStatus(t, eid, 2)
+		postRound(t, eid, p1, white)
+	}
+	if resp := getHandicap(t, p1); resp.HandicapIndex == nil || *resp.HandicapIndex != 16.0 {
+		t.Fatalf("expected a calculated 16.0, got %+v", resp)
+	}
+
+	params := map[string]string{"id": fmt.Sprintf("%d", c1), "tee_set_id": fmt.Sprintf("%d", white)}
+	path := fmt.Sprintf("/api/v1/courses/%d/tee-sets/%d", c1, white)
+	rr := doAuthRequestWithChiCtx(t, "PUT", path, teeSetBody("White", 70.0, 113), testHandler.UpdateTeeSet, params, admin)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	// Three 20.0 differentials now: the lowest less 2.0
+	if resp := getHandicap(t, p1); resp.HandicapIndex == nil || *resp.HandicapIndex != 18.0 {
+		t.Errorf("expected the index recalculated to 18.0, got %+v", resp)
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "DELETE", path, nil, testHandler.DeleteTeeSet, params, admin)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if resp := getHandicap(t, p1); len(resp.Scores) != 0 || resp.Calculated || resp.HandicapIndex != nil {
+		t.Errorf("expected no rated rounds left, got %+v", resp)
+	}
+}
+
+// ===================== GAMES =====================
+
+func createGame(t *testing.T, eventID, authID int64, body map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/games", eventID), body,
+		testHandler.CreateEventGame, map[string]string{"id": fmt.Sprintf("%d", eventID)}, authID)
+}
+
+func getGames(t *testing.T, eventID, authID int64) model.EventGamesResponse {
+	t.Helper()
+	rr := doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/event/%d/games", eventID), nil,
+		testHandler.ListEventGames, map[string]string{"id": fmt.Sprintf("%d", eventID)}, authID)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.EventGamesResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	return resp
+}
+
+func TestEventGames_SkinsAndSettlement(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+	seedPlayerEvent(t, p3, eid, 1)
+
+	skins := map[string]interface{}{"format": "skins", "stake_cents": 500}
+	if rr := createGame(t, eid, p2, skins); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := createGame(t, eid, host, map[string]interface{}{"format": "bingo", "stake_cents": 500}); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown format, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := createGame(t, eid, host, map[string]interface{}{"format": "match_play", "stake_cents": 500}); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for match play without two players, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr := createGame(t, eid, host, skins)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var created model.EventGamesResponse
+	json.NewDecoder(rr.Body).Decode(&created)
+	if len(created.Games) != 1 || len(created.Games[0].PlayerIDs) != 3 || !created.Games[0].Carryover {
+		t.Fatalf("expected skins for all three accepted players, got %+v", created.Games)
+	}
+
+	setEventStatus(t, eid, 1)
+	saveScores(t, eid, host, host, []map[string]interface{}{{"hole": 1, "strokes": 4, "par": 4}, {"hole": 2, "strokes": 3, "par": 4}})
+	saveScores(t, eid, p2, host, []map[string]interface{}{{"hole": 1, "strokes": 4, "par": 4}, {"hole": 2, "strokes": 5, "par": 4}})
+	saveScores(t, eid, p3, host, []map[string]interface{}{{"hole": 1, "strokes": 5, "par": 4}})
+	createExpense(t, eid, p2, 3000, host)
+
+	// Cleo hasn't finished hole 2, so nothing is won yet.
+	resp := getGames(t, eid, p3)
+	if len(resp.Games[0].Debts) != 0 {
+		t.Errorf("expected no winnings until every player finishes a hole, got %+v", resp.Games[0].Debts)
+	}
+
+	saveScores(t, eid, p3, host, []map[string]interface{}{{"hole": 2, "strokes": 5, "par": 4}})
+	resp = getGames(t, eid, p3)
+	game := resp.Games[0]
+	for _, r := range game.Results {
+		if r.PlayerID == host && (r.Points != 2 || r.WinningsCents != 2000) {
+			t.Errorf("expected the host to win two skins for 2000, got %+v", r)
+		}
+	}
+
+	// Bob paid 3000 for the three of them and owes the host 1000 in skins.
+	for _, b := range resp.Settlement.Balances {
+		want := map[int64]int64{host: 1000, p2: 1000, p3: -2000}[b.PlayerID]
+		if b.TotalCents != want {
+			t.Errorf("player %d: expected a total of %d, got %+v", b.PlayerID, want, b)
+		}
+	}
+	if len(resp.Settlement.Debts) != 2 {
+		t.Errorf("expected the round to net to two payments, got %+v", resp.Settlement.Debts)
+	}
+}
+
+func TestEventGames_Settle(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false)
+	seedPlayerEvent(t, host, eid, 1)
+	seedPlayerEvent(t, p2, eid, 1)
+
+	rr := createGame(t, eid, host, map[string]interface{}{"format": "match_play", "stake_cents": 1000, "player_ids": []int64{host, p2}})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	gameID := getGames(t, eid, host).Games[0].ID
+
+	setEventStatus(t, eid, 1)
+	saveScores(t, eid, host, host, []map[string]interface{}{{"hole": 1, "strokes": 5, "par": 4}})
+	saveScores(t, eid, p2, p2, []map[string]interface{}{{"hole": 1, "strokes": 4, "par": 4}})
+
+	settle := func() *httptest.ResponseRecorder {
+		return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/event/%d/games/settle", eid), nil,
+			testHandler.SettleEventGames, map[string]string{"id": fmt.Sprintf("%d", eid)}, host)
+	}
+	if rr := settle(); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 before the round is completed, got %d: %s", rr.Code, rr.Body.String())
+	}
+	setEventStatus(t, eid, 2)
+	if rr := settle(); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 with only one hole on the cards, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if resp := getGames(t, eid, host); resp.Games[0].SettledAt != nil {
+		t.Errorf("expected the game left unsettled, got %+v", resp.Games[0])
+	}
+
+	// The rest of the holes are halved, so Bob wins one up.
+	rest := make([]map[string]interface{}, 0, 17)
+	for hole := 2; hole <= 18; hole++ {
+		rest = append(rest, map[string]interface{}{"hole": hole, "strokes": 4, "par": 4})
+	}
+	saveScores(t, eid, host, host, rest)
+	saveScores(t, eid, p2, p2, rest)
+	if rr := settle(); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	balances := getPlayerBalances(t, host)
+	if len(balances.Balances) != 1 || balances.Balances[0].PlayerID != p2 || balances.Balances[0].AmountCents != 1000 || balances.Balances[0].Direction != "you_owe" {
+		t.Errorf("expected the host to owe Bob 1000, got %+v", balances.Balances)
+	}
+
+	// Corrections after settling don't change what's owed.
+	saveScores(t, eid, host, host, []map[string]interface{}{{"hole": 1, "strokes": 3, "par": 4}})
+	resp := getGames(t, eid, p2)
+	if resp.Games[0].SettledAt == nil || len(resp.Games[0].Debts) != 1 || resp.Games[0].Debts[0].ToPlayerID != p2 {
+		t.Errorf("expected the settled payout to stand, got %+v", resp.Games[0])
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "DELETE", fmt.Sprintf("/api/v1/event/%d/games/%d", eid, gameID), nil,
+		testHandler.DeleteEventGame, map[string]string{"id": fmt.Sprintf("%d", eid), "game_id": fmt.Sprintf("%d", gameID)}, host)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 deleting a settled game, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+// ===================== LEAGUES =====================
+
+func createLeague(t *testing.T, authID int64, name string) model.LeagueResponse {
+	t.Helper()
+	rr := doAuthRequestWithChiCtx(t, "POST", "/api/v1/leagues", map[string]interface{}{"name": name},
+		testHandler.CreateLeague, nil, authID)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.LeagueResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	return resp
+}
+
+func addLeagueMember(t *testing.T, leagueID, playerID, authID int64) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/leagues/%d/members", leagueID), map[string]interface{}{
+		"player_id": playerID,
+	}, testHandler.AddLeagueMember, map[string]string{"id": fmt.Sprintf("%d", leagueID)}, authID)
+}
+
+func createSeason(t *testing.T, leagueID, authID int64, body map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/leagues/%d/seasons", leagueID), body,
+		testHandler.CreateLeagueSeason, map[string]string{"id": fmt.Sprintf("%d", leagueID)}, authID)
+}
+
+// playNine saves a nine hole card of par 4s with the given strokes.
+func playNine(t *testing.T, eventID, playerID int64, strokes ...int) {
+	t.Helper()
+	holes := make([]map[string]interface{}, len(strokes))
+	for i, s := range strokes {
+		holes[i] = map[string]interface{}{"hole": i + 1, "strokes": s, "par": 4}
+	}
+	if rr := saveScores(t, eventID, playerID, playerID, holes); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestLeagues_Membership(t *testing.T) {
+	cleanDB(t)
+	commish := seedPlayer(t, "Commish", "commish@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	league := createLeague(t, commish, "Tuesday League")
+	if league.CommissionerID != commish || len(league.MemberIDs) != 1 {
+		t.Fatalf("expected the commissioner as the only member, got %+v", league)
+	}
+
+	if rr := addLeagueMember(t, league.ID, p3, p2); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a non-commissioner, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := addLeagueMember(t, league.ID, p2, commish); rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr := doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/leagues/%d", league.ID), nil,
+		testHandler.GetLeague, map[string]string{"id": fmt.Sprintf("%d", league.ID)}, p3)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a non-member, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	remove := func(playerID, authID int64) *httptest.ResponseRecorder {
+		return doAuthRequestWithChiCtx(t, "DELETE", fmt.Sprintf("/api/v1/leagues/%d/members/%d", league.ID, playerID), nil,
+			testHandler.RemoveLeagueMember, map[string]string{"id": fmt.Sprintf("%d", league.ID), "player_id": fmt.Sprintf("%d", playerID)}, authID)
+	}
+	if rr := remove(commish, commish); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for the commissioner leaving, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := remove(p2, p2); rr.Code != http.StatusOK {
+		t.Errorf("expected a member to be able to leave, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestLeagues_SeasonScheduleAndStandings(t *testing.T) {
+	cleanDB(t)
+	commish := seedPlayer(t, "Commish", "commish@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	league := createLeague(t, commish, "Tuesday League")
+	addLeagueMember(t, league.ID, p2, commish)
+
+	season := map[string]interface{}{
+		"name":               "Summer",
+		"course_id":          c1,
+		"starts_on":          "2030-06-04",
+		"tee_time":           "17:30",
+		"open_spots":         8,
+		"number_of_holes":    "9",
+		"rrule":              "FREQ=WEEKLY",
+		"finish_points":      []int{10, 5},
+		"match_win_points":   2,
+		"match_halve_points": 1,
+	}
+	if rr := createSeason(t, league.ID, commish, season); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an endless schedule, got %d: %s", rr.Code, rr.Body.String())
+	}
+	season["rrule"] = "FREQ=WEEKLY;COUNT=3"
+	if rr := createSeason(t, league.ID, p2, season); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a non-commissioner, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr := createSeason(t, league.ID, commish, season)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.LeagueResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if len(resp.Seasons) != 1 || len(resp.Seasons[0].EventIDs) != 3 {
+		t.Fatalf("expected a season of three events, got %+v", resp.Seasons)
+	}
+	events := resp.Seasons[0].EventIDs
+
+	// Members added mid-season are invited to the rest of the schedule.
+	addLeagueMember(t, league.ID, p3, commish)
+	var invited int
+	testDB.QueryRow("SELECT COUNT(*) FROM player_events WHERE player_id = $1 AND invite_status = 0", p3).Scan(&invited)
+	if invited != 3 {
+		t.Errorf("expected the new member invited to all three events, got %d", invited)
+	}
+
+	week1 := events[0]
+	testDB.Exec("UPDATE player_events SET invite_status = 1 WHERE event_id = $1", week1)
+	setEventStatus(t, week1, 1)
+	playNine(t, week1, commish, 4, 4, 4, 4, 4, 4, 4, 4, 4)
+	playNine(t, week1, p2, 3, 5, 4, 4, 4, 4, 4, 4, 4)
+	playNine(t, week1, p3, 5, 5, 5, 5, 5, 5, 5, 5, 5)
+
+	getStandings := func() model.LeagueStandingsResponse {
+		rr := doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/leagues/%d/standings", league.ID), nil,
+			testHandler.GetLeagueStandings, map[string]string{"id": fmt.Sprintf("%d", league.ID)}, p2)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		var resp model.LeagueStandingsResponse
+		json.NewDecoder(rr.Body).Decode(&resp)
+		return resp
+	}
+
+	if standings := getStandings(); len(standings.Rounds) != 0 || standings.Standings[0].Points != 0 {
+		t.Errorf("expected no points until the round is completed, got %+v", standings)
+	}
+
+	setEventStatus(t, week1, 2)
+	standings := getStandings()
+	if len(standings.Rounds) != 1 || len(standings.Standings) != 3 {
+		t.Fatalf("expected one round for three members, got %+v", standings)
+	}
+	// Commish and Bob tie on 36 and share 15 finish points, halve their
+	// match and both beat Cleo.
+	want := map[int64]float64{commish: 10.5, p2: 10.5, p3: 0}
+	for _, s := range standings.Standings {
+		if s.Points != want[s.PlayerID] {
+			t.Errorf("player %d: expected %v points, got %+v", s.PlayerID, want[s.PlayerID], s)
+		}
+	}
+	if standings.Standings[0].Position != 1 || standings.Standings[1].Position != 1 || standings.Standings[2].Position != 3 {
+		t.Errorf("expected positions 1, 1, 3, got %+v", standings.Standings)
+	}
+
+	// Leaving withdraws the invitations to the rest of the schedule, but not
+	// the round already played.
+	rr = doAuthRequestWithChiCtx(t, "DELETE", fmt.Sprintf("/api/v1/leagues/%d/members/%d", league.ID, p3), nil,
+		testHandler.RemoveLeagueMember, map[string]string{"id": fmt.Sprintf("%d", league.ID), "player_id": fmt.Sprintf("%d", p3)}, p3)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var pending, played int
+	testDB.QueryRow("SELECT COUNT(*) FROM player_events WHERE player_id = $1 AND invite_status = 0", p3).Scan(&pending)
+	testDB.QueryRow("SELECT COUNT(*) FROM player_events WHERE player_id = $1 AND event_id = $2", p3, week1).Scan(&played)
+	if pending != 0 || played != 1 {
+		t.Errorf("expected only the played round left, got %d pending and %d played", pending, played)
+	}
+}
+
+// ===================== PLAYER STATS =====================
+
+// eighteen is a full card of par 4s with the same score and stats on every
+// hole.
+func eighteen(strokes, putts int, fairwayHit, gir bool) []map[string]interface{} {
+	holes := make([]map[string]interface{}, 18)
+	for i := range holes {
+		holes[i] = map[string]interface{}{
+			"hole": i + 1, "strokes": strokes, "par": 4,
+			"putts": putts, "fairway_hit": fairwayHit, "green_in_regulation": gir,
+		}
+	}
+	return holes
+}
+
+func getPlayerStats(t *testing.T, playerID int64, query string) model.PlayerStatsResponse {
+	t.Helper()
+	rr := doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/stats?%s", playerID, query), nil,
+		testHandler.GetPlayerStats, map[string]string{"player_id": fmt.Sprintf("%d", playerID)})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.PlayerStatsResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	return resp
+}
+
+func TestGetPlayerStats(t *testing.T) {
+	cleanDB(t)
+	p1 := seedPlayer(t, "Alice", "alice@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	c2 := seedCourse(t, "Pine Hills")
+	may := seedEventAt(t, c1, p1, 4, false, "2030-05-01", "09:00")
+	june := seedEventAt(t, c1, p1, 4, false, "2030-06-01", "09:00")
+	july := seedEventAt(t, c2, p1, 4, false, "2030-07-01", "09:00")
+	for _, eid := range []int64{may, june, july} {
+		seedPlayerEvent(t, p1, eid, 1)
+		setEventStatus(t, eid, 2)
+	}
+	saveScores(t, may, p1, p1, eighteen(5, 2, true, false))
+	saveScores(t, june, p1, p1, eighteen(4, 2, false, true))
+	saveScores(t, july, p1, p1, eighteen(4, 1, true, true)[:9]) // not finished
+
+	stats := getPlayerStats(t, p1, "")
+	if stats.Rounds != 2 || stats.ScoringAverage == nil || *stats.ScoringAverage != 81 {
+		t.Fatalf("expected two complete rounds averaging 81, got %+v", stats)
+	}
+	if stats.NineHoleAverage != nil {
+		t.Errorf("expected no nine hole average, got %v", *stats.NineHoleAverage)
+	}
+	if stats.PuttsPerRound == nil || *stats.PuttsPerRound != 36 {
+		t.Errorf("expected 36 putts per round, got %v", stats.PuttsPerRound)
+	}
+	if stats.FairwayPercentage == nil || *stats.FairwayPercentage != 50 || stats.GIRPercentage == nil || *stats.GIRPercentage != 50 {
+		t.Errorf("expected half the fairways and greens hit, got %v and %v", stats.FairwayPercentage, stats.GIRPercentage)
+	}
+	if len(stats.ParAverages) != 1 || stats.ParAverages[0].Par != 4 || stats.ParAverages[0].Average != 4.5 {
+		t.Errorf("expected par 4s averaging 4.5, got %+v", stats.ParAverages)
+	}
+	if len(stats.BestRounds) != 2 || stats.BestRounds[0].EventID != june || stats.BestRounds[0].ToPar == nil || *stats.BestRounds[0].ToPar != 0 {
+		t.Errorf("expected the level par round in June first, got %+v", stats.BestRounds)
+	}
+	if len(stats.Courses) != 1 || stats.Courses[0].CourseID != c1 || stats.Courses[0].Rounds != 2 {
+		t.Errorf("expected both rounds at the first course, got %+v", stats.Courses)
+	}
+
+	if filtered := getPlayerStats(t, p1, "from=2030-05-02&to=2030-06-01"); filtered.Rounds != 1 || *filtered.ScoringAverage != 72 {
+		t.Errorf("expected only the June round, got %+v", filtered)
+	}
+	if filtered := getPlayerStats(t, p1, fmt.Sprintf("course_id=%d", c2)); filtered.Rounds != 0 || filtered.ScoringAverage != nil {
+		t.Errorf("expected no complete rounds at the second course, got %+v", filtered)
+	}
+
+	var cached int
+	testDB.QueryRow("SELECT COUNT(*) FROM player_stats WHERE player_id = $1", p1).Scan(&cached)
+	if cached != 3 {
+		t.Errorf("expected a cached summary per filter, got %d", cached)
+	}
+
+	// Finishing the card clears the cache.
+	saveScores(t, july, p1, p1, eighteen(4, 1, true, true)[9:])
+	testDB.QueryRow("SELECT COUNT(*) FROM player_stats WHERE player_id = $1", p1).Scan(&cached)
+	if cached != 0 {
+		t.Errorf("expected saving scores to clear cached stats, got %d", cached)
+	}
+	if stats := getPlayerStats(t, p1, ""); stats.Rounds != 3 || len(stats.Courses) != 2 {
+		t.Errorf("expected the finished round to count, got %+v", stats)
+	}
+
+	rr := doRequestWithChiCtx(t, "GET", "/api/v1/players/999999/stats", nil,
+		testHandler.GetPlayerStats, map[string]string{"player_id": "999999"})
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for an unknown player, got %d", rr.Code)
+	}
+	rr = doRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/stats?from=May", p1), nil,
+		testHandler.GetPlayerStats, map[string]string{"player_id": fmt.Sprintf("%d", p1)})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a bad date, got %d", rr.Code)
+	}
+}
+
+// ===================== LEADERBOARDS =====================
+
+// postRoundAt plays an 18 hole round of par 4s at the course on the date,
+// spreading the strokes over or under par across the first holes.
+func postRoundAt(t *testing.T, courseID, playerID int64, date string, total int) int64 {
+	t.Helper()
+	eid := seedEventAt(t, courseID, playerID, 4, false, date, "09:00")
+	seedPlayerEvent(t, playerID, eid, 1)
+	setEventStatus(t, eid, 2)
+	holes := make([]map[string]interface{}, 18)
+	for i := range holes {
+		strokes := 4
+		if diff := total - 72; diff > 0 && i < diff {
+			strokes = 5
+		} else if diff < 0 && i < -diff {
+			strokes = 3
+		}
+		holes[i] = map[string]interface{}{"hole": i + 1, "strokes": strokes, "par": 4}
+	}
+	if rr := saveScores(t, eid, playerID, playerID, holes); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	return eid
+}
+
+func getLeaderboard(t *testing.T, handler http.HandlerFunc, path string, params map[string]string, viewerID int64) ([]model.LeaderboardEntryResponse, string) {
+	t.Helper()
+	var rr *httptest.ResponseRecorder
+	if viewerID == 0 {
+		rr = doRequestWithChiCtx(t, "GET", path, nil, handler, params)
+	} else {
+		rr = doAuthRequestWithChiCtx(t, "GET", path, nil, handler, params, viewerID)
+	}
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp []model.LeaderboardEntryResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	return resp, rr.Header().Get("X-Next-Cursor")
+}
+
+func leaderboardPlayers(entries []model.LeaderboardEntryResponse) []int64 {
+	ids := make([]int64, len(entries))
+	for i, e := range entries {
+		ids[i] = e.PlayerID
+	}
+	return ids
+}
+
+func setScoresVisibility(t *testing.T, playerID int64, visibility string) {
+	t.Helper()
+	rr := doAuthRequestWithChiCtx(t, "PUT", fmt.Sprintf("/api/v1/players/%d/privacy", playerID), map[string]interface{}{
+		"scores_visibility": visibility,
+	}, testHandler.UpdatePrivacy, map[string]string{"player_id": fmt.Sprintf("%d", playerID)}, playerID)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestScoresVisibility_StatsAndHandicap(t *testing.T) {
+	cleanDB(t)
+	alice := seedPlayer(t, "Alice", "alice@test.com", "password")
+	bob := seedPlayer(t, "Bob", "bob@test.com", "password")
+	cleo := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	seedFriendship(t, alice, bob)
+
+	get := func(handler http.HandlerFunc, path string, authID int64) int {
+		params := map[string]string{"player_id": fmt.Sprintf("%d", alice)}
+		if authID == 0 {
+			return doRequestWithChiCtx(t, "GET", path, nil, handler, params).Code
+		}
+		return doAuthRequestWithChiCtx(t, "GET", path, nil, handler, params, authID).Code
+	}
+	statsPath := fmt.Sprintf("/api/v1/players/%d/stats", alice)
+	handicapPath := fmt.Sprintf("/api/v1/players/%d/handicap", alice)
+
+	setScoresVisibility(t, alice, "friends")
+	for _, tc := range []struct {
+		viewer int64
+		want   int
+	}{{alice, http.StatusOK}, {bob, http.StatusOK}, {cleo, http.StatusForbidden}, {0, http.StatusForbidden}} {
+		if code := get(testHandler.GetPlayerStats, statsPath, tc.viewer); code != tc.want {
+			t.Errorf("expected stats status %d for viewer %d, got %d", tc.want, tc.viewer, code)
+		}
+		if code := get(testHandler.GetHandicap, handicapPath, tc.viewer); code != tc.want {
+			t.Errorf("expected handicap status %d for viewer %d, got %d", tc.want, tc.viewer, code)
+		}
+	}
+
+	setScoresVisibility(t, alice, "only_me")
+	if code := get(testHandler.GetHandicap, handicapPath, bob); code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a friend when only Alice can see, got %d", code)
+	}
+	if code := get(testHandler.GetPlayerStats, statsPath, alice); code != http.StatusOK {
+		t.Errorf("expected Alice to see her own stats, got %d", code)
+	}
+}
+
+func TestCourseLeaderboard(t *testing.T) {
+	cleanDB(t)
+	alice := seedPlayer(t, "Alice", "alice@test.com", "password")
+	bob := seedPlayer(t, "Bob", "bob@test.com", "password")
+	cleo := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	dan := seedPlayer(t, "Dan", "dan@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	seedHandicap(t, alice, 10)
+	seedHandicap(t, bob, 20)
+
+	recent := time.Now().UTC().AddDate(0, 0, -3).Format(dateLayout)
+	postRoundAt(t, c1, alice, recent, 72)
+	postRoundAt(t, c1, alice, "2020-01-01", 80)
+	postRoundAt(t, c1, bob, recent, 75)
+	postRoundAt(t, c1, cleo, "2020-01-01", 75)
+	postRoundAt(t, c1, dan, recent, 70)
+	setScoresVisibility(t, dan, "only_me")
+
+	path := fmt.Sprintf("/api/v1/courses/%d/leaderboard", c1)
+	params := map[string]string{"id": fmt.Sprintf("%d", c1)}
+
+	// Cleo posted her 75 first so she's listed ahead of Bob in a shared
+	// second place.
+	entries, cursor := getLeaderboard(t, testHandler.GetCourseLeaderboard, path, params, 0)
+	if got := leaderboardPlayers(entries); fmt.Sprint(got) != fmt.Sprint([]int64{alice, cleo, bob}) {
+		t.Fatalf("expected Alice, Cleo, Bob, got %v", got)
+	}
+	if entries[0].Score != 72 || entries[0].PlayerName != "Alice" || entries[1].Position != 2 || entries[2].Position != 2 {
+		t.Errorf("expected Alice's 72 then a tie for second, got %+v", entries)
+	}
+	if cursor != "" {
+		t.Errorf("expected no next page, got %q", cursor)
+	}
+
+	if entries, _ := getLeaderboard(t, testHandler.GetCourseLeaderboard, path, params, dan); entries[0].PlayerID != dan {
+		t.Errorf("expected Dan to see his own round, got %+v", entries)
+	}
+
+	entries, _ = getLeaderboard(t, testHandler.GetCourseLeaderboard, path+"?window=week", params, 0)
+	if got := leaderboardPlayers(entries); fmt.Sprint(got) != fmt.Sprint([]int64{alice, bob}) {
+		t.Errorf("expected only this week's rounds, got %v", got)
+	}
+
+	entries, _ = getLeaderboard(t, testHandler.GetCourseLeaderboard, path+"?scoring=net", params, 0)
+	if entries[0].PlayerID != bob || entries[0].Score != 55 || entries[0].Gross != 75 {
+		t.Errorf("expected Bob's net 55 to lead, got %+v", entries[0])
+	}
+
+	entries, cursor = getLeaderboard(t, testHandler.GetCourseLeaderboard, path+"?limit=2", params, 0)
+	if len(entries) != 2 || cursor == "" {
+		t.Fatalf("expected a page of two with a cursor, got %+v %q", entries, cursor)
+	}
+	entries, cursor = getLeaderboard(t, testHandler.GetCourseLeaderboard, path+"?limit=2&cursor="+cursor, params, 0)
+	if len(entries) != 1 || entries[0].PlayerID != bob || entries[0].Position != 2 || cursor != "" {
+		t.Errorf("expected Bob alone on the last page, still second, got %+v %q", entries, cursor)
+	}
+
+	// Friends-only rounds show to the players Cleo follows.
+	setScoresVisibility(t, cleo, "friends")
+	seedFriendship(t, cleo, alice)
+	if entries, _ := getLeaderboard(t, testHandler.GetCourseLeaderboard, path, params, bob); len(entries) != 2 {
+		t.Errorf("expected Cleo hidden from Bob, got %+v", entries)
+	}
+	if entries, _ := getLeaderboard(t, testHandler.GetCourseLeaderboard, path, params, alice); len(entries) != 3 {
+		t.Errorf("expected Cleo shown to Alice, got %+v", entries)
+	}
+
+	rr := doRequestWithChiCtx(t, "GET", path+"?window=decade", nil, testHandler.GetCourseLeaderboard, params)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown window, got %d", rr.Code)
+	}
+}
+
+func TestFriendsLeaderboard(t *testing.T) {
+	cleanDB(t)
+	alice := seedPlayer(t, "Alice", "alice@test.com", "password")
+	bob := seedPlayer(t, "Bob", "bob@test.com", "password")
+	cleo := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	c2 := seedCourse(t, "Pine Hills")
+	postRoundAt(t, c1, alice, "2030-06-01", 78)
+	postRoundAt(t, c2, bob, "2030-06-01", 74)
+	postRoundAt(t, c1, cleo, "2030-06-01", 70)
+	seedFriendship(t, alice, bob)
+
+	path := fmt.Sprintf("/api/v1/players/%d/friends-leaderboard", alice)
+	params := map[string]string{"player_id": fmt.Sprintf("%d", alice)}
+	entries, _ := getLeaderboard(t, testHandler.GetFriendsLeaderboard, path, params, alice)
+	if got := leaderboardPlayers(entries); fmt.Sprint(got) != fmt.Sprint([]int64{bob, alice}) {
+		t.Errorf("expected Bob then Alice, got %v", got)
+	}
+	entries, _ = getLeaderboard(t, testHandler.GetFriendsLeaderboard, path+fmt.Sprintf("?course_id=%d", c1), params, alice)
+	if got := leaderboardPlayers(entries); fmt.Sprint(got) != fmt.Sprint([]int64{alice}) {
+		t.Errorf("expected only Alice at the first course, got %v", got)
+	}
+}
+
+func TestUpdatePrivacy(t *testing.T) {
+	cleanDB(t)
+	alice := seedPlayer(t, "Alice", "alice@test.com", "password")
+	bob := seedPlayer(t, "Bob", "bob@test.com", "password")
+	path := fmt.Sprintf("/api/v1/players/%d/privacy", alice)
+	params := map[string]string{"player_id": fmt.Sprintf("%d", alice)}
+
+	rr := doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"scores_visibility": "friends"}, testHandler.UpdatePrivacy, params, bob)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"scores_visibility": "secret"}, testHandler.UpdatePrivacy, params, alice)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+	setScoresVisibility(t, alice, "friends")
+
+	rr = doAuthRequestWithChiCtx(t, "GET", path, nil, testHandler.GetPrivacy, params, alice)
+	var resp model.PrivacyResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if resp.ScoresVisibility != "friends" {
+		t.Errorf("expected friends, got %+v", resp)
+	}
+}
+
+// ===================== MATCHMAKING =====================
+
+func seedCourseLocation(t *testing.T, courseID int64, lat, lng float64) {
+	t.Helper()
+	if _, err := testDB.Exec("UPDATE courses SET latitude = $2, longitude = $3 WHERE id = $1", courseID, lat, lng); err != nil {
+		t.Fatalf("seedCourseLocation failed: %v", err)
+	}
+}
+
+func recommendedEvents(t *testing.T, playerID int64, query string) []model.EventMatchResponse {
+	t.Helper()
+	rr := doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/recommended-events?%s", playerID, query), nil,
+		testHandler.ListRecommendedEvents, map[string]string{"player_id": fmt.Sprintf("%d", playerID)}, playerID)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp []model.EventMatchResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	return resp
+}
+
+func TestListRecommendedEvents(t *testing.T) {
+	cleanDB(t)
+	alice := seedPlayer(t, "Alice", "alice@test.com", "password")
+	bob := seedPlayer(t, "Bob", "bob@test.com", "password")
+	cleo := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	dan := seedPlayer(t, "Dan", "dan@test.com", "password")
+	erin := seedPlayer(t, "Erin", "erin@test.com", "password")
+	near := seedCourse(t, "City Park")
+	far := seedCourse(t, "Boulder Creek")
+	seedCourseLocation(t, near, 39.7509, -104.9520)
+	seedCourseLocation(t, far, 40.0150, -105.2705)
+	seedHandicap(t, alice, 12)
+	seedHandicap(t, bob, 12)
+	seedHandicap(t, cleo, 30)
+	seedFriendship(t, alice, erin)
+	testDB.Exec("INSERT INTO pairing_preferences (player_id, other_player_id, preference) VALUES ($1, $2, 1)", dan, alice)
+
+	rr := doAuthRequestWithChiCtx(t, "PUT", fmt.Sprintf("/api/v1/players/%d/home-course", alice), map[string]interface{}{
+		"course_id": near,
+	}, testHandler.UpdateHomeCourse, map[string]string{"player_id": fmt.Sprintf("%d", alice)}, alice)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	hosted := func(course, host int64, spots int, private bool, date, teeTime string) int64 {
+		eid := seedEventAt(t, course, host, spots, private, date, teeTime)
+		seedPlayerEvent(t, host, eid, 1)
+		return eid
+	}
+	similar := hosted(near, bob, 4, false, "2030-06-01", "09:00")
+	mismatched := hosted(far, cleo, 4, false, "2030-06-01", "09:30")
+	friendNextDay := hosted(far, erin, 4, false, "2030-06-02", "10:00")
+	hosted(near, dan, 4, false, "2030-06-01", "09:00")  // Dan avoids Alice
+	hosted(near, bob, 4, true, "2030-06-01", "09:00")   // private
+	hosted(near, cleo, 1, false, "2030-06-01", "09:00") // full
+	hosted(near, bob, 4, false, "2030-06-05", "09:00")  // outside the flex days
+	joined := hosted(near, bob, 4, false, "2030-06-01", "10:00")
+	seedPlayerEvent(t, alice, joined, 0)
+
+	matches := recommendedEvents(t, alice, "date=2030-06-01&from=08:00&to=11:00")
+	got := make([]int64, len(matches))
+	for i, m := range matches {
+		got[i] = m.Event.ID
+	}
+	if fmt.Sprint(got) != fmt.Sprint([]int64{similar, friendNextDay, mismatched}) {
+		t.Fatalf("expected the similar nearby game, the friend's game, then the mismatched one, got %v", got)
+	}
+	if m := matches[0]; m.HandicapGap == nil || *m.HandicapGap != 0 || m.DistanceKm == nil || *m.DistanceKm != 0 || m.HoursAway != 0 {
+		t.Errorf("expected a perfect handicap and location fit, got %+v", m)
+	}
+	if m := matches[1]; m.Friends != 1 || m.HoursAway != 23 || m.HandicapGap != nil {
+		t.Errorf("expected one friend 23 hours after the window, got %+v", m)
+	}
+	if matches[0].Event.HostID != int32(bob) || len(matches[0].Event.Accepted) != 1 {
+		t.Errorf("expected the event details in the match, got %+v", matches[0].Event)
+	}
+
+	matches = recommendedEvents(t, alice, "date=2030-06-01&from=08:00&to=11:00&flex_days=0&limit=1")
+	if len(matches) != 1 || matches[0].Event.ID != similar {
+		t.Errorf("expected only the best game in the window, got %+v", matches)
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/recommended-events", alice), nil,
+		testHandler.ListRecommendedEvents, map[string]string{"player_id": fmt.Sprintf("%d", alice)}, bob)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", rr.Code)
+	}
+	rr = doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/recommended-events?from=11:00&to=08:00", alice), nil,
+		testHandler.ListRecommendedEvents, map[string]string{"player_id": fmt.Sprintf("%d", alice)}, alice)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a backwards window, got %d", rr.Code)
+	}
+}
+
+func TestListRecommendedEvents_CourseLocalTime(t *testing.T) {
+	cleanDB(t)
+	alice := seedPlayer(t, "Alice", "alice@test.com", "password")
+	bob := seedPlayer(t, "Bob", "bob@test.com", "password")
+	course := seedCourse(t, "Millbrook")
+	testDB.Exec("UPDATE courses SET time_zone = 'Pacific/Auckland' WHERE id = $1", course)
+	loc, _ := time.LoadLocation("Pacific/Auckland")
+	now := time.Now().In(loc)
+
+	// Auckland is ahead of UTC, so a round that teed off there half an hour
+	// ago would still look upcoming against the UTC clock.
+	started := now.Add(-30 * time.Minute)
+	seedEventAt(t, course, bob, 4, false, started.Format("2006-01-02"), started.Format("15:04"))
+	later := now.Add(time.Hour)
+	upcoming := seedEventAt(t, course, bob, 4, false, later.Format("2006-01-02"), later.Format("15:04"))
+
+	matches := recommendedEvents(t, alice, "date="+now.Format("2006-01-02"))
+	if len(matches) != 1 || matches[0].Event.ID != upcoming {
+		t.Errorf("expected only the event that hasn't teed off at the course, got %+v", matches)
+	}
+}
+
+func TestUpdateHomeCourse(t *testing.T) {
+	cleanDB(t)
+	alice := seedPlayer(t, "Alice", "alice@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	path := fmt.Sprintf("/api/v1/players/%d/home-course", alice)
+	params := map[string]string{"player_id": fmt.Sprintf("%d", alice)}
+
+	rr := doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"course_id": c1 + 100}, testHandler.UpdateHomeCourse, params, alice)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for an unknown course, got %d", rr.Code)
+	}
+	rr = doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"course_id": c1}, testHandler.UpdateHomeCourse, params, alice)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "GET", path, nil, testHandler.GetHomeCourse, params, alice)
+	var resp model.HomeCourseResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if resp.HomeCourseID == nil || int64(*resp.HomeCourseID) != c1 {
+		t.Errorf("expected home course %d, got %+v", c1, resp)
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "PUT", path, map[string]interface{}{"course_id": nil}, testHandler.UpdateHomeCourse, params, alice)
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if rr.Code != http.StatusOK || resp.HomeCourseID != nil {
+		t.Errorf("expected the home course cleared, got %d %+v", rr.Code, resp)
+	}
+}
+
+// ===================== AVAILABILITY =====================
 
-	// Should still be only one friendship
-	var count int
-	testDB.QueryRow("SELECT COUNT(*) FROM friendships WHERE follower_id = $1 AND followee_id = $2", p1, p2).Scan(&count)
-	if count != 1 {
-		t.Errorf("expected 1 friendship, got %d", count)
+func setAvailability(t *testing.T, playerID int64, body map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "PUT", fmt.Sprintf("/api/v1/players/%d/availability", playerID), body,
+		testHandler.UpdateAvailability, map[string]string{"player_id": fmt.Sprintf("%d", playerID)}, playerID)
+}
+
+func saturdayWindow(from, to string) map[string]interface{} {
+	return map[string]interface{}{"day": "saturday", "from": from, "to": to}
+}
+
+func proposeGame(t *testing.T, hostID int64, body map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/players/%d/game-proposals", hostID), body,
+		testHandler.ProposeGame, map[string]string{"player_id": fmt.Sprintf("%d", hostID)}, hostID)
+}
+
+func TestUpdateAvailability(t *testing.T) {
+	cleanDB(t)
+	alice := seedPlayer(t, "Alice", "alice@test.com", "password")
+	bob := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+
+	rr := setAvailability(t, alice, map[string]interface{}{
+		"windows": []map[string]interface{}{
+			saturdayWindow("07:00", "13:00"),
+			{"day": "tuesday", "from": "16:00", "to": "20:00"},
+		},
+		"preferred_course_ids": []int64{c1},
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/availability", alice), nil,
+		testHandler.GetAvailability, map[string]string{"player_id": fmt.Sprintf("%d", alice)}, alice)
+	var resp model.AvailabilityResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if len(resp.Windows) != 2 || resp.Windows[0].Day != "tuesday" || resp.Windows[1].From != "07:00" || resp.Windows[1].To != "13:00" {
+		t.Errorf("expected both windows by day of the week, got %+v", resp.Windows)
+	}
+	if len(resp.PreferredCourseIDs) != 1 || resp.PreferredCourseIDs[0] != c1 {
+		t.Errorf("expected the preferred course, got %v", resp.PreferredCourseIDs)
+	}
+
+	// Saving again replaces everything.
+	setAvailability(t, alice, map[string]interface{}{"windows": []map[string]interface{}{saturdayWindow("08:00", "10:00")}})
+	rr = doAuthRequestWithChiCtx(t, "GET", fmt.Sprintf("/api/v1/players/%d/availability", alice), nil,
+		testHandler.GetAvailability, map[string]string{"player_id": fmt.Sprintf("%d", alice)}, alice)
+	resp = model.AvailabilityResponse{}
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if len(resp.Windows) != 1 || len(resp.PreferredCourseIDs) != 0 {
+		t.Errorf("expected the availability replaced, got %+v", resp)
+	}
+
+	for _, body := range []map[string]interface{}{
+		{"windows": []map[string]interface{}{{"day": "someday", "from": "07:00", "to": "09:00"}}},
+		{"windows": []map[string]interface{}{saturdayWindow("13:00", "07:00")}},
+		{"windows": []map[string]interface{}{saturdayWindow("7am", "9am")}},
+	} {
+		if rr := setAvailability(t, alice, body); rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %v, got %d", body, rr.Code)
+		}
+	}
+	rr = doAuthRequestWithChiCtx(t, "PUT", fmt.Sprintf("/api/v1/players/%d/availability", alice), map[string]interface{}{},
+		testHandler.UpdateAvailability, map[string]string{"player_id": fmt.Sprintf("%d", alice)}, bob)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", rr.Code)
 	}
 }
 
-func TestDeleteFriendship(t *testing.T) {
+func TestProposeGame(t *testing.T) {
 	cleanDB(t)
-	p1 := seedPlayer(t, "Amy", "amy@test.com", "password")
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	bob := seedPlayer(t, "Bob", "bob@test.com", "password")
+	cleo := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	dan := seedPlayer(t, "Dan", "dan@test.com", "password")
+	stranger := seedPlayer(t, "Stranger", "stranger@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	c2 := seedCourse(t, "Pine Hills")
+	for _, id := range []int64{bob, cleo, dan} {
+		seedFriendship(t, host, id)
+	}
+
+	// 2030-06-01 is a Saturday. Dan is free all day but already playing in
+	// the morning.
+	setAvailability(t, host, map[string]interface{}{"windows": []map[string]interface{}{saturdayWindow("07:00", "18:00")}})
+	setAvailability(t, bob, map[string]interface{}{"windows": []map[string]interface{}{saturdayWindow("07:00", "13:00")}})
+	setAvailability(t, cleo, map[string]interface{}{
+		"windows":              []map[string]interface{}{saturdayWindow("08:00", "14:00")},
+		"preferred_course_ids": []int64{c1},
+	})
+	setAvailability(t, dan, map[string]interface{}{"windows": []map[string]interface{}{saturdayWindow("06:00", "20:00")}})
+	busy := seedEventAt(t, c2, dan, 4, false, "2030-06-01", "07:30")
+	seedPlayerEvent(t, dan, busy, 1)
+
+	body := map[string]interface{}{"course_id": c1, "date": "2030-06-01", "player_ids": []int64{bob, cleo, dan}}
+	rr := proposeGame(t, host, body)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.GameProposalsResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if len(resp.Proposals) == 0 || resp.Event != nil {
+		t.Fatalf("expected proposals without an event, got %+v", resp)
+	}
+	best := resp.Proposals[0]
+	if best.TeeTime != "08:00" || best.LatestTeeTime != "08:30" {
+		t.Errorf("expected 08:00 to 08:30, got %+v", best)
+	}
+	if fmt.Sprint(best.Available) != fmt.Sprint([]int64{host, bob, cleo}) || fmt.Sprint(best.Unavailable) != fmt.Sprint([]int64{dan}) || best.PreferCourse != 1 {
+		t.Errorf("expected everyone but Dan, with Cleo preferring the course, got %+v", best)
+	}
+
+	body["player_ids"] = []int64{bob, stranger}
+	if rr := proposeGame(t, host, body); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a player the host doesn't follow, got %d", rr.Code)
+	}
+	body["player_ids"] = []int64{bob, cleo}
+	body["open_spots"] = 2
+	if rr := proposeGame(t, host, body); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 when the group doesn't fit, got %d", rr.Code)
+	}
+
+	body["open_spots"] = 4
+	body["create"] = true
+	rr = proposeGame(t, host, body)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	resp = model.GameProposalsResponse{}
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if resp.Event == nil || resp.Event.TeeTime != "08:00" || resp.Event.Date != "2030-06-01" || resp.Event.OpenSpots != 4 {
+		t.Fatalf("expected an event at the best tee time, got %+v", resp.Event)
+	}
+	if fmt.Sprint(resp.Event.Accepted) != fmt.Sprint([]int64{host}) || fmt.Sprint(resp.Event.Pending) != fmt.Sprint([]int64{bob, cleo}) {
+		t.Errorf("expected the host accepted and the group invited, got %+v", resp.Event)
+	}
+
+	body["date"] = "2030-06-03" // a Monday nobody is free
+	if rr := proposeGame(t, host, body); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 when nobody is free, got %d", rr.Code)
+	}
+
+	// Bob and Cleo are still free on the Saturday, but the host isn't.
+	setAvailability(t, host, map[string]interface{}{"windows": []map[string]interface{}{{"day": "sunday", "from": "07:00", "to": "18:00"}}})
+	body["date"] = "2030-06-08"
+	if rr := proposeGame(t, host, body); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 when the host isn't free, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestProposeGame_CourseLocalTime(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	bob := seedPlayer(t, "Bob", "bob@test.com", "password")
+	course := seedCourse(t, "Millbrook")
+	testDB.Exec("UPDATE courses SET time_zone = 'Pacific/Kiritimati' WHERE id = $1", course)
+	seedFriendship(t, host, bob)
+	loc, _ := time.LoadLocation("Pacific/Kiritimati")
+	now := time.Now().In(loc)
+	allDay := map[string]interface{}{"windows": []map[string]interface{}{{"day": strings.ToLower(now.Weekday().String()), "from": "00:00", "to": "23:59"}}}
+	setAvailability(t, host, allDay)
+	setAvailability(t, bob, allDay)
+
+	// Kiritimati is 14 hours ahead of UTC, so its today is usually still
+	// tomorrow in UTC.
+	rr := proposeGame(t, host, map[string]interface{}{
+		"course_id":       course,
+		"date":            now.Format("2006-01-02"),
+		"player_ids":      []int64{bob},
+		"number_of_holes": "9",
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.GameProposalsResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	for _, p := range resp.Proposals {
+		if p.TeeTime < now.Format("15:04") {
+			t.Errorf("expected no tee times before %s at the course, got %+v", now.Format("15:04"), p)
+		}
+	}
+}
+
+// ===================== TOURNAMENTS =====================
+
+func createTournament(t *testing.T, authID, courseID int64, playerIDs ...int64) model.TournamentResponse {
+	t.Helper()
+	rr := doAuthRequestWithChiCtx(t, "POST", "/api/v1/tournaments", map[string]interface{}{
+		"name":            "Club Championship",
+		"course_id":       courseID,
+		"number_of_holes": "9",
+		"player_ids":      playerIDs,
+	}, testHandler.CreateTournament, nil, authID)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp model.TournamentResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	return resp
+}
+
+func startTournament(t *testing.T, tournamentID, authID int64) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/tournaments/%d/start", tournamentID), nil,
+		testHandler.StartTournament, map[string]string{"id": fmt.Sprintf("%d", tournamentID)}, authID)
+}
+
+func reportMatch(t *testing.T, tournamentID, matchID, authID int64, body map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/tournaments/%d/matches/%d/result", tournamentID, matchID), body,
+		testHandler.ReportTournamentMatch, map[string]string{"id": fmt.Sprintf("%d", tournamentID), "match_id": fmt.Sprintf("%d", matchID)}, authID)
+}
+
+func decodeTournament(t *testing.T, rr *httptest.ResponseRecorder) model.TournamentResponse {
+	t.Helper()
+	var resp model.TournamentResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	return resp
+}
+
+func TestTournaments_RegistrationAndDraw(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	var players []int64
+	for i, handicap := range []float64{12, 3, 20, 8, 15} {
+		p := seedPlayer(t, fmt.Sprintf("Player %d", i), fmt.Sprintf("p%d@test.com", i), "password")
+		seedHandicap(t, p, handicap)
+		players = append(players, p)
+	}
+	tournament := createTournament(t, host, c1, players[:4]...)
+	if tournament.Status != "registration" || len(tournament.Players) != 4 {
+		t.Fatalf("expected four players registered, got %+v", tournament)
+	}
+	params := map[string]string{"id": fmt.Sprintf("%d", tournament.ID)}
+
+	register := func(playerID, authID int64) *httptest.ResponseRecorder {
+		return doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/tournaments/%d/players", tournament.ID), map[string]interface{}{
+			"player_id": playerID,
+		}, testHandler.RegisterTournamentPlayer, params, authID)
+	}
+	if rr := register(players[4], players[0]); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 registering someone else, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := register(players[4], players[4]); rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := register(players[4], players[4]); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 registering twice, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	if rr := startTournament(t, tournament.ID, players[0]); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a non-host, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr := startTournament(t, tournament.ID, host)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	resp := decodeTournament(t, rr)
+	if resp.Status != "in_progress" {
+		t.Errorf("expected in_progress, got %q", resp.Status)
+	}
+
+	// Seeds by handicap: 3, 8, 12, 15, 20.
+	wantSeeds := map[int64]int32{players[1]: 1, players[3]: 2, players[0]: 3, players[4]: 4, players[2]: 5}
+	for _, p := range resp.Players {
+		if p.Seed == nil || *p.Seed != wantSeeds[p.PlayerID] {
+			t.Errorf("player %d: expected seed %d, got %v", p.PlayerID, wantSeeds[p.PlayerID], p.Seed)
+		}
+	}
+
+	// Five players fill an eight slot bracket, so the top three seeds have
+	// byes and 4 plays 5.
+	if len(resp.Rounds) != 3 || len(resp.Rounds[0].Matches) != 4 || len(resp.Rounds[1].Matches) != 2 || len(resp.Rounds[2].Matches) != 1 {
+		t.Fatalf("expected rounds of 4, 2 and 1 matches, got %+v", resp.Rounds)
+	}
+	var byes int
+	for _, m := range resp.Rounds[0].Matches {
+		if m.Result == "bye" {
+			byes++
+			if m.Player2ID != nil || m.WinnerID == nil || *m.WinnerID != *m.Player1ID {
+				t.Errorf("expected the bye to go to player 1, got %+v", m)
+			}
+		}
+	}
+	if byes != 3 {
+		t.Errorf("expected 3 byes, got %d", byes)
+	}
+	semi := resp.Rounds[1].Matches
+	if semi[0].Player1ID == nil || *semi[0].Player1ID != players[1] || semi[0].Player2ID != nil {
+		t.Errorf("expected the top seed waiting on 4 v 5, got %+v", semi[0])
+	}
+	if semi[1].Player1ID == nil || semi[1].Player2ID == nil || *semi[1].Player1ID != players[3] || *semi[1].Player2ID != players[0] {
+		t.Errorf("expected seeds 2 and 3 to meet, got %+v", semi[1])
+	}
+
+	if rr := register(host, host); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 registering after the draw, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := reportMatch(t, tournament.ID, semi[0].ID, host, map[string]interface{}{"winner_id": players[1]}); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 deciding a match still waiting on a player, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestTournaments_MatchesAdvanceWinners(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p1 := seedPlayer(t, "Alice", "alice@test.com", "password")
 	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
-	seedFriendship(t, p1, p2)
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	seedHandicap(t, p1, 2)
+	seedHandicap(t, p2, 5)
+	seedHandicap(t, p3, 9)
+	c1 := seedCourse(t, "Green Valley")
+	tournament := createTournament(t, host, c1, p1, p2, p3)
+	resp := decodeTournament(t, startTournament(t, tournament.ID, host))
+	semi := resp.Rounds[0].Matches[1]
+	final := resp.Rounds[1].Matches[0]
+	matchParams := map[string]string{"id": fmt.Sprintf("%d", tournament.ID), "match_id": fmt.Sprintf("%d", semi.ID)}
+
+	rr := doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/tournaments/%d/matches/%d/schedule", tournament.ID, semi.ID), map[string]interface{}{
+		"date":     "2030-06-04",
+		"tee_time": "08:00",
+	}, testHandler.ScheduleTournamentMatch, matchParams, p1)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a player not in the match, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = doAuthRequestWithChiCtx(t, "POST", fmt.Sprintf("/api/v1/tournaments/%d/matches/%d/schedule", tournament.ID, semi.ID), map[string]interface{}{
+		"date":     "2030-06-04",
+		"tee_time": "08:00",
+	}, testHandler.ScheduleTournamentMatch, matchParams, p3)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	resp = decodeTournament(t, rr)
+	eventID := resp.Rounds[0].Matches[1].EventID
+	if eventID == nil {
+		t.Fatalf("expected the match to have an event, got %+v", resp.Rounds[0].Matches[1])
+	}
+	var hostID int64
+	var private bool
+	testDB.QueryRow("SELECT host_id, private FROM events WHERE id = $1", *eventID).Scan(&hostID, &private)
+	if hostID != p2 || !private {
+		t.Errorf("expected a private event hosted by the first player, got host %d private %v", hostID, private)
+	}
 
-	body := map[string]int64{
-		"follower_id": p1,
-		"followee_id": p2,
+	if rr := reportMatch(t, tournament.ID, semi.ID, p2, map[string]interface{}{}); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 before the round is completed, got %d: %s", rr.Code, rr.Body.String())
 	}
 
-	rr := doRequest(t, "DELETE", "/api/v1/friendship", body, testHandler.DeleteFriendship)
+	testDB.Exec("UPDATE player_events SET invite_status = 1 WHERE event_id = $1", *eventID)
+	setEventStatus(t, *eventID, 1)
+	playNine(t, *eventID, p2, 4, 4, 4, 4, 4, 4, 4, 4, 4)
+	playNine(t, *eventID, p3, 4, 4, 4, 4, 4, 4, 4, 4)
+	setEventStatus(t, *eventID, 2)
+
+	if rr := reportMatch(t, tournament.ID, semi.ID, p2, map[string]interface{}{}); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 while Cleo's card is missing a hole, got %d: %s", rr.Code, rr.Body.String())
+	}
+	saveScores(t, *eventID, p3, p3, []map[string]interface{}{{"hole": 9, "strokes": 3, "par": 4}})
 
+	if rr := reportMatch(t, tournament.ID, semi.ID, p2, map[string]interface{}{"winner_id": p2}); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a player naming the winner, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = reportMatch(t, tournament.ID, semi.ID, p2, map[string]interface{}{})
 	if rr.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d", rr.Code)
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	resp = decodeTournament(t, rr)
+	if m := resp.Rounds[0].Matches[1]; m.Result != "played" || m.WinnerID == nil || *m.WinnerID != p3 {
+		t.Errorf("expected Cleo to win on the last hole, got %+v", m)
+	}
+	if m := resp.Rounds[1].Matches[0]; m.Player1ID == nil || m.Player2ID == nil || *m.Player1ID != p1 || *m.Player2ID != p3 {
+		t.Errorf("expected Alice to meet Cleo in the final, got %+v", m)
+	}
+	if rr := reportMatch(t, tournament.ID, semi.ID, p2, map[string]interface{}{}); rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 deciding a match twice, got %d: %s", rr.Code, rr.Body.String())
 	}
 
-	var count int
-	testDB.QueryRow("SELECT COUNT(*) FROM friendships WHERE follower_id = $1 AND followee_id = $2", p1, p2).Scan(&count)
-	if count != 0 {
-		t.Error("friendship should be deleted")
+	if rr := reportMatch(t, tournament.ID, final.ID, host, map[string]interface{}{"winner_id": p2, "walkover": true}); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a winner not in the match, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = reportMatch(t, tournament.ID, final.ID, host, map[string]interface{}{"winner_id": p3, "walkover": true})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	resp = decodeTournament(t, rr)
+	if resp.Status != "completed" || resp.WinnerID == nil || *resp.WinnerID != p3 {
+		t.Errorf("expected Cleo to win the tournament, got %+v", resp)
+	}
+	if m := resp.Rounds[1].Matches[0]; m.Result != "walkover" {
+		t.Errorf("expected the final to be a walkover, got %+v", m)
 	}
 }
 
@@ -815,6 +4644,216 @@ func TestUpdatePlayerEvent_Decline(t *testing.T) {
 	}
 }
 
+func TestUpdatePlayerEvent_AcceptWhenFull(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 1, false) // only the host's spot
+
+	seedPlayerEvent(t, host, eid, 1) // host accepted (full)
+	seedPlayerEvent(t, p2, eid, 3)   // closed
+
+	body := map[string]interface{}{
+		"player_id":     p2,
+		"event_id":      eid,
+		"invite_status": "accepted",
+	}
+
+	rr := doRequest(t, "PATCH", "/api/v1/player-event", body, testHandler.UpdatePlayerEvent)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestUpdatePlayerEvent_PublicWithoutInvite(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 3, false)
+	seedPlayerEvent(t, host, eid, 1) // host accepted
+
+	for _, tt := range []struct {
+		playerID int64
+		status   string
+	}{{p2, "accepted"}, {p3, "declined"}} {
+		body := map[string]interface{}{
+			"player_id":     tt.playerID,
+			"event_id":      eid,
+			"invite_status": tt.status,
+		}
+
+		rr := doRequest(t, "PATCH", "/api/v1/player-event", body, testHandler.UpdatePlayerEvent)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		var pe model.PlayerEventResponse
+		json.NewDecoder(rr.Body).Decode(&pe)
+		if pe.InviteStatus != tt.status {
+			t.Errorf("expected status '%s', got '%s'", tt.status, pe.InviteStatus)
+		}
+	}
+}
+
+func TestUpdatePlayerEvent_PrivateWithoutInvite(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 3, true)
+	seedPlayerEvent(t, host, eid, 1) // host accepted
+
+	body := map[string]interface{}{
+		"player_id":     p2,
+		"event_id":      eid,
+		"invite_status": "accepted",
+	}
+
+	rr := doRequest(t, "PATCH", "/api/v1/player-event", body, testHandler.UpdatePlayerEvent)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestJoinEvent_Success(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	p3 := seedPlayer(t, "Cleo", "cleo@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 2, false)
+
+	seedPlayerEvent(t, host, eid, 1) // host accepted
+	seedPlayerEvent(t, p3, eid, 0)   // pending
+
+	body := map[string]interface{}{
+		"player_id": p2,
+		"event_id":  eid,
+	}
+
+	rr := doRequest(t, "POST", "/api/v1/player-event/join", body, testHandler.JoinEvent)
+
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// p3 should now be closed (event is full)
+	var status int
+	testDB.QueryRow("SELECT invite_status FROM player_events WHERE player_id = $1 AND event_id = $2", p3, eid).Scan(&status)
+	if status != 3 {
+		t.Errorf("expected p3 status to be 3 (closed), got %d", status)
+	}
+}
+
+func TestJoinEvent_AlreadyJoined(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 3, false)
+	seedPlayerEvent(t, host, eid, 1)
+
+	body := map[string]interface{}{
+		"player_id": host,
+		"event_id":  eid,
+	}
+
+	rr := doRequest(t, "POST", "/api/v1/player-event/join", body, testHandler.JoinEvent)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", rr.Code)
+	}
+}
+
+func TestJoinEvent_ConcurrentNoOverbooking(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	eid := seedEvent(t, c1, host, 4, false) // a foursome
+	seedPlayerEvent(t, host, eid, 1)
+
+	const joiners = 20
+	playerIDs := make([]int64, joiners)
+	for i := range playerIDs {
+		playerIDs[i] = seedPlayer(t, fmt.Sprintf("Player%d", i), fmt.Sprintf("p%d@test.com", i), "password")
+	}
+
+	codes := make([]int, joiners)
+	var wg sync.WaitGroup
+	for i, pid := range playerIDs {
+		wg.Add(1)
+		go func(i int, pid int64) {
+			defer wg.Done()
+			body := map[string]interface{}{
+				"player_id": pid,
+				"event_id":  eid,
+			}
+			var buf bytes.Buffer
+			json.NewEncoder(&buf).Encode(body)
+			req := httptest.NewRequest("POST", "/api/v1/player-event/join", &buf)
+			rr := httptest.NewRecorder()
+			testHandler.JoinEvent(rr, req)
+			codes[i] = rr.Code
+		}(i, pid)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+		default:
+			t.Errorf("unexpected status %d", code)
+		}
+	}
+	if created != 3 {
+		t.Errorf("expected exactly 3 successful joins, got %d", created)
+	}
+
+	var accepted int
+	testDB.QueryRow("SELECT COUNT(*) FROM player_events WHERE event_id = $1 AND invite_status = 1", eid).Scan(&accepted)
+	if accepted != 4 {
+		t.Errorf("expected 4 accepted players, got %d", accepted)
+	}
+}
+
+func TestJoinEvent_ConcurrentNoDoubleBooking(t *testing.T) {
+	cleanDB(t)
+	host := seedPlayer(t, "Host", "host@test.com", "password")
+	p2 := seedPlayer(t, "Bob", "bob@test.com", "password")
+	c1 := seedCourse(t, "Green Valley")
+	morning := seedEventAt(t, c1, host, 4, false, "2030-06-01", "08:00")
+	later := seedEventAt(t, c1, host, 4, false, "2030-06-01", "09:00")
+
+	codes := make([]int, 2)
+	var wg sync.WaitGroup
+	for i, eid := range []int64{morning, later} {
+		wg.Add(1)
+		go func(i int, eid int64) {
+			defer wg.Done()
+			var buf bytes.Buffer
+			json.NewEncoder(&buf).Encode(map[string]interface{}{"player_id": p2, "event_id": eid})
+			req := httptest.NewRequest("POST", "/api/v1/player-event/join", &buf)
+			rr := httptest.NewRecorder()
+			testHandler.JoinEvent(rr, req)
+			codes[i] = rr.Code
+		}(i, eid)
+	}
+	wg.Wait()
+
+	var accepted int
+	testDB.QueryRow("SELECT COUNT(*) FROM player_events WHERE player_id = $1 AND invite_status = 1", p2).Scan(&accepted)
+	if accepted != 1 {
+		t.Errorf("expected Bob in only one of the overlapping rounds, got %d (statuses %v)", accepted, codes)
+	}
+}
+
 // ===================== PLAYER WITH DETAILS =====================
 
 func TestPlayerResponse_IncludesFriendsAndEvents(t *testing.T) {
@@ -878,3 +4917,55 @@ func TestSessionResponse_IncludesFriendsAndEvents(t *testing.T) {
 		t.Errorf("expected 1 event in login response, got %d", len(login.Events))
 	}
 }
+
+// ===================== BENCHMARKS =====================
+
+func seedBenchmarkEvents(b *testing.B, n int) []int64 {
+	b.Helper()
+	cleanDB(b)
+	host := seedPlayer(b, "Host", "host@test.com", "password")
+	c1 := seedCourse(b, "Green Valley")
+	players := make([]int64, 8)
+	for i := range players {
+		players[i] = seedPlayer(b, fmt.Sprintf("Player%d", i), fmt.Sprintf("p%d@test.com", i), "password")
+	}
+
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = seedEvent(b, c1, host, 4, false)
+		seedPlayerEvent(b, host, ids[i], 1)
+		for j, pid := range players {
+			seedPlayerEvent(b, pid, ids[i], j%4)
+		}
+	}
+	return ids
+}
+
+// BenchmarkBuildEventResponse_PerEvent loads 100 events one at a time, the
+// way ListEvents used to.
+func BenchmarkBuildEventResponse_PerEvent(b *testing.B) {
+	ids := seedBenchmarkEvents(b, 100)
+	req := httptest.NewRequest("GET", "/api/v1/events", nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			if _, err := testHandler.buildEventResponse(req, id); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkBuildEventResponses_Batched loads the same 100 events in one batch.
+func BenchmarkBuildEventResponses_Batched(b *testing.B) {
+	ids := seedBenchmarkEvents(b, 100)
+	req := httptest.NewRequest("GET", "/api/v1/events", nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := testHandler.buildEventResponses(req, ids); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+type hostPlayerRequest struct {
+	PlayerID int64 `json:"player_id"`
+}
+
+// requireEventHost loads the event and checks the authenticated player hosts
+// it, writing the error response when they don't.
+func (h *Handler) requireEventHost(w http.ResponseWriter, r *http.Request, eventID int64) bool {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return false
+	}
+
+	event, err := h.queries.GetEventByID(r.Context(), eventID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return false
+	}
+	if int64(event.HostID.Int32) != authID {
+		respondError(w, http.StatusForbidden, "forbidden", "Only the host can do that")
+		return false
+	}
+	return true
+}
+
+// requireEventManager is requireEventHost but also lets co-hosts through.
+func (h *Handler) requireEventManager(w http.ResponseWriter, r *http.Request, eventID int64) bool {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return false
+	}
+
+	if _, err := h.queries.GetEventByID(r.Context(), eventID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return false
+	}
+	isManager, err := h.queries.IsEventManager(r.Context(), store.IsEventManagerParams{
+		EventID:  eventID,
+		PlayerID: authID,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to check permissions")
+		return false
+	}
+	if !isManager {
+		respondError(w, http.StatusForbidden, "forbidden", "Only the host or a co-host can manage this event")
+		return false
+	}
+	return true
+}
+
+// requireEventParticipant checks the logged-in player is the event's host or
+// an invitee, for things only the people in the round should see, like its
+// thread and expenses. When playerID is set it must be the logged-in player,
+// so no one can act as someone else.
+func (h *Handler) requireEventParticipant(w http.ResponseWriter, r *http.Request, eventID, playerID int64) bool {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return false
+	}
+	if playerID != 0 && playerID != authID {
+		respondError(w, http.StatusForbidden, "forbidden", "You can only act as yourself")
+		return false
+	}
+
+	if _, err := h.queries.GetEventByID(r.Context(), eventID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return false
+	}
+	isParticipant, err := h.queries.IsEventParticipant(r.Context(), store.IsEventParticipantParams{
+		EventID:  eventID,
+		PlayerID: authID,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to check permissions")
+		return false
+	}
+	if !isParticipant {
+		respondError(w, http.StatusForbidden, "forbidden", "Only the host and invitees have access to this event")
+		return false
+	}
+	return true
+}
+
+func respondHostError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrPlayerNotAccepted):
+		respondError(w, http.StatusConflict, "conflict", "Player must have accepted the event")
+	case errors.Is(err, store.ErrEventCancelled):
+		respondError(w, http.StatusConflict, "conflict", "Event is cancelled")
+	case errors.Is(err, store.ErrEventCompleted):
+		respondError(w, http.StatusConflict, "conflict", "Event has already been played")
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+	default:
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to update hosts")
+	}
+}
+
+// decodeHostPlayer parses the event ID and the player named in the body.
+func decodeHostPlayer(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return 0, 0, false
+	}
+
+	var req hostPlayerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return 0, 0, false
+	}
+	if req.PlayerID <= 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Player can't be blank")
+		return 0, 0, false
+	}
+	return eventID, req.PlayerID, true
+}
+
+// TransferEventHost hands hosting to another player who has accepted. Only
+// the current host can transfer the event.
+func (h *Handler) TransferEventHost(w http.ResponseWriter, r *http.Request) {
+	eventID, playerID, ok := decodeHostPlayer(w, r)
+	if !ok {
+		return
+	}
+	if !h.requireEventHost(w, r, eventID) {
+		return
+	}
+
+	if err := store.TransferEventHost(r.Context(), h.db, h.queries, store.TransferEventHostParams{
+		EventID:   eventID,
+		NewHostID: playerID,
+	}); err != nil {
+		respondHostError(w, err)
+		return
+	}
+
+	resp, err := h.buildEventResponse(r, eventID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build event response")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// AddEventCohost lets the host share edit and cancel permissions with an
+// accepted player.
+func (h *Handler) AddEventCohost(w http.ResponseWriter, r *http.Request) {
+	eventID, playerID, ok := decodeHostPlayer(w, r)
+	if !ok {
+		return
+	}
+	if !h.requireEventHost(w, r, eventID) {
+		return
+	}
+
+	if err := store.AddEventCohost(r.Context(), h.db, h.queries, eventID, playerID); err != nil {
+		respondHostError(w, err)
+		return
+	}
+
+	resp, err := h.buildEventResponse(r, eventID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build event response")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// RemoveEventCohost revokes a co-host's permissions. The host can remove any
+// co-host, and co-hosts can step down themselves.
+func (h *Handler) RemoveEventCohost(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+	playerID, err := strconv.ParseInt(chi.URLParam(r, "player_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
+		return
+	}
+
+	if authID, ok := authPlayerID(r); !ok || authID != playerID {
+		if !h.requireEventHost(w, r, eventID) {
+			return
+		}
+	}
+
+	n, err := h.queries.DeleteEventCohost(r.Context(), store.DeleteEventCohostParams{
+		EventID:  eventID,
+		PlayerID: playerID,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to remove co-host")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "not_found", "Co-host not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/auth"
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+type createInviteLinkRequest struct {
+	ExpiresAt string `json:"expires_at"`
+	MaxUses   *int32 `json:"max_uses"`
+}
+
+type redeemInviteRequest struct {
+	AllowConflicts bool `json:"allow_conflicts"`
+}
+
+func optionalTime(t sql.NullTime) *string {
+	if !t.Valid {
+		return nil
+	}
+	s := t.Time.Format(time.RFC3339)
+	return &s
+}
+
+// CreateInviteLink lets the host or a co-host create a shareable link to the
+// event, optionally limited by an expiry time and a maximum number of uses.
+func (h *Handler) CreateInviteLink(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	eventID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	// Both options are optional, so an empty body is allowed.
+	var req createInviteLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+
+	var expiresAt sql.NullTime
+	if req.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "validation_error", "Expires at is invalid")
+			return
+		}
+		if !t.After(time.Now()) {
+			respondError(w, http.StatusBadRequest, "validation_error", "Expires at must be in the future")
+			return
+		}
+		expiresAt = sql.NullTime{Time: t.UTC(), Valid: true}
+	}
+
+	var maxUses sql.NullInt32
+	if req.MaxUses != nil {
+		if *req.MaxUses < 1 {
+			respondError(w, http.StatusBadRequest, "validation_error", "Max uses must be greater than 0")
+			return
+		}
+		maxUses = sql.NullInt32{Int32: *req.MaxUses, Valid: true}
+	}
+
+	if !h.requireEventManager(w, r, eventID) {
+		return
+	}
+	creatorID, _ := authPlayerID(r)
+
+	link, err := h.queries.CreateEventInviteLink(r.Context(), store.CreateEventInviteLinkParams{
+		EventID:   eventID,
+		CreatedBy: creatorID,
+		ExpiresAt: expiresAt,
+		MaxUses:   maxUses,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create invite link")
+		return
+	}
+
+	token, err := auth.GenerateInviteToken(link.ID, h.jwtSecret)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to generate token")
+		return
+	}
+
+	resp := model.InviteLinkResponse{
+		ID:        link.ID,
+		EventID:   link.EventID,
+		Token:     token,
+		URL:       fmt.Sprintf("/api/v1/invites/%s", token),
+		ExpiresAt: optionalTime(link.ExpiresAt),
+		Uses:      link.Uses,
+	}
+	if link.MaxUses.Valid {
+		resp.MaxUses = &link.MaxUses.Int32
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// RevokeInviteLink disables a link so it can no longer be previewed or
+// redeemed. Players who already joined through it keep their spot.
+func (h *Handler) RevokeInviteLink(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+	linkID, err := strconv.ParseInt(chi.URLParam(r, "link_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid invite link ID")
+		return
+	}
+
+	if !h.requireEventManager(w, r, eventID) {
+		return
+	}
+
+	n, err := h.queries.RevokeEventInviteLink(r.Context(), store.RevokeEventInviteLinkParams{
+		ID:      linkID,
+		EventID: eventID,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to revoke invite link")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "not_found", "Invite link not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
+
+// respondInviteError maps the reasons a link can't be used to responses.
+// Expired, revoked and used-up links are 410 Gone.
+func respondInviteError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrInviteExpired):
+		respondError(w, http.StatusGone, "gone", "Invite link has expired")
+	case errors.Is(err, store.ErrInviteUsedUp):
+		respondError(w, http.StatusGone, "gone", "Invite link has no uses left")
+	case errors.Is(err, store.ErrEventCancelled):
+		respondError(w, http.StatusConflict, "conflict", "Event is cancelled")
+	case errors.Is(err, store.ErrEventCompleted):
+		respondError(w, http.StatusConflict, "conflict", "Event has already been played")
+	case errors.Is(err, store.ErrRsvpClosed):
+		respondError(w, http.StatusConflict, "conflict", "The respond by deadline has passed")
+	case errors.Is(err, store.ErrEventFull):
+		respondError(w, http.StatusConflict, "conflict", "Event is full")
+	case errors.Is(err, store.ErrAlreadyJoined):
+		respondError(w, http.StatusConflict, "conflict", "Player is already part of this event")
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "not_found", "Invite not found")
+	default:
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to redeem invite")
+	}
+}
+
+// PreviewInvite shows the event behind an invite token. It doesn't require a
+// login so invitees can see the round before signing up.
+func (h *Handler) PreviewInvite(w http.ResponseWriter, r *http.Request) {
+	linkID, err := auth.ValidateInviteToken(chi.URLParam(r, "token"), h.jwtSecret)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Invite not found")
+		return
+	}
+
+	link, err := h.queries.GetEventInviteLink(r.Context(), linkID)
+	if err != nil {
+		respondInviteError(w, err)
+		return
+	}
+	if err := store.CheckInviteLink(link, time.Now()); err != nil {
+		respondInviteError(w, err)
+		return
+	}
+
+	event, err := h.buildEventResponse(r, link.EventID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Invite not found")
+		return
+	}
+
+	resp := model.InvitePreviewResponse{
+		Event:     *event,
+		ExpiresAt: optionalTime(link.ExpiresAt),
+	}
+	if link.MaxUses.Valid {
+		remaining := link.MaxUses.Int32 - link.Uses
+		resp.UsesRemaining = &remaining
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// RedeemInvite adds the authenticated player to the event as accepted.
+// Players without an account sign up and log in first, then redeem. A
+// schedule conflict is a 409 unless allow_conflicts is set.
+func (h *Handler) RedeemInvite(w http.ResponseWriter, r *http.Request) {
+	playerID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return
+	}
+
+	linkID, err := auth.ValidateInviteToken(chi.URLParam(r, "token"), h.jwtSecret)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Invite not found")
+		return
+	}
+
+	// The body is only needed to accept despite a schedule conflict.
+	var req redeemInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+
+	pe, err := store.RedeemInviteLink(r.Context(), h.db, h.queries, store.RedeemInviteLinkParams{
+		LinkID:         linkID,
+		PlayerID:       playerID,
+		AllowConflicts: req.AllowConflicts,
+	})
+	var conflict *store.ScheduleConflictError
+	if errors.As(err, &conflict) {
+		h.respondScheduleConflict(w, r, conflict.EventID)
+		return
+	}
+	if err != nil {
+		respondInviteError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, model.PlayerEventResponse{
+		ID:           pe.ID,
+		PlayerID:     pe.PlayerID.Int64,
+		EventID:      pe.EventID.Int64,
+		InviteStatus: inviteStatusToString(pe.InviteStatus.Int32),
+	})
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/leaderboard"
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+const (
+	defaultLeaderboardLimit = 25
+	maxLeaderboardLimit     = 100
+)
+
+// leaderboardWindows are how far back each window reaches.
+var leaderboardWindows = map[string]int{
+	"week":  7,
+	"month": 30,
+	"year":  365,
+}
+
+// players.scores_visibility enum: 0=everyone, 1=friends, 2=only_me
+const (
+	scoresVisibleToEveryone int32 = 0
+	scoresVisibleToFriends  int32 = 1
+	scoresVisibleToMe       int32 = 2
+)
+
+func scoresVisibilityToString(visibility int32) string {
+	switch visibility {
+	case scoresVisibleToEveryone:
+		return "everyone"
+	case scoresVisibleToFriends:
+		return "friends"
+	case scoresVisibleToMe:
+		return "only_me"
+	default:
+		return "unknown"
+	}
+}
+
+func scoresVisibilityToInt(visibility string) (int32, bool) {
+	switch visibility {
+	case "everyone":
+		return scoresVisibleToEveryone, true
+	case "friends":
+		return scoresVisibleToFriends, true
+	case "only_me":
+		return scoresVisibleToMe, true
+	default:
+		return 0, false
+	}
+}
+
+// requireScoresVisible checks the logged-in viewer can see the player's
+// scores: anyone when they're visible to everyone, the players the owner
+// follows when they're visible to friends, and always the owner.
+func (h *Handler) requireScoresVisible(w http.ResponseWriter, r *http.Request, playerID int64) bool {
+	visibility, err := h.queries.GetPlayerScoresVisibility(r.Context(), playerID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return false
+	}
+
+	viewerID, ok := authPlayerID(r)
+	if visibility == scoresVisibleToEveryone || (ok && viewerID == playerID) {
+		return true
+	}
+	if visibility == scoresVisibleToFriends && ok {
+		_, err := h.queries.FindFriendship(r.Context(), store.FindFriendshipParams{
+			FollowerID: sql.NullInt32{Int32: int32(playerID), Valid: true},
+			FolloweeID: sql.NullInt32{Int32: int32(viewerID), Valid: true},
+		})
+		if err == nil {
+			return true
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusInternalServerError, "internal_error", "Failed to check permissions")
+			return false
+		}
+	}
+	respondError(w, http.StatusForbidden, "forbidden", "This player's scores are private")
+	return false
+}
+
+// encodeLeaderboardCursor packs the last entry on a page into an opaque token
+// for the next request.
+func encodeLeaderboardCursor(key leaderboard.Key) string {
+	raw := fmt.Sprintf("%d:%d:%d", key.Score, key.PlayedAt.UnixNano(), key.PlayerID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeLeaderboardCursor(cursor string) (leaderboard.Key, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return leaderboard.Key{}, err
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return leaderboard.Key{}, fmt.Errorf("malformed cursor")
+	}
+	var nums [3]int64
+	for i, p := range parts {
+		if nums[i], err = strconv.ParseInt(p, 10, 64); err != nil {
+			return leaderboard.Key{}, err
+		}
+	}
+	return leaderboard.Key{Score: int(nums[0]), PlayedAt: time.Unix(0, nums[1]).UTC(), PlayerID: nums[2]}, nil
+}
+
+type leaderboardQuery struct {
+	net    bool
+	limit  int
+	cursor *leaderboard.Key
+}
+
+// parseLeaderboard reads the scoring, window and paging options shared by
+// every leaderboard into params.
+func parseLeaderboard(r *http.Request, params *store.ListLeaderboardRoundsParams) (leaderboardQuery, error) {
+	query := r.URL.Query()
+	lq := leaderboardQuery{limit: defaultLeaderboardLimit}
+
+	switch query.Get("scoring") {
+	case "", "gross":
+	case "net":
+		lq.net = true
+	default:
+		return lq, fmt.Errorf("Invalid scoring")
+	}
+
+	if v := query.Get("window"); v != "" && v != "all" {
+		days, ok := leaderboardWindows[v]
+		if !ok {
+			return lq, fmt.Errorf("Invalid window")
+		}
+		params.PlayedFrom = sql.NullTime{Time: today().AddDate(0, 0, -days), Valid: true}
+	}
+
+	if v := query.Get("cursor"); v != "" {
+		key, err := decodeLeaderboardCursor(v)
+		if err != nil {
+			return lq, fmt.Errorf("Invalid cursor")
+		}
+		lq.cursor = &key
+	}
+
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return lq, fmt.Errorf("Invalid limit")
+		}
+		lq.limit = min(n, maxLeaderboardLimit)
+	}
+
+	if authID, ok := authPlayerID(r); ok {
+		params.ViewerID = authID
+	}
+	return lq, nil
+}
+
+// respondLeaderboard ranks the rounds and writes one page of the board. When
+// more entries follow, the next cursor is returned in the X-Next-Cursor
+// header.
+func (h *Handler) respondLeaderboard(w http.ResponseWriter, r *http.Request, params store.ListLeaderboardRoundsParams, lq leaderboardQuery) {
+	rounds, names, err := store.LeaderboardRounds(r.Context(), h.queries, params)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch leaderboard")
+		return
+	}
+
+	entries := leaderboard.Rank(rounds, lq.net)
+	if lq.cursor != nil {
+		entries = leaderboard.After(entries, *lq.cursor)
+	}
+	if len(entries) > lq.limit {
+		entries = entries[:lq.limit]
+		w.Header().Set("X-Next-Cursor", encodeLeaderboardCursor(entries[len(entries)-1].Key()))
+	}
+
+	resp := make([]model.LeaderboardEntryResponse, len(entries))
+	for i, e := range entries {
+		resp[i] = model.LeaderboardEntryResponse{
+			Position:   e.Position,
+			PlayerID:   e.PlayerID,
+			PlayerName: names[e.PlayerID],
+			Score:      e.Score,
+			Gross:      e.Gross,
+			Net:        e.Net,
+			EventID:    e.EventID,
+			CourseID:   e.CourseID,
+			PlayedAt:   e.PlayedAt.Format(time.RFC3339),
+		}
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetCourseLeaderboard ranks each player's best complete 18 hole round at the
+// course, gross by default or net with scoring=net, over the last week,
+// month or year with window. Only rounds the logged-in player may see are
+// included.
+func (h *Handler) GetCourseLeaderboard(w http.ResponseWriter, r *http.Request) {
+	courseID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid course ID")
+		return
+	}
+
+	params := store.ListLeaderboardRoundsParams{CourseID: sql.NullInt32{Int32: int32(courseID), Valid: true}}
+	lq, err := parseLeaderboard(r, &params)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	if _, err := h.queries.GetCourseByID(r.Context(), courseID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Course not found")
+		return
+	}
+
+	h.respondLeaderboard(w, r, params, lq)
+}
+
+// GetFriendsLeaderboard ranks the player against the players they follow,
+// with the same options as the course leaderboard.
+func (h *Handler) GetFriendsLeaderboard(w http.ResponseWriter, r *http.Request) {
+	pid, err := strconv.ParseInt(chi.URLParam(r, "player_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
+		return
+	}
+
+	params := store.ListLeaderboardRoundsParams{}
+	lq, err := parseLeaderboard(r, &params)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+	if v := r.URL.Query().Get("course_id"); v != "" {
+		courseID, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "bad_request", "Invalid course_id")
+			return
+		}
+		params.CourseID = sql.NullInt32{Int32: int32(courseID), Valid: true}
+	}
+
+	if _, err := h.queries.GetPlayerByID(r.Context(), pid); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+	followees, err := h.queries.ListFolloweeIDsByFollowerID(r.Context(), sql.NullInt32{Int32: int32(pid), Valid: true})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch friends")
+		return
+	}
+	params.PlayerIds = []int64{pid}
+	for _, id := range followees {
+		if id.Valid {
+			params.PlayerIds = append(params.PlayerIds, int64(id.Int32))
+		}
+	}
+
+	h.respondLeaderboard(w, r, params, lq)
+}
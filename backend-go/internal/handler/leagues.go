@@ -0,0 +1,464 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/recurrence"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+// defaultFinishPoints are awarded for the top eight places when a season
+// doesn't set its own points.
+var defaultFinishPoints = []int32{10, 8, 6, 5, 4, 3, 2, 1}
+
+type createLeagueRequest struct {
+	Name string `json:"name"`
+}
+
+type leagueMemberRequest struct {
+	PlayerID int64 `json:"player_id"`
+}
+
+type createSeasonRequest struct {
+	Name             string  `json:"name"`
+	CourseID         int64   `json:"course_id"`
+	StartsOn         string  `json:"starts_on"`
+	TeeTime          string  `json:"tee_time"`
+	OpenSpots        int32   `json:"open_spots"`
+	NumberOfHoles    string  `json:"number_of_holes"`
+	RRule            string  `json:"rrule"`
+	Net              bool    `json:"net"`
+	FinishPoints     []int32 `json:"finish_points"`
+	MatchWinPoints   int32   `json:"match_win_points"`
+	MatchHalvePoints int32   `json:"match_halve_points"`
+}
+
+// requireLeagueCommissioner loads the league and checks the logged-in player
+// runs it.
+func (h *Handler) requireLeagueCommissioner(w http.ResponseWriter, r *http.Request, leagueID int64) bool {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return false
+	}
+
+	league, err := h.queries.GetLeague(r.Context(), leagueID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "League not found")
+		return false
+	}
+	if league.CommissionerID != authID {
+		respondError(w, http.StatusForbidden, "forbidden", "Only the commissioner can do that")
+		return false
+	}
+	return true
+}
+
+// requireLeagueMember checks the logged-in player belongs to the league.
+func (h *Handler) requireLeagueMember(w http.ResponseWriter, r *http.Request, leagueID int64) bool {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return false
+	}
+
+	if _, err := h.queries.GetLeague(r.Context(), leagueID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "League not found")
+		return false
+	}
+	isMember, err := h.queries.IsLeagueMember(r.Context(), store.IsLeagueMemberParams{
+		LeagueID: leagueID,
+		PlayerID: authID,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to check membership")
+		return false
+	}
+	if !isMember {
+		respondError(w, http.StatusForbidden, "forbidden", "Only league members can see this")
+		return false
+	}
+	return true
+}
+
+func (h *Handler) respondLeague(w http.ResponseWriter, r *http.Request, leagueID int64, status int) {
+	league, err := h.queries.GetLeague(r.Context(), leagueID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "League not found")
+		return
+	}
+	members, err := h.queries.ListLeagueMemberIDs(r.Context(), leagueID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch members")
+		return
+	}
+	seasons, err := h.queries.ListLeagueSeasons(r.Context(), leagueID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch seasons")
+		return
+	}
+	seasonIDs := make([]int64, len(seasons))
+	for i, s := range seasons {
+		seasonIDs[i] = s.ID
+	}
+	events, err := h.queries.ListLeagueSeasonEvents(r.Context(), seasonIDs)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch season events")
+		return
+	}
+	eventIDs := map[int64][]int64{}
+	for _, e := range events {
+		eventIDs[e.LeagueSeasonID.Int64] = append(eventIDs[e.LeagueSeasonID.Int64], e.ID)
+	}
+
+	resp := model.LeagueResponse{
+		ID:             league.ID,
+		Name:           league.Name,
+		CommissionerID: league.CommissionerID,
+		MemberIDs:      members,
+		Seasons:        make([]model.LeagueSeasonResponse, len(seasons)),
+	}
+	if resp.MemberIDs == nil {
+		resp.MemberIDs = []int64{}
+	}
+	for i, s := range seasons {
+		resp.Seasons[i] = model.LeagueSeasonResponse{
+			ID:               s.ID,
+			Name:             s.Name,
+			CourseID:         s.CourseID,
+			StartsOn:         s.StartsOn.Format(dateLayout),
+			TeeTime:          s.TeeTime,
+			OpenSpots:        s.OpenSpots,
+			NumberOfHoles:    s.NumberOfHoles,
+			RRule:            s.Rrule,
+			Net:              s.Net,
+			FinishPoints:     s.FinishPoints,
+			MatchWinPoints:   s.MatchWinPoints,
+			MatchHalvePoints: s.MatchHalvePoints,
+			EventIDs:         eventIDs[s.ID],
+		}
+		if resp.Seasons[i].FinishPoints == nil {
+			resp.Seasons[i].FinishPoints = []int32{}
+		}
+		if resp.Seasons[i].EventIDs == nil {
+			resp.Seasons[i].EventIDs = []int64{}
+		}
+	}
+
+	respondJSON(w, status, resp)
+}
+
+// CreateLeague starts a league run by the logged-in player.
+func (h *Handler) CreateLeague(w http.ResponseWriter, r *http.Request) {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return
+	}
+
+	var req createLeagueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Name can't be blank")
+		return
+	}
+
+	league, err := store.CreateLeague(r.Context(), h.db, h.queries, req.Name, authID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create league")
+		return
+	}
+
+	h.respondLeague(w, r, league.ID, http.StatusCreated)
+}
+
+// GetLeague returns the league with its members and seasons.
+func (h *Handler) GetLeague(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid league ID")
+		return
+	}
+
+	if !h.requireLeagueMember(w, r, leagueID) {
+		return
+	}
+
+	h.respondLeague(w, r, leagueID, http.StatusOK)
+}
+
+// AddLeagueMember lets the commissioner add a player, who is invited to the
+// league's upcoming events.
+func (h *Handler) AddLeagueMember(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid league ID")
+		return
+	}
+
+	var req leagueMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	if req.PlayerID <= 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Player can't be blank")
+		return
+	}
+
+	if !h.requireLeagueCommissioner(w, r, leagueID) {
+		return
+	}
+	if _, err := h.queries.GetPlayerByID(r.Context(), req.PlayerID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	if err := store.AddLeagueMember(r.Context(), h.db, h.queries, leagueID, req.PlayerID); err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to add member")
+		return
+	}
+
+	h.respondLeague(w, r, leagueID, http.StatusCreated)
+}
+
+// RemoveLeagueMember lets the commissioner remove a member, or a member
+// leave, withdrawing their unanswered invitations to the league's rounds.
+// The commissioner can't leave their own league.
+func (h *Handler) RemoveLeagueMember(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid league ID")
+		return
+	}
+	playerID, err := strconv.ParseInt(chi.URLParam(r, "player_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
+		return
+	}
+
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return
+	}
+	league, err := h.queries.GetLeague(r.Context(), leagueID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "League not found")
+		return
+	}
+	if authID != playerID && authID != league.CommissionerID {
+		respondError(w, http.StatusForbidden, "forbidden", "Only the commissioner can remove other members")
+		return
+	}
+	if playerID == league.CommissionerID {
+		respondError(w, http.StatusConflict, "conflict", "The commissioner can't leave the league")
+		return
+	}
+
+	err = store.RemoveLeagueMember(r.Context(), h.db, h.queries, leagueID, playerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusNotFound, "not_found", "Member not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to remove member")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
+
+// CreateLeagueSeason lets the commissioner add a season. Its schedule is
+// generated into private events hosted by the commissioner with every member
+// invited.
+func (h *Handler) CreateLeagueSeason(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid league ID")
+		return
+	}
+
+	var req createSeasonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Name can't be blank")
+		return
+	}
+	if req.CourseID <= 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Course can't be blank")
+		return
+	}
+	startsOn, err := time.Parse(dateLayout, req.StartsOn)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "validation_error", "Starts on must be a date (YYYY-MM-DD)")
+		return
+	}
+	if !validTeeTime(req.TeeTime) {
+		respondError(w, http.StatusBadRequest, "validation_error", "Tee time must be a time (HH:MM)")
+		return
+	}
+	if req.OpenSpots <= 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Open spots must be greater than 0")
+		return
+	}
+	if req.NumberOfHoles == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Number of holes can't be blank")
+		return
+	}
+	rule, err := recurrence.Parse(req.RRule)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "validation_error", "Invalid rrule: "+err.Error())
+		return
+	}
+	if rule.Count == 0 && rule.Until.IsZero() {
+		respondError(w, http.StatusBadRequest, "validation_error", "Season schedules must end with COUNT or UNTIL")
+		return
+	}
+	if len(store.SeasonDates(startsOn, rule)) == 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Season schedule has no dates")
+		return
+	}
+	if req.MatchWinPoints < 0 || req.MatchHalvePoints < 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Match points can't be negative")
+		return
+	}
+	for _, p := range req.FinishPoints {
+		if p < 0 {
+			respondError(w, http.StatusBadRequest, "validation_error", "Finish points can't be negative")
+			return
+		}
+	}
+	if len(req.FinishPoints) == 0 && req.MatchWinPoints == 0 && req.MatchHalvePoints == 0 {
+		req.FinishPoints = defaultFinishPoints
+	}
+
+	if !h.requireLeagueCommissioner(w, r, leagueID) {
+		return
+	}
+	if _, err := h.queries.GetCourseByID(r.Context(), req.CourseID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Course not found")
+		return
+	}
+
+	if _, err := store.CreateLeagueSeason(r.Context(), h.db, h.queries, store.CreateLeagueSeasonParams{
+		LeagueID:         leagueID,
+		Name:             req.Name,
+		CourseID:         req.CourseID,
+		StartsOn:         startsOn,
+		TeeTime:          req.TeeTime,
+		OpenSpots:        req.OpenSpots,
+		NumberOfHoles:    req.NumberOfHoles,
+		Rrule:            rule.String(),
+		Net:              req.Net,
+		FinishPoints:     req.FinishPoints,
+		MatchWinPoints:   req.MatchWinPoints,
+		MatchHalvePoints: req.MatchHalvePoints,
+	}); err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create season")
+		return
+	}
+
+	h.respondLeague(w, r, leagueID, http.StatusCreated)
+}
+
+// GetLeagueStandings recomputes a season's standings from the scorecards of
+// its completed rounds. The latest season is used unless season_id is given.
+func (h *Handler) GetLeagueStandings(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid league ID")
+		return
+	}
+	var seasonID int64
+	if v := r.URL.Query().Get("season_id"); v != "" {
+		if seasonID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			respondError(w, http.StatusBadRequest, "bad_request", "Invalid season_id")
+			return
+		}
+	}
+
+	if !h.requireLeagueMember(w, r, leagueID) {
+		return
+	}
+
+	seasons, err := h.queries.ListLeagueSeasons(r.Context(), leagueID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch seasons")
+		return
+	}
+	// Seasons are in order, so the last match is the latest.
+	var season *store.LeagueSeason
+	for i := range seasons {
+		if seasonID == 0 || seasons[i].ID == seasonID {
+			season = &seasons[i]
+		}
+	}
+	if season == nil {
+		respondError(w, http.StatusNotFound, "not_found", "Season not found")
+		return
+	}
+
+	members, err := h.queries.ListLeagueMemberIDs(r.Context(), leagueID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch members")
+		return
+	}
+	rounds, standings, err := store.SeasonStandings(r.Context(), h.queries, *season, members)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to calculate standings")
+		return
+	}
+
+	resp := model.LeagueStandingsResponse{
+		LeagueID:  leagueID,
+		SeasonID:  season.ID,
+		Standings: make([]model.LeagueStandingResponse, len(standings)),
+		Rounds:    make([]model.LeagueRoundResponse, len(rounds)),
+	}
+	for i, s := range standings {
+		resp.Standings[i] = model.LeagueStandingResponse{
+			PlayerID:     s.PlayerID,
+			Position:     s.Position,
+			Rounds:       s.Rounds,
+			FinishPoints: s.FinishPoints,
+			MatchPoints:  s.MatchPoints,
+			Points:       s.Points,
+		}
+	}
+	for i, round := range rounds {
+		resp.Rounds[i] = model.LeagueRoundResponse{
+			EventID: round.EventID,
+			Date:    round.Date,
+			Results: make([]model.LeagueRoundResultResponse, len(round.Results)),
+		}
+		for j, res := range round.Results {
+			resp.Rounds[i].Results[j] = model.LeagueRoundResultResponse{
+				PlayerID:     res.PlayerID,
+				Score:        res.Score,
+				Position:     res.Position,
+				FinishPoints: res.FinishPoints,
+				MatchPoints:  res.MatchPoints,
+			}
+		}
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
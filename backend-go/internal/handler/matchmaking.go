@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ericrabun/findfore-go/internal/matchmaking"
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+const (
+	defaultMatchLimit    = 10
+	maxMatchLimit        = 50
+	defaultMatchFlexDays = 1
+	maxMatchFlexDays     = 7
+)
+
+func optionalPoint(lat, lng sql.NullFloat64) *matchmaking.Point {
+	if !lat.Valid || !lng.Valid {
+		return nil
+	}
+	return &matchmaking.Point{Lat: lat.Float64, Lng: lng.Float64}
+}
+
+// ListRecommendedEvents finds public events the player could join around
+// the date, between the from and to tee times. The date defaults to today at
+// the player's home course. Events up to flex_days either side of the window
+// are considered too, scoring lower the further out they are, and events
+// that have already teed off at their course are left out. Events are ranked
+// by how close the player's handicap is to the accepted players', how near
+// the course is to the player's home course, and how many of the players
+// they follow or asked to play with are going. Events with a player either
+// side asked to avoid are left out.
+func (h *Handler) ListRecommendedEvents(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	player, err := h.queries.GetMatchmakingPlayer(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	query := r.URL.Query()
+	date := courseToday(player.TimeZone.String)
+	if v := query.Get("date"); v != "" {
+		d, err := time.Parse(dateLayout, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "bad_request", "Invalid date")
+			return
+		}
+		date = d
+	}
+	from, to := "00:00", "23:59"
+	if v := query.Get("from"); v != "" {
+		from = v
+	}
+	if v := query.Get("to"); v != "" {
+		to = v
+	}
+	if !validTeeTime(from) || !validTeeTime(to) {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid tee time")
+		return
+	}
+	if to < from {
+		respondError(w, http.StatusBadRequest, "validation_error", "The window must end after it starts")
+		return
+	}
+	flexDays := defaultMatchFlexDays
+	if v := query.Get("flex_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > maxMatchFlexDays {
+			respondError(w, http.StatusBadRequest, "bad_request", "Invalid flex_days")
+			return
+		}
+		flexDays = n
+	}
+	limit := defaultMatchLimit
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			respondError(w, http.StatusBadRequest, "bad_request", "Invalid limit")
+			return
+		}
+		limit = min(n, maxMatchLimit)
+	}
+
+	d := date.Format(dateLayout)
+	windowFrom, _ := store.EventStartsAt(d, from)
+	windowTo, _ := store.EventStartsAt(d, to)
+	rows, err := h.queries.ListMatchmakingEvents(r.Context(), store.ListMatchmakingEventsParams{
+		StartsAfter:  windowFrom.AddDate(0, 0, -flexDays),
+		StartsBefore: windowTo.AddDate(0, 0, flexDays).Add(time.Minute),
+		Now:          time.Now().UTC(),
+		PlayerID:     pid,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch events")
+		return
+	}
+
+	events := make([]matchmaking.Event, len(rows))
+	index := make(map[int64]int, len(rows))
+	eventIDs := make([]int64, len(rows))
+	for i, row := range rows {
+		events[i] = matchmaking.Event{
+			ID:       row.ID,
+			StartsAt: row.StartsAt.Time,
+			Location: optionalPoint(row.Latitude, row.Longitude),
+		}
+		index[row.ID] = i
+		eventIDs[i] = row.ID
+	}
+	roster, err := h.queries.ListMatchmakingRosters(r.Context(), store.ListMatchmakingRostersParams{
+		PlayerID: pid,
+		EventIds: eventIDs,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch events")
+		return
+	}
+	for _, p := range roster {
+		e := &events[index[p.EventID.Int64]]
+		e.Roster = append(e.Roster, matchmaking.Player{
+			ID:               p.PlayerID,
+			Handicap:         optionalFloat64(p.HandicapIndex),
+			Followed:         p.Followed,
+			FriendsFollowing: int(p.FriendsFollowing),
+			PlayWith:         p.PlayWith,
+			Avoid:            p.Avoid,
+		})
+	}
+
+	matches := matchmaking.Rank(matchmaking.Search{
+		Handicap: optionalFloat64(player.HandicapIndex),
+		Home:     optionalPoint(player.Latitude, player.Longitude),
+		From:     windowFrom,
+		To:       windowTo,
+	}, events)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	ids := make([]int64, len(matches))
+	for i, m := range matches {
+		ids[i] = m.ID
+	}
+	built, err := h.buildEventResponses(r, ids)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build event response")
+		return
+	}
+	byID := make(map[int64]model.EventResponse, len(built))
+	for _, e := range built {
+		byID[e.ID] = e
+	}
+
+	resp := make([]model.EventMatchResponse, len(matches))
+	for i, m := range matches {
+		resp[i] = model.EventMatchResponse{
+			Event:       byID[m.ID],
+			Score:       m.Score,
+			HandicapGap: m.HandicapGap,
+			DistanceKm:  m.DistanceKm,
+			Friends:     m.Friends,
+			HoursAway:   m.HoursAway,
+		}
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+const notificationsLimit = 50
+
+// requireSelf checks the authenticated player is the one named in the
+// player_id path param, writing the error response when they aren't.
+func requireSelf(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	pid, err := strconv.ParseInt(chi.URLParam(r, "player_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
+		return 0, false
+	}
+
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return 0, false
+	}
+	if authID != pid {
+		respondError(w, http.StatusForbidden, "forbidden", "You can only access your own account")
+		return 0, false
+	}
+	return pid, true
+}
+
+// ListNotifications returns the player's most recent notifications, newest
+// first.
+func (h *Handler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	notifications, err := h.queries.ListNotificationsByPlayerID(r.Context(), store.ListNotificationsByPlayerIDParams{
+		PlayerID: pid,
+		Limit:    notificationsLimit,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch notifications")
+		return
+	}
+
+	resp := make([]model.NotificationResponse, len(notifications))
+	for i, n := range notifications {
+		resp[i] = model.NotificationResponse{
+			ID:        n.ID,
+			Kind:      n.Kind,
+			Message:   n.Message,
+			Read:      n.ReadAt.Valid,
+			CreatedAt: n.CreatedAt.Format(time.RFC3339),
+		}
+		if n.EventID.Valid {
+			resp[i].EventID = &n.EventID.Int64
+		}
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// MarkNotificationRead marks one of the player's notifications as read.
+func (h *Handler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "notification_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid notification ID")
+		return
+	}
+
+	n, err := h.queries.MarkNotificationRead(r.Context(), store.MarkNotificationReadParams{
+		ID:       id,
+		PlayerID: pid,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to update notification")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "not_found", "Notification not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+type pairingPreferenceRequest struct {
+	Preference string `json:"preference"`
+}
+
+func preferenceToString(preference int32) string {
+	switch preference {
+	case preferencePlayWith:
+		return "play_with"
+	case preferenceAvoid:
+		return "avoid"
+	default:
+		return "unknown"
+	}
+}
+
+func preferenceToInt(preference string) int32 {
+	switch preference {
+	case "play_with":
+		return preferencePlayWith
+	case "avoid":
+		return preferenceAvoid
+	default:
+		return -1
+	}
+}
+
+// parseOtherPlayer reads the other_player_id URL param, which can't be the
+// player themselves.
+func parseOtherPlayer(w http.ResponseWriter, r *http.Request, pid int64) (int64, bool) {
+	otherID, err := strconv.ParseInt(chi.URLParam(r, "other_player_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid other_player_id")
+		return 0, false
+	}
+	if otherID == pid {
+		respondError(w, http.StatusBadRequest, "validation_error", "You can't set a preference for yourself")
+		return 0, false
+	}
+	return otherID, true
+}
+
+// ListPairingPreferences returns the player's own play-with and avoid
+// preferences. They're private since other players shouldn't learn who
+// avoids them.
+func (h *Handler) ListPairingPreferences(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.queries.ListPairingPreferencesByPlayerID(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch preferences")
+		return
+	}
+
+	resp := make([]model.PairingPreferenceResponse, len(prefs))
+	for i, p := range prefs {
+		resp[i] = model.PairingPreferenceResponse{
+			OtherPlayerID: p.OtherPlayerID,
+			Preference:    preferenceToString(p.Preference),
+		}
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetPairingPreference records that the player wants to play with, or avoid,
+// another player when groups are generated.
+func (h *Handler) SetPairingPreference(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+	otherID, ok := parseOtherPlayer(w, r, pid)
+	if !ok {
+		return
+	}
+
+	var req pairingPreferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	preference := preferenceToInt(req.Preference)
+	if preference < 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Preference must be play_with or avoid")
+		return
+	}
+
+	if _, err := h.queries.GetPlayerByID(r.Context(), otherID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	pref, err := h.queries.UpsertPairingPreference(r.Context(), store.UpsertPairingPreferenceParams{
+		PlayerID:      pid,
+		OtherPlayerID: otherID,
+		Preference:    preference,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to save preference")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, model.PairingPreferenceResponse{
+		OtherPlayerID: pref.OtherPlayerID,
+		Preference:    preferenceToString(pref.Preference),
+	})
+}
+
+// DeletePairingPreference clears the player's preference about another player.
+func (h *Handler) DeletePairingPreference(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+	otherID, ok := parseOtherPlayer(w, r, pid)
+	if !ok {
+		return
+	}
+
+	n, err := h.queries.DeletePairingPreference(r.Context(), store.DeletePairingPreferenceParams{
+		PlayerID:      pid,
+		OtherPlayerID: otherID,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to delete preference")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "not_found", "Preference not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
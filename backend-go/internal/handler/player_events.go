@@ -3,6 +3,7 @@ package handler
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/ericrabun/findfore-go/internal/model"
@@ -10,9 +11,10 @@ import (
 )
 
 type updatePlayerEventRequest struct {
-	PlayerID     int64  `json:"player_id"`
-	EventID      int64  `json:"event_id"`
-	InviteStatus string `json:"invite_status"`
+	PlayerID       int64  `json:"player_id"`
+	EventID        int64  `json:"event_id"`
+	InviteStatus   string `json:"invite_status"`
+	AllowConflicts bool   `json:"allow_conflicts"`
 }
 
 func inviteStatusToInt(status string) int32 {
@@ -25,6 +27,8 @@ func inviteStatusToInt(status string) int32 {
 		return 2
 	case "closed":
 		return 3
+	case "expired":
+		return 4
 	default:
 		return -1
 	}
@@ -40,11 +44,28 @@ func inviteStatusToString(status int32) string {
 		return "declined"
 	case 3:
 		return "closed"
+	case 4:
+		return "expired"
 	default:
 		return "unknown"
 	}
 }
 
+// respondScheduleConflict is a 409 that includes the already accepted event
+// the player would be double-booked with. Clients can retry with
+// allow_conflicts to accept anyway.
+func (h *Handler) respondScheduleConflict(w http.ResponseWriter, r *http.Request, conflictID int64) {
+	resp := model.ScheduleConflictResponse{
+		Errors: []model.ErrorDetail{
+			{Code: "schedule_conflict", Message: "Player has already accepted an event at this time"},
+		},
+	}
+	if event, err := h.buildEventResponse(r, conflictID); err == nil {
+		resp.ConflictingEvent = event
+	}
+	respondJSON(w, http.StatusConflict, resp)
+}
+
 func (h *Handler) UpdatePlayerEvent(w http.ResponseWriter, r *http.Request) {
 	var req updatePlayerEventRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -57,19 +78,44 @@ func (h *Handler) UpdatePlayerEvent(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "validation_error", "Invalid invite status")
 		return
 	}
+	// Invitations only expire through the respond by deadline.
+	if statusInt == statusExpired {
+		respondError(w, http.StatusBadRequest, "validation_error", "Invite status can't be set to expired")
+		return
+	}
 
-	pe, err := h.queries.UpdatePlayerEventStatus(r.Context(), store.UpdatePlayerEventStatusParams{
-		PlayerID:     sql.NullInt64{Int64: req.PlayerID, Valid: true},
-		EventID:      sql.NullInt64{Int64: req.EventID, Valid: true},
-		InviteStatus: sql.NullInt32{Int32: statusInt, Valid: true},
+	pe, err := store.UpdateInviteStatus(r.Context(), h.db, h.queries, store.UpdateInviteStatusParams{
+		PlayerID:       req.PlayerID,
+		EventID:        req.EventID,
+		InviteStatus:   statusInt,
+		AllowConflicts: req.AllowConflicts,
 	})
-	if err != nil {
+	if errors.Is(err, store.ErrEventFull) {
+		respondError(w, http.StatusConflict, "conflict", "Event is full")
+		return
+	}
+	var conflict *store.ScheduleConflictError
+	if errors.As(err, &conflict) {
+		h.respondScheduleConflict(w, r, conflict.EventID)
+		return
+	}
+	if errors.Is(err, store.ErrEventCancelled) {
+		respondError(w, http.StatusConflict, "conflict", "Event is cancelled")
+		return
+	}
+	if errors.Is(err, store.ErrEventCompleted) {
+		respondError(w, http.StatusConflict, "conflict", "Event has already been played")
+		return
+	}
+	if errors.Is(err, store.ErrRsvpClosed) {
+		respondError(w, http.StatusConflict, "conflict", "The respond by deadline has passed")
+		return
+	}
+	if errors.Is(err, sql.ErrNoRows) {
 		respondError(w, http.StatusNotFound, "not_found", "Player event not found")
 		return
 	}
-
-	// Cascade logic: check if spots are full
-	if err := h.closeOrOpenInvitations(r, req.EventID); err != nil {
+	if err != nil {
 		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to update invitation statuses")
 		return
 	}
@@ -85,8 +131,9 @@ func (h *Handler) UpdatePlayerEvent(w http.ResponseWriter, r *http.Request) {
 }
 
 type joinEventRequest struct {
-	PlayerID int64 `json:"player_id"`
-	EventID  int64 `json:"event_id"`
+	PlayerID       int64 `json:"player_id"`
+	EventID        int64 `json:"event_id"`
+	AllowConflicts bool  `json:"allow_conflicts"`
 }
 
 func (h *Handler) JoinEvent(w http.ResponseWriter, r *http.Request) {
@@ -96,49 +143,42 @@ func (h *Handler) JoinEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check that the player isn't already part of this event
-	_, err := h.queries.GetPlayerEvent(r.Context(), store.GetPlayerEventParams{
-		PlayerID: sql.NullInt64{Int64: req.PlayerID, Valid: true},
-		EventID:  sql.NullInt64{Int64: req.EventID, Valid: true},
+	pe, err := store.JoinEvent(r.Context(), h.db, h.queries, store.JoinEventParams{
+		PlayerID:       req.PlayerID,
+		EventID:        req.EventID,
+		AllowConflicts: req.AllowConflicts,
 	})
-	if err == nil {
+	if errors.Is(err, store.ErrAlreadyJoined) {
 		respondError(w, http.StatusConflict, "conflict", "Player is already part of this event")
 		return
 	}
-
-	// Check remaining spots
-	event, err := h.queries.GetEventByID(r.Context(), req.EventID)
-	if err != nil {
-		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+	if errors.Is(err, store.ErrEventFull) {
+		respondError(w, http.StatusConflict, "conflict", "Event is full")
 		return
 	}
-
-	acceptedCount, err := h.queries.CountAcceptedForEvent(r.Context(), sql.NullInt64{Int64: req.EventID, Valid: true})
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to check event capacity")
+	var conflict *store.ScheduleConflictError
+	if errors.As(err, &conflict) {
+		h.respondScheduleConflict(w, r, conflict.EventID)
 		return
 	}
-
-	remaining := event.OpenSpots.Int32 - int32(acceptedCount)
-	if remaining <= 0 {
-		respondError(w, http.StatusConflict, "conflict", "Event is full")
+	if errors.Is(err, store.ErrEventCancelled) {
+		respondError(w, http.StatusConflict, "conflict", "Event is cancelled")
 		return
 	}
-
-	// Create player_event with accepted status
-	pe, err := h.queries.CreatePlayerEvent(r.Context(), store.CreatePlayerEventParams{
-		PlayerID:     sql.NullInt64{Int64: req.PlayerID, Valid: true},
-		EventID:      sql.NullInt64{Int64: req.EventID, Valid: true},
-		InviteStatus: sql.NullInt32{Int32: statusAccepted, Valid: true},
-	})
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to join event")
+	if errors.Is(err, store.ErrEventCompleted) {
+		respondError(w, http.StatusConflict, "conflict", "Event has already been played")
 		return
 	}
-
-	// Cascade logic: close pending if event is now full
-	if err := h.closeOrOpenInvitations(r, req.EventID); err != nil {
-		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to update invitation statuses")
+	if errors.Is(err, store.ErrRsvpClosed) {
+		respondError(w, http.StatusConflict, "conflict", "The respond by deadline has passed")
+		return
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to join event")
 		return
 	}
 
@@ -151,25 +191,3 @@ func (h *Handler) JoinEvent(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusCreated, resp)
 }
-
-func (h *Handler) closeOrOpenInvitations(r *http.Request, eventID int64) error {
-	event, err := h.queries.GetEventByID(r.Context(), eventID)
-	if err != nil {
-		return err
-	}
-
-	acceptedCount, err := h.queries.CountAcceptedForEvent(r.Context(), sql.NullInt64{Int64: eventID, Valid: true})
-	if err != nil {
-		return err
-	}
-
-	remaining := event.OpenSpots.Int32 - int32(acceptedCount)
-
-	if remaining <= 0 {
-		// Close all pending invitations
-		return h.queries.ClosePendingForEvent(r.Context(), sql.NullInt64{Int64: eventID, Valid: true})
-	}
-
-	// Reopen all closed invitations
-	return h.queries.ReopenClosedForEvent(r.Context(), sql.NullInt64{Int64: eventID, Valid: true})
-}
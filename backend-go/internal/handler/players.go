@@ -3,12 +3,18 @@ package handler
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
 
 	"github.com/ericrabun/findfore-go/internal/auth"
 	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/scoring"
 	"github.com/ericrabun/findfore-go/internal/store"
 )
 
@@ -29,13 +35,14 @@ func (h *Handler) ListPlayers(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		resp[i] = model.PlayerResponse{
-			ID:       details.ID,
-			Name:     details.Name,
-			Phone:    details.Phone,
-			Email:    details.Email,
-			Username: details.Username,
-			Friends:  details.Friends,
-			Events:   details.Events,
+			ID:            details.ID,
+			Name:          details.Name,
+			Phone:         details.Phone,
+			Email:         details.Email,
+			Username:      details.Username,
+			Friends:       details.Friends,
+			Events:        details.Events,
+			HandicapIndex: details.HandicapIndex,
 		}
 	}
 
@@ -123,3 +130,336 @@ func (h *Handler) CreatePlayer(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusCreated, resp)
 }
+
+// GetPlayerStats returns the player's scoring statistics over their complete
+// rounds, optionally between the from and to dates (inclusive) or at one
+// course. Scoring averages are kept apart for 18 and nine hole rounds; putts
+// are per 18 holes. The player's scores visibility applies.
+func (h *Handler) GetPlayerStats(w http.ResponseWriter, r *http.Request) {
+	pid, err := strconv.ParseInt(chi.URLParam(r, "player_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
+		return
+	}
+	if !h.requireScoresVisible(w, r, pid) {
+		return
+	}
+
+	params := store.ListPlayerStatRoundsParams{PlayerID: pid}
+	query := r.URL.Query()
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse(dateLayout, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "bad_request", "Invalid from")
+			return
+		}
+		params.PlayedFrom = sql.NullTime{Time: from, Valid: true}
+	}
+	if v := query.Get("to"); v != "" {
+		to, err := time.Parse(dateLayout, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "bad_request", "Invalid to")
+			return
+		}
+		params.PlayedBefore = sql.NullTime{Time: to.AddDate(0, 0, 1), Valid: true}
+	}
+	if v := query.Get("course_id"); v != "" {
+		courseID, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "bad_request", "Invalid course_id")
+			return
+		}
+		params.CourseID = sql.NullInt32{Int32: int32(courseID), Valid: true}
+	}
+
+	summary, err := store.PlayerStats(r.Context(), h.db, h.queries, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to calculate stats")
+		return
+	}
+
+	resp := model.PlayerStatsResponse{
+		PlayerID:          pid,
+		Rounds:            summary.Rounds,
+		ScoringAverage:    summary.ScoringAverage,
+		NineHoleAverage:   summary.NineHoleAverage,
+		PuttsPerRound:     summary.PuttsPerRound,
+		FairwayPercentage: summary.FairwayPercentage,
+		GIRPercentage:     summary.GIRPercentage,
+		ParAverages:       make([]model.ParAverageResponse, len(summary.ParAverages)),
+		BestRounds:        make([]model.BestRoundResponse, len(summary.BestRounds)),
+		Courses:           make([]model.CourseStatsResponse, len(summary.Courses)),
+	}
+	for i, p := range summary.ParAverages {
+		resp.ParAverages[i] = model.ParAverageResponse{Par: p.Par, Holes: p.Holes, Average: p.Average}
+	}
+	for i, b := range summary.BestRounds {
+		resp.BestRounds[i] = model.BestRoundResponse{
+			EventID:  b.EventID,
+			CourseID: b.CourseID,
+			PlayedAt: b.PlayedAt.Format(time.RFC3339),
+			Strokes:  b.Strokes,
+		}
+		if b.Par != nil {
+			toPar := b.Strokes - *b.Par
+			resp.BestRounds[i].ToPar = &toPar
+		}
+	}
+	for i, c := range summary.Courses {
+		resp.Courses[i] = model.CourseStatsResponse{
+			CourseID:        c.CourseID,
+			Rounds:          c.Rounds,
+			ScoringAverage:  c.ScoringAverage,
+			NineHoleAverage: c.NineHoleAverage,
+		}
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+type updatePrivacyRequest struct {
+	ScoresVisibility string `json:"scores_visibility"`
+}
+
+// GetPrivacy returns who can see the player's rounds on leaderboards.
+func (h *Handler) GetPrivacy(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	visibility, err := h.queries.GetPlayerScoresVisibility(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, model.PrivacyResponse{
+		PlayerID:         pid,
+		ScoresVisibility: scoresVisibilityToString(visibility),
+	})
+}
+
+// UpdatePrivacy sets who can see the player's rounds on leaderboards:
+// everyone, friends (the players they follow) or only_me.
+func (h *Handler) UpdatePrivacy(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	var req updatePrivacyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	visibility, ok := scoresVisibilityToInt(req.ScoresVisibility)
+	if !ok {
+		respondError(w, http.StatusBadRequest, "validation_error", "Scores visibility must be everyone, friends or only_me")
+		return
+	}
+
+	n, err := h.queries.SetPlayerScoresVisibility(r.Context(), store.SetPlayerScoresVisibilityParams{
+		ID:               pid,
+		ScoresVisibility: visibility,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to save privacy settings")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, model.PrivacyResponse{
+		PlayerID:         pid,
+		ScoresVisibility: req.ScoresVisibility,
+	})
+}
+
+type updateHomeCourseRequest struct {
+	CourseID *int64 `json:"course_id"`
+}
+
+// GetHomeCourse returns the course the player usually plays, used to find
+// games nearby.
+func (h *Handler) GetHomeCourse(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	courseID, err := h.queries.GetPlayerHomeCourse(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, model.HomeCourseResponse{
+		PlayerID:     pid,
+		HomeCourseID: optionalInt32(courseID),
+	})
+}
+
+// UpdateHomeCourse sets the player's home course. A null course_id clears it.
+func (h *Handler) UpdateHomeCourse(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	var req updateHomeCourseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+
+	var courseID sql.NullInt32
+	if req.CourseID != nil {
+		if _, err := h.queries.GetCourseByID(r.Context(), *req.CourseID); err != nil {
+			respondError(w, http.StatusNotFound, "not_found", "Course not found")
+			return
+		}
+		courseID = sql.NullInt32{Int32: int32(*req.CourseID), Valid: true}
+	}
+
+	n, err := h.queries.SetPlayerHomeCourse(r.Context(), store.SetPlayerHomeCourseParams{
+		ID:           pid,
+		HomeCourseID: courseID,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to save home course")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, model.HomeCourseResponse{
+		PlayerID:     pid,
+		HomeCourseID: optionalInt32(courseID),
+	})
+}
+
+type updateHandicapRequest struct {
+	HandicapIndex *float64 `json:"handicap_index"`
+}
+
+// GetHandicap returns the player's handicap index with the scores behind it,
+// oldest first, and the index after each. Calculated is false while the
+// index is still self-reported. The player's scores visibility applies.
+func (h *Handler) GetHandicap(w http.ResponseWriter, r *http.Request) {
+	pid, err := strconv.ParseInt(chi.URLParam(r, "player_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
+		return
+	}
+	if !h.requireScoresVisible(w, r, pid) {
+		return
+	}
+
+	index, err := h.queries.GetPlayerHandicapIndex(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+	revisions, err := h.queries.ListHandicapRevisions(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch handicap history")
+		return
+	}
+
+	resp := model.HandicapDetailResponse{
+		PlayerID: pid,
+		Scores:   make([]model.HandicapScoreResponse, len(revisions)),
+	}
+	if index.Valid {
+		resp.HandicapIndex = &index.Float64
+	}
+	differentials := make([]float64, len(revisions))
+	for i, rev := range revisions {
+		differentials[i] = rev.ScoreDifferential
+	}
+	counted := scoring.Counted(differentials)
+	for i, rev := range revisions {
+		resp.Scores[i] = model.HandicapScoreResponse{
+			EventID:            rev.EventID.Int64,
+			PlayedAt:           rev.PlayedAt.Format(time.RFC3339),
+			AdjustedGrossScore: rev.AdjustedGrossScore,
+			ScoreDifferential:  rev.ScoreDifferential,
+			Counted:            counted[i],
+		}
+		if rev.HandicapIndex.Valid {
+			resp.Scores[i].HandicapIndex = &rev.HandicapIndex.Float64
+		}
+	}
+	if n := len(revisions); n > 0 {
+		last := revisions[n-1]
+		resp.Calculated = last.HandicapIndex.Valid
+		if last.LowHandicapIndex.Valid {
+			resp.LowHandicapIndex = &last.LowHandicapIndex.Float64
+		}
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// UpdateHandicap sets the player's self-reported handicap index, which is used
+// to balance generated groups. Plus handicaps are negative. A null index
+// clears it. Once the index is calculated from posted scores it can't be
+// set by hand.
+func (h *Handler) UpdateHandicap(w http.ResponseWriter, r *http.Request) {
+	pid, ok := requireSelf(w, r)
+	if !ok {
+		return
+	}
+
+	var req updateHandicapRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+
+	var index sql.NullFloat64
+	if req.HandicapIndex != nil {
+		if *req.HandicapIndex < -10 || *req.HandicapIndex > 54 {
+			respondError(w, http.StatusBadRequest, "validation_error", "Handicap index must be between +10.0 and 54.0")
+			return
+		}
+		index = sql.NullFloat64{Float64: *req.HandicapIndex, Valid: true}
+	}
+
+	revisions, err := h.queries.ListHandicapRevisions(r.Context(), pid)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch handicap history")
+		return
+	}
+	if n := len(revisions); n > 0 && revisions[n-1].HandicapIndex.Valid {
+		respondError(w, http.StatusConflict, "conflict", "Handicap index is calculated from posted scores")
+		return
+	}
+
+	n, err := h.queries.SetPlayerHandicapIndex(r.Context(), store.SetPlayerHandicapIndexParams{
+		ID:            pid,
+		HandicapIndex: index,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to save handicap")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, model.HandicapResponse{
+		PlayerID:      pid,
+		HandicapIndex: req.HandicapIndex,
+	})
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -47,7 +48,7 @@ func (h *Handler) buildPostResponse(r *http.Request, postRow store.GetPostByIDRo
 		})
 	}
 
-	return &model.PostResponse{
+	resp := &model.PostResponse{
 		ID:         postRow.ID,
 		PlayerID:   postRow.PlayerID,
 		PlayerName: postRow.PlayerName.String,
@@ -55,7 +56,50 @@ func (h *Handler) buildPostResponse(r *http.Request, postRow store.GetPostByIDRo
 		CreatedAt:  postRow.CreatedAt.Format(time.RFC3339),
 		Reactions:  reactionResps,
 		Replies:    replyResps,
-	}, nil
+	}
+	if postRow.EventID.Valid {
+		resp.EventID = &postRow.EventID.Int64
+	}
+	return resp, nil
+}
+
+// requirePostAccess applies requireEventParticipant when the post belongs to an
+// event thread. Global feed posts are open to everyone.
+func (h *Handler) requirePostAccess(w http.ResponseWriter, r *http.Request, postID, playerID int64) bool {
+	post, err := h.queries.GetPostByID(r.Context(), postID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusNotFound, "not_found", "Post not found")
+		return false
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch post")
+		return false
+	}
+	if !post.EventID.Valid {
+		return true
+	}
+	return h.requireEventParticipant(w, r, post.EventID.Int64, playerID)
+}
+
+// postPage reads the limit and offset query params, ignoring bad values.
+func postPage(r *http.Request) (int32, int32) {
+	limitStr := r.URL.Query().Get("limit")
+	offsetStr := r.URL.Query().Get("offset")
+
+	limit := int32(50)
+	offset := int32(0)
+
+	if limitStr != "" {
+		if v, err := strconv.ParseInt(limitStr, 10, 32); err == nil {
+			limit = int32(v)
+		}
+	}
+	if offsetStr != "" {
+		if v, err := strconv.ParseInt(offsetStr, 10, 32); err == nil {
+			offset = int32(v)
+		}
+	}
+	return limit, offset
 }
 
 // --- Posts ---
@@ -63,8 +107,11 @@ func (h *Handler) buildPostResponse(r *http.Request, postRow store.GetPostByIDRo
 type createPostRequest struct {
 	PlayerID int64  `json:"player_id"`
 	Body     string `json:"body"`
+	EventID  *int64 `json:"event_id"`
 }
 
+// CreatePost adds a post to the global feed, or to an event's thread when
+// event_id is given.
 func (h *Handler) CreatePost(w http.ResponseWriter, r *http.Request) {
 	var req createPostRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -81,9 +128,18 @@ func (h *Handler) CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var eventID sql.NullInt64
+	if req.EventID != nil {
+		if !h.requireEventParticipant(w, r, *req.EventID, req.PlayerID) {
+			return
+		}
+		eventID = sql.NullInt64{Int64: *req.EventID, Valid: true}
+	}
+
 	created, err := h.queries.CreatePost(r.Context(), store.CreatePostParams{
 		PlayerID: req.PlayerID,
 		Body:     req.Body,
+		EventID:  eventID,
 	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create post")
@@ -106,26 +162,55 @@ func (h *Handler) CreatePost(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) ListPosts(w http.ResponseWriter, r *http.Request) {
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
+	limit, offset := postPage(r)
 
-	limit := int32(50)
-	offset := int32(0)
+	rows, err := h.queries.ListPosts(r.Context(), store.ListPostsParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch posts")
+		return
+	}
 
-	if limitStr != "" {
-		if v, err := strconv.ParseInt(limitStr, 10, 32); err == nil {
-			limit = int32(v)
+	posts := make([]model.PostResponse, 0, len(rows))
+	for _, row := range rows {
+		postRow := store.GetPostByIDRow{
+			ID:         row.ID,
+			PlayerID:   row.PlayerID,
+			Body:       row.Body,
+			CreatedAt:  row.CreatedAt,
+			PlayerName: row.PlayerName,
 		}
-	}
-	if offsetStr != "" {
-		if v, err := strconv.ParseInt(offsetStr, 10, 32); err == nil {
-			offset = int32(v)
+		resp, err := h.buildPostResponse(r, postRow)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build post response")
+			return
 		}
+		posts = append(posts, *resp)
 	}
 
-	rows, err := h.queries.ListPosts(r.Context(), store.ListPostsParams{
-		Limit:  limit,
-		Offset: offset,
+	respondJSON(w, http.StatusOK, posts)
+}
+
+// ListEventPosts returns an event's discussion thread, newest first. Only the
+// host and invitees can read it.
+func (h *Handler) ListEventPosts(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	if !h.requireEventParticipant(w, r, eventID, 0) {
+		return
+	}
+
+	limit, offset := postPage(r)
+	rows, err := h.queries.ListPostsByEventID(r.Context(), store.ListPostsByEventIDParams{
+		EventID: sql.NullInt64{Int64: eventID, Valid: true},
+		Limit:   limit,
+		Offset:  offset,
 	})
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch posts")
@@ -140,6 +225,7 @@ func (h *Handler) ListPosts(w http.ResponseWriter, r *http.Request) {
 			Body:       row.Body,
 			CreatedAt:  row.CreatedAt,
 			PlayerName: row.PlayerName,
+			EventID:    sql.NullInt64{Int64: eventID, Valid: true},
 		}
 		resp, err := h.buildPostResponse(r, postRow)
 		if err != nil {
@@ -207,6 +293,10 @@ func (h *Handler) ToggleReaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !h.requirePostAccess(w, r, postID, req.PlayerID) {
+		return
+	}
+
 	// Toggle: if exists, delete; if not, create
 	_, err = h.queries.FindReaction(r.Context(), store.FindReactionParams{
 		PostID:   postID,
@@ -285,6 +375,10 @@ func (h *Handler) CreateReply(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !h.requirePostAccess(w, r, postID, req.PlayerID) {
+		return
+	}
+
 	created, err := h.queries.CreateReply(r.Context(), store.CreateReplyParams{
 		PostID:   postID,
 		PlayerID: req.PlayerID,
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/scoring"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+type holeScoreRequest struct {
+	Hole              int32  `json:"hole"`
+	Strokes           int32  `json:"strokes"`
+	Putts             *int32 `json:"putts"`
+	FairwayHit        *bool  `json:"fairway_hit"`
+	GreenInRegulation *bool  `json:"green_in_regulation"`
+	Par               *int32 `json:"par"`
+}
+
+type saveScorecardRequest struct {
+	TeeSetID *int64             `json:"tee_set_id"`
+	Holes    []holeScoreRequest `json:"holes"`
+}
+
+func nullInt32(v *int32) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *v, Valid: true}
+}
+
+func nullBool(v *bool) sql.NullBool {
+	if v == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *v, Valid: true}
+}
+
+func optionalInt32(v sql.NullInt32) *int32 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int32
+}
+
+func optionalBool(v sql.NullBool) *bool {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Bool
+}
+
+func optionalFloat64(v sql.NullFloat64) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
+
+// validateHoleScore checks a hole's numbers are ones a real round could
+// produce. Whether the hole exists on the course is checked when saving.
+func validateHoleScore(h holeScoreRequest) string {
+	if h.Hole < 1 {
+		return "Hole must be 1 or more"
+	}
+	if h.Strokes < 1 {
+		return fmt.Sprintf("Strokes on hole %d must be at least 1", h.Hole)
+	}
+	if h.Putts != nil && (*h.Putts < 0 || *h.Putts > h.Strokes) {
+		return fmt.Sprintf("Putts on hole %d must be between 0 and the strokes taken", h.Hole)
+	}
+	if h.Par != nil && (*h.Par < 3 || *h.Par > 6) {
+		return fmt.Sprintf("Par on hole %d must be between 3 and 6", h.Hole)
+	}
+	return ""
+}
+
+func respondScorecardError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrScorerNotAccepted):
+		respondError(w, http.StatusConflict, "conflict", "Only players who accepted the event have a scorecard")
+	case errors.Is(err, store.ErrRoundNotStarted):
+		respondError(w, http.StatusConflict, "conflict", "Scores can't be entered before the round starts")
+	case errors.Is(err, store.ErrTeeSetNotOnCourse):
+		respondError(w, http.StatusBadRequest, "validation_error", "Tee set isn't on the event's course")
+	case errors.Is(err, store.ErrHoleOutOfRange):
+		respondError(w, http.StatusBadRequest, "validation_error", "Hole is outside the round's number of holes")
+	case errors.Is(err, store.ErrEventCancelled):
+		respondError(w, http.StatusConflict, "conflict", "Event is cancelled")
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+	default:
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to save scorecard")
+	}
+}
+
+// respondEventScores writes every accepted player's scorecard with its
+// totals. Players who haven't entered anything yet get an empty card.
+func (h *Handler) respondEventScores(w http.ResponseWriter, r *http.Request, eventID int64, status int) {
+	event, err := h.queries.GetEventByID(r.Context(), eventID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Event not found")
+		return
+	}
+	cards, err := h.queries.ListScorecardsByEventID(r.Context(), eventID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch scorecards")
+		return
+	}
+	rows, err := h.queries.ListScorecardHolesByEventID(r.Context(), eventID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch scorecards")
+		return
+	}
+
+	holes := make(map[int64][]scoring.Hole, len(cards))
+	for _, row := range rows {
+		pid := row.PlayerID.Int64
+		holes[pid] = append(holes[pid], scoring.Hole{
+			Number:            row.HoleNumber,
+			Strokes:           row.Strokes,
+			Putts:             optionalInt32(row.Putts),
+			FairwayHit:        optionalBool(row.FairwayHit),
+			GreenInRegulation: optionalBool(row.GreenInRegulation),
+			Par:               optionalInt32(row.Par),
+		})
+	}
+
+	resp := model.EventScoresResponse{
+		EventID:       eventID,
+		NumberOfHoles: store.HoleCount(event.NumberOfHoles.String),
+		Scorecards:    make([]model.ScorecardResponse, len(cards)),
+	}
+	for i, c := range cards {
+		pid := c.PlayerID.Int64
+		card := model.ScorecardResponse{
+			PlayerID:  pid,
+			UpdatedAt: optionalTime(c.UpdatedAt),
+			Holes:     make([]model.HoleScoreResponse, len(holes[pid])),
+		}
+		if c.EnteredBy.Valid {
+			enteredBy := c.EnteredBy.Int64
+			card.EnteredBy = &enteredBy
+		}
+		if c.TeeSetID.Valid {
+			teeSetID := c.TeeSetID.Int64
+			card.TeeSetID = &teeSetID
+		}
+		for j, hole := range holes[pid] {
+			card.Holes[j] = model.HoleScoreResponse{
+				Hole:              hole.Number,
+				Strokes:           hole.Strokes,
+				Putts:             hole.Putts,
+				FairwayHit:        hole.FairwayHit,
+				GreenInRegulation: hole.GreenInRegulation,
+				Par:               hole.Par,
+			}
+		}
+		t := scoring.Total(holes[pid])
+		card.Totals = model.ScorecardTotalsResponse{
+			HolesPlayed:        t.HolesPlayed,
+			Strokes:            t.Strokes,
+			Putts:              t.Putts,
+			Par:                t.Par,
+			ToPar:              t.ToPar,
+			FairwaysHit:        t.FairwaysHit,
+			Fairways:           t.Fairways,
+			GreensInRegulation: t.GreensInRegulation,
+			Greens:             t.Greens,
+		}
+		resp.Scorecards[i] = card
+	}
+
+	respondJSON(w, status, resp)
+}
+
+// GetEventScores returns the scorecards of everyone playing in the event.
+func (h *Handler) GetEventScores(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+
+	if !h.requireEventParticipant(w, r, eventID, 0) {
+		return
+	}
+
+	h.respondEventScores(w, r, eventID, http.StatusOK)
+}
+
+// SaveScorecard records holes on a player's scorecard once the round is under
+// way. Players keep their own card; the host or a co-host can keep score for
+// anyone in the group. Holes can be sent a few at a time. Naming the tee set
+// played fills in each hole's par and, once all 18 holes are in, posts the
+// round toward the player's handicap.
+func (h *Handler) SaveScorecard(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid event ID")
+		return
+	}
+	playerID, err := strconv.ParseInt(chi.URLParam(r, "player_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
+		return
+	}
+
+	var req saveScorecardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	if len(req.Holes) == 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Holes can't be blank")
+		return
+	}
+	seen := map[int32]bool{}
+	holes := make([]store.UpsertScorecardHoleParams, len(req.Holes))
+	for i, hole := range req.Holes {
+		if msg := validateHoleScore(hole); msg != "" {
+			respondError(w, http.StatusBadRequest, "validation_error", msg)
+			return
+		}
+		if seen[hole.Hole] {
+			respondError(w, http.StatusBadRequest, "validation_error", fmt.Sprintf("Hole %d is listed more than once", hole.Hole))
+			return
+		}
+		seen[hole.Hole] = true
+		holes[i] = store.UpsertScorecardHoleParams{
+			HoleNumber:        hole.Hole,
+			Strokes:           hole.Strokes,
+			Putts:             nullInt32(hole.Putts),
+			FairwayHit:        nullBool(hole.FairwayHit),
+			GreenInRegulation: nullBool(hole.GreenInRegulation),
+			Par:               nullInt32(hole.Par),
+		}
+	}
+
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return
+	}
+	if authID != playerID && !h.requireEventManager(w, r, eventID) {
+		return
+	}
+
+	params := store.SaveScorecardParams{
+		EventID:   eventID,
+		PlayerID:  playerID,
+		EnteredBy: authID,
+		Holes:     holes,
+	}
+	if req.TeeSetID != nil {
+		params.TeeSetID = sql.NullInt64{Int64: *req.TeeSetID, Valid: true}
+	}
+	if _, err := store.SaveScorecard(r.Context(), h.db, h.queries, params); err != nil {
+		respondScorecardError(w, err)
+		return
+	}
+
+	h.respondEventScores(w, r, eventID, http.StatusOK)
+}
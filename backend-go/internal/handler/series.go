@@ -0,0 +1,431 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/recurrence"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+const dateLayout = "2006-01-02"
+
+// seriesWindow is how far ahead occurrences of a recurring series are
+// materialized into events.
+const seriesWindow = 8 * 7 * 24 * time.Hour
+
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (h *Handler) buildSeriesResponse(r *http.Request, seriesID int64) (*model.SeriesResponse, error) {
+	series, err := h.queries.GetEventSeriesByID(r.Context(), seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	exceptions, err := h.queries.ListEventSeriesExceptionDates(r.Context(), seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitees, err := h.queries.ListEventSeriesInviteeIDs(r.Context(), seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := h.queries.ListSeriesOccurrences(r.Context(), store.ListSeriesOccurrencesParams{
+		SeriesID:       sql.NullInt64{Int64: seriesID, Valid: true},
+		OccurrenceDate: sql.NullTime{Time: today(), Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exceptionDates := make([]string, len(exceptions))
+	for i, ex := range exceptions {
+		exceptionDates[i] = ex.Format(dateLayout)
+	}
+
+	if invitees == nil {
+		invitees = []int64{}
+	}
+
+	occurrenceIDs := make([]int64, len(occurrences))
+	for i, occ := range occurrences {
+		occurrenceIDs[i] = occ.ID
+	}
+
+	events, err := h.buildEventResponses(r, occurrenceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SeriesResponse{
+		ID:            series.ID,
+		HostID:        series.HostID,
+		CourseID:      series.CourseID,
+		StartsOn:      series.StartsOn.Format(dateLayout),
+		TeeTime:       series.TeeTime,
+		OpenSpots:     series.OpenSpots,
+		NumberOfHoles: series.NumberOfHoles,
+		Private:       series.Private,
+		RRule:         series.Rrule,
+		Exceptions:    exceptionDates,
+		Invitees:      invitees,
+		Occurrences:   events,
+	}, nil
+}
+
+type createEventSeriesRequest struct {
+	CourseID      json.Number `json:"course_id"`
+	StartsOn      string      `json:"starts_on"`
+	TeeTime       string      `json:"tee_time"`
+	OpenSpots     json.Number `json:"open_spots"`
+	NumberOfHoles string      `json:"number_of_holes"`
+	Private       bool        `json:"private"`
+	HostID        int64       `json:"host_id"`
+	Invitees      []int64     `json:"invitees"`
+	RRule         string      `json:"rrule"`
+	Exceptions    []string    `json:"exceptions"`
+}
+
+func (h *Handler) CreateEventSeries(w http.ResponseWriter, r *http.Request) {
+	var req createEventSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+
+	courseID, err := req.CourseID.Int64()
+	if err != nil || courseID == 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Course can't be blank")
+		return
+	}
+
+	openSpots, err := req.OpenSpots.Int64()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "validation_error", "Open spots can't be blank")
+		return
+	}
+
+	startsOn, err := time.Parse(dateLayout, req.StartsOn)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "validation_error", "Starts on must be a date (YYYY-MM-DD)")
+		return
+	}
+	if req.TeeTime == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Tee time can't be blank")
+		return
+	}
+	if req.NumberOfHoles == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Number of holes can't be blank")
+		return
+	}
+	if req.HostID == 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Host can't be blank")
+		return
+	}
+
+	rule, err := recurrence.Parse(req.RRule)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "validation_error", "Invalid rrule: "+err.Error())
+		return
+	}
+
+	exceptions := make([]time.Time, 0, len(req.Exceptions))
+	for _, ex := range req.Exceptions {
+		d, err := time.Parse(dateLayout, ex)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "validation_error", "Exceptions must be dates (YYYY-MM-DD)")
+			return
+		}
+		exceptions = append(exceptions, d)
+	}
+
+	seriesID, err := store.CreateEventSeriesWithInvitees(r.Context(), h.db, h.queries, store.CreateEventSeriesWithInviteesParams{
+		HostID:        req.HostID,
+		CourseID:      courseID,
+		StartsOn:      startsOn,
+		TeeTime:       req.TeeTime,
+		OpenSpots:     int32(openSpots),
+		NumberOfHoles: req.NumberOfHoles,
+		Private:       req.Private,
+		Rule:          rule,
+		Invitees:      req.Invitees,
+		Exceptions:    exceptions,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create event series")
+		return
+	}
+
+	if err := store.MaterializeSeries(r.Context(), h.db, h.queries, seriesID, today(), today().Add(seriesWindow)); err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create series occurrences")
+		return
+	}
+
+	resp, err := h.buildSeriesResponse(r, seriesID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build series response")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *Handler) GetEventSeries(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid series ID")
+		return
+	}
+
+	// Top up the materialized window on read so upcoming weeks keep appearing.
+	err = store.MaterializeSeries(r.Context(), h.db, h.queries, id, today(), today().Add(seriesWindow))
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusNotFound, "not_found", "Series not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create series occurrences")
+		return
+	}
+
+	resp, err := h.buildSeriesResponse(r, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build series response")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+type rsvpEventSeriesRequest struct {
+	PlayerID     int64  `json:"player_id"`
+	InviteStatus string `json:"invite_status"`
+}
+
+// RsvpEventSeries accepts or declines every upcoming occurrence of a series
+// at once, and keeps doing so for occurrences materialized later.
+func (h *Handler) RsvpEventSeries(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid series ID")
+		return
+	}
+
+	var req rsvpEventSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+
+	statusInt := inviteStatusToInt(req.InviteStatus)
+	if statusInt != statusAccepted && statusInt != statusDeclined {
+		respondError(w, http.StatusBadRequest, "validation_error", "Invite status must be accepted or declined")
+		return
+	}
+	if req.PlayerID == 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Player ID is required")
+		return
+	}
+
+	if _, err := h.queries.GetEventSeriesByID(r.Context(), id); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Series not found")
+		return
+	}
+
+	if err := store.RsvpEventSeries(r.Context(), h.db, h.queries, store.RsvpEventSeriesParams{
+		SeriesID:     id,
+		PlayerID:     req.PlayerID,
+		InviteStatus: statusInt,
+		From:         today(),
+	}); err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to update series RSVP")
+		return
+	}
+
+	resp, err := h.buildSeriesResponse(r, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build series response")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+type overrideOccurrenceRequest struct {
+	CourseID      *int64  `json:"course_id"`
+	TeeTime       *string `json:"tee_time"`
+	OpenSpots     *int32  `json:"open_spots"`
+	NumberOfHoles *string `json:"number_of_holes"`
+}
+
+// requireSeriesHost checks the logged-in player hosts the series, for changes
+// that reach beyond a single event.
+func (h *Handler) requireSeriesHost(w http.ResponseWriter, r *http.Request, seriesID int64) bool {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return false
+	}
+
+	series, err := h.queries.GetEventSeriesByID(r.Context(), seriesID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Series not found")
+		return false
+	}
+	if series.HostID != authID {
+		respondError(w, http.StatusForbidden, "forbidden", "Only the host can manage this series")
+		return false
+	}
+	return true
+}
+
+func parseOccurrencePath(w http.ResponseWriter, r *http.Request) (int64, time.Time, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid series ID")
+		return 0, time.Time{}, false
+	}
+	date, err := time.Parse(dateLayout, chi.URLParam(r, "date"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid occurrence date")
+		return 0, time.Time{}, false
+	}
+	return id, date, true
+}
+
+// OverrideSeriesOccurrence edits a single occurrence (e.g. a different tee
+// time one week) without changing the rest of the series. The host or a
+// co-host can edit an occurrence that already exists; one that hasn't been
+// materialized yet can only be created and edited by the series host.
+func (h *Handler) OverrideSeriesOccurrence(w http.ResponseWriter, r *http.Request) {
+	seriesID, date, ok := parseOccurrencePath(w, r)
+	if !ok {
+		return
+	}
+
+	// An occurrence that already exists can be edited by its host or
+	// co-hosts. Materializing a new one is up to the series host, so that's
+	// checked before anything is created.
+	occParams := store.GetSeriesOccurrenceParams{
+		SeriesID:       sql.NullInt64{Int64: seriesID, Valid: true},
+		OccurrenceDate: sql.NullTime{Time: date, Valid: true},
+	}
+	occ, err := h.queries.GetSeriesOccurrence(r.Context(), occParams)
+	switch {
+	case err == nil:
+		if !h.requireEventManager(w, r, occ.ID) {
+			return
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		if !h.requireSeriesHost(w, r, seriesID) {
+			return
+		}
+		if err := store.MaterializeSeries(r.Context(), h.db, h.queries, seriesID, date, date); err != nil {
+			respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create series occurrence")
+			return
+		}
+		occ, err = h.queries.GetSeriesOccurrence(r.Context(), occParams)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "not_found", "Occurrence not found")
+			return
+		}
+	default:
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch occurrence")
+		return
+	}
+
+	var req overrideOccurrenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+
+	event, err := h.queries.GetEventByID(r.Context(), occ.ID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Occurrence not found")
+		return
+	}
+
+	params := store.OverrideOccurrenceParams{
+		EventID:       occ.ID,
+		Date:          event.Date.String,
+		CourseID:      event.CourseID.Int32,
+		TeeTime:       event.TeeTime.String,
+		OpenSpots:     event.OpenSpots.Int32,
+		NumberOfHoles: event.NumberOfHoles.String,
+	}
+	if req.CourseID != nil {
+		params.CourseID = int32(*req.CourseID)
+	}
+	if req.TeeTime != nil {
+		params.TeeTime = *req.TeeTime
+	}
+	if req.OpenSpots != nil {
+		params.OpenSpots = *req.OpenSpots
+	}
+	if req.NumberOfHoles != nil {
+		params.NumberOfHoles = *req.NumberOfHoles
+	}
+	if params.CourseID == 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Course can't be blank")
+		return
+	}
+	if params.TeeTime == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Tee time can't be blank")
+		return
+	}
+	if params.NumberOfHoles == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Number of holes can't be blank")
+		return
+	}
+
+	err = store.OverrideOccurrence(r.Context(), h.db, h.queries, params)
+	if errors.Is(err, store.ErrSpotsBelowAccepted) {
+		respondError(w, http.StatusConflict, "conflict", "Open spots can't be fewer than accepted players and guests")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to update occurrence")
+		return
+	}
+
+	resp, err := h.buildEventResponse(r, occ.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to build event response")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// CancelSeriesOccurrence cancels a single occurrence and keeps it from being
+// materialized again.
+func (h *Handler) CancelSeriesOccurrence(w http.ResponseWriter, r *http.Request) {
+	seriesID, date, ok := parseOccurrencePath(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.requireSeriesHost(w, r, seriesID) {
+		return
+	}
+
+	if err := store.CancelOccurrence(r.Context(), h.db, h.queries, seriesID, date); err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to cancel occurrence")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
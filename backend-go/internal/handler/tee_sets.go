@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+// Slope ratings run from 55 to 155, with 113 for a course of standard
+// difficulty.
+const (
+	minSlopeRating = 55
+	maxSlopeRating = 155
+)
+
+type teeSetHoleRequest struct {
+	Hole        int32  `json:"hole"`
+	Par         int32  `json:"par"`
+	StrokeIndex int32  `json:"stroke_index"`
+	Yardage     *int32 `json:"yardage"`
+}
+
+type teeSetRequest struct {
+	Name         string              `json:"name"`
+	Color        string              `json:"color"`
+	CourseRating float64             `json:"course_rating"`
+	SlopeRating  int32               `json:"slope_rating"`
+	Yardage      *int32              `json:"yardage"`
+	Holes        []teeSetHoleRequest `json:"holes"`
+}
+
+type importTeeSetsRequest struct {
+	TeeSets []teeSetRequest `json:"tee_sets"`
+}
+
+// requireAdmin checks the logged-in player maintains course data, writing the
+// error response when they don't.
+func (h *Handler) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return false
+	}
+
+	isAdmin, err := h.queries.IsPlayerAdmin(r.Context(), authID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to check permissions")
+		return false
+	}
+	if !isAdmin {
+		respondError(w, http.StatusForbidden, "forbidden", "Only admins can do that")
+		return false
+	}
+	return true
+}
+
+// teeSetInput validates a tee set, returning a message for the first problem
+// found. A tee set covers 9 or 18 holes, each numbered once with its own
+// stroke index. The tee set's yardage defaults to the sum of its holes'.
+func teeSetInput(req teeSetRequest) (store.TeeSetInput, string) {
+	if req.Name == "" {
+		return store.TeeSetInput{}, "Name can't be blank"
+	}
+	if req.CourseRating < 20 || req.CourseRating > 90 {
+		return store.TeeSetInput{}, "Course rating must be between 20 and 90"
+	}
+	if req.SlopeRating < minSlopeRating || req.SlopeRating > maxSlopeRating {
+		return store.TeeSetInput{}, fmt.Sprintf("Slope rating must be between %d and %d", minSlopeRating, maxSlopeRating)
+	}
+	if req.Yardage != nil && *req.Yardage <= 0 {
+		return store.TeeSetInput{}, "Yardage must be greater than 0"
+	}
+	if len(req.Holes) != 9 && len(req.Holes) != 18 {
+		return store.TeeSetInput{}, "Tee sets must have 9 or 18 holes"
+	}
+
+	in := store.TeeSetInput{
+		Name:         req.Name,
+		Color:        sql.NullString{String: req.Color, Valid: req.Color != ""},
+		CourseRating: req.CourseRating,
+		SlopeRating:  req.SlopeRating,
+		Yardage:      nullInt32(req.Yardage),
+		Holes:        make([]store.CreateTeeSetHoleParams, len(req.Holes)),
+	}
+	holes := map[int32]bool{}
+	strokeIndexes := map[int32]bool{}
+	var total int32
+	allYardages := true
+	for i, hole := range req.Holes {
+		if hole.Hole < 1 || hole.Hole > int32(len(req.Holes)) || holes[hole.Hole] {
+			return store.TeeSetInput{}, fmt.Sprintf("Holes must be numbered 1 to %d once each", len(req.Holes))
+		}
+		holes[hole.Hole] = true
+		if hole.Par < 3 || hole.Par > 6 {
+			return store.TeeSetInput{}, fmt.Sprintf("Par on hole %d must be between 3 and 6", hole.Hole)
+		}
+		if hole.StrokeIndex < 1 || hole.StrokeIndex > 18 || strokeIndexes[hole.StrokeIndex] {
+			return store.TeeSetInput{}, fmt.Sprintf("Stroke index on hole %d must be between 1 and 18 and not repeat", hole.Hole)
+		}
+		strokeIndexes[hole.StrokeIndex] = true
+		if hole.Yardage != nil {
+			if *hole.Yardage <= 0 {
+				return store.TeeSetInput{}, fmt.Sprintf("Yardage on hole %d must be greater than 0", hole.Hole)
+			}
+			total += *hole.Yardage
+		} else {
+			allYardages = false
+		}
+		in.Holes[i] = store.CreateTeeSetHoleParams{
+			HoleNumber:  hole.Hole,
+			Par:         hole.Par,
+			StrokeIndex: hole.StrokeIndex,
+			Yardage:     nullInt32(hole.Yardage),
+		}
+	}
+	if !in.Yardage.Valid && allYardages {
+		in.Yardage = sql.NullInt32{Int32: total, Valid: true}
+	}
+	return in, ""
+}
+
+func respondTeeSetError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrTeeSetNameTaken):
+		respondError(w, http.StatusConflict, "conflict", "Course already has a tee set with that name")
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "not_found", "Tee set not found")
+	default:
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to save tee set")
+	}
+}
+
+// parseCourse reads the course ID from the URL and checks the course exists.
+func (h *Handler) parseCourse(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	courseID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid course ID")
+		return 0, false
+	}
+	if _, err := h.queries.GetCourseByID(r.Context(), courseID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Course not found")
+		return 0, false
+	}
+	return courseID, true
+}
+
+// CreateTeeSet adds a tee set to a course.
+func (h *Handler) CreateTeeSet(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r) {
+		return
+	}
+	courseID, ok := h.parseCourse(w, r)
+	if !ok {
+		return
+	}
+
+	var req teeSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	in, msg := teeSetInput(req)
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, "validation_error", msg)
+		return
+	}
+
+	if _, err := store.CreateTeeSet(r.Context(), h.db, h.queries, courseID, in); err != nil {
+		respondTeeSetError(w, err)
+		return
+	}
+
+	h.respondCourseDetail(w, r, courseID, http.StatusCreated)
+}
+
+// UpdateTeeSet replaces a tee set, holes included.
+func (h *Handler) UpdateTeeSet(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r) {
+		return
+	}
+	courseID, ok := h.parseCourse(w, r)
+	if !ok {
+		return
+	}
+	teeSetID, err := strconv.ParseInt(chi.URLParam(r, "tee_set_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid tee set ID")
+		return
+	}
+
+	var req teeSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	in, msg := teeSetInput(req)
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, "validation_error", msg)
+		return
+	}
+
+	if _, err := store.UpdateTeeSet(r.Context(), h.db, h.queries, courseID, teeSetID, in); err != nil {
+		respondTeeSetError(w, err)
+		return
+	}
+
+	h.respondCourseDetail(w, r, courseID, http.StatusOK)
+}
+
+// DeleteTeeSet removes a tee set and its holes from a course. Rounds played
+// from it stop counting toward handicaps.
+func (h *Handler) DeleteTeeSet(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r) {
+		return
+	}
+	courseID, ok := h.parseCourse(w, r)
+	if !ok {
+		return
+	}
+	teeSetID, err := strconv.ParseInt(chi.URLParam(r, "tee_set_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid tee set ID")
+		return
+	}
+
+	err = store.DeleteTeeSet(r.Context(), h.db, h.queries, courseID, teeSetID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusNotFound, "not_found", "Tee set not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to delete tee set")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
+
+// ImportTeeSets loads several tee sets into a course at once. Tee sets are
+// matched by name, so an import can be rerun to pick up new ratings. Nothing
+// is saved unless every tee set is valid.
+func (h *Handler) ImportTeeSets(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r) {
+		return
+	}
+	courseID, ok := h.parseCourse(w, r)
+	if !ok {
+		return
+	}
+
+	var req importTeeSetsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	if len(req.TeeSets) == 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Tee sets can't be blank")
+		return
+	}
+
+	names := map[string]bool{}
+	teeSets := make([]store.TeeSetInput, len(req.TeeSets))
+	for i, t := range req.TeeSets {
+		in, msg := teeSetInput(t)
+		if msg != "" {
+			respondError(w, http.StatusBadRequest, "validation_error", fmt.Sprintf("Tee set %d: %s", i+1, msg))
+			return
+		}
+		if names[in.Name] {
+			respondError(w, http.StatusBadRequest, "validation_error", fmt.Sprintf("Tee set %q is listed more than once", in.Name))
+			return
+		}
+		names[in.Name] = true
+		teeSets[i] = in
+	}
+
+	if err := store.ImportTeeSets(r.Context(), h.db, h.queries, courseID, teeSets); err != nil {
+		respondTeeSetError(w, err)
+		return
+	}
+
+	h.respondCourseDetail(w, r, courseID, http.StatusOK)
+}
@@ -0,0 +1,19 @@
+package handler
+
+import "time"
+
+// courseNow is the wall-clock time in a course's time zone, labelled UTC
+// like events' starts_at so the two compare directly. Unknown zones fall
+// back to UTC.
+func courseNow(timeZone string) time.Time {
+	now := time.Now().UTC()
+	if loc, err := time.LoadLocation(timeZone); err == nil {
+		now = now.In(loc)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), 0, time.UTC)
+}
+
+// courseToday is the current date in a course's time zone.
+func courseToday(timeZone string) time.Time {
+	return courseNow(timeZone).Truncate(24 * time.Hour)
+}
@@ -0,0 +1,464 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ericrabun/findfore-go/internal/model"
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+type createTournamentRequest struct {
+	Name          string  `json:"name"`
+	CourseID      int64   `json:"course_id"`
+	NumberOfHoles string  `json:"number_of_holes"`
+	Net           bool    `json:"net"`
+	PlayerIDs     []int64 `json:"player_ids"`
+}
+
+type tournamentPlayerRequest struct {
+	PlayerID int64 `json:"player_id"`
+}
+
+type scheduleMatchRequest struct {
+	Date    string `json:"date"`
+	TeeTime string `json:"tee_time"`
+}
+
+type matchResultRequest struct {
+	WinnerID int64 `json:"winner_id"`
+	Walkover bool  `json:"walkover"`
+}
+
+func tournamentStatusToString(status int32) string {
+	switch status {
+	case 0:
+		return "registration"
+	case 1:
+		return "in_progress"
+	case 2:
+		return "completed"
+	default:
+		return "unknown"
+	}
+}
+
+func matchResultToString(result sql.NullInt32) string {
+	if !result.Valid {
+		return "pending"
+	}
+	switch result.Int32 {
+	case 0:
+		return "played"
+	case 1:
+		return "bye"
+	case 2:
+		return "walkover"
+	default:
+		return "unknown"
+	}
+}
+
+func optionalID(id sql.NullInt64) *int64 {
+	if !id.Valid {
+		return nil
+	}
+	return &id.Int64
+}
+
+func respondTournamentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrTournamentStarted):
+		respondError(w, http.StatusConflict, "conflict", "Tournament has already started")
+	case errors.Is(err, store.ErrTournamentNotStarted):
+		respondError(w, http.StatusConflict, "conflict", "Tournament hasn't started")
+	case errors.Is(err, store.ErrTournamentCompleted):
+		respondError(w, http.StatusConflict, "conflict", "Tournament is over")
+	case errors.Is(err, store.ErrAlreadyRegistered):
+		respondError(w, http.StatusConflict, "conflict", "Player is already registered")
+	case errors.Is(err, store.ErrTooFewEntrants):
+		respondError(w, http.StatusConflict, "conflict", "Tournaments need at least two players")
+	case errors.Is(err, store.ErrMatchNotReady):
+		respondError(w, http.StatusConflict, "conflict", "Match is waiting on an earlier round")
+	case errors.Is(err, store.ErrMatchDecided):
+		respondError(w, http.StatusConflict, "conflict", "Match has already been decided")
+	case errors.Is(err, store.ErrMatchScheduled):
+		respondError(w, http.StatusConflict, "conflict", "Match is already scheduled")
+	case errors.Is(err, store.ErrMatchNotPlayed):
+		respondError(w, http.StatusConflict, "conflict", "Match round hasn't been completed")
+	case errors.Is(err, store.ErrMatchHalved):
+		respondError(w, http.StatusConflict, "conflict", "Match was halved, report the winner of the playoff")
+	case errors.Is(err, store.ErrNotInMatch):
+		respondError(w, http.StatusBadRequest, "validation_error", "Winner must be playing in the match")
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "not_found", "Match not found")
+	default:
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to update tournament")
+	}
+}
+
+// requireTournamentHost loads the tournament and checks the logged-in player
+// runs it.
+func (h *Handler) requireTournamentHost(w http.ResponseWriter, r *http.Request, tournamentID int64) bool {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return false
+	}
+
+	tournament, err := h.queries.GetTournament(r.Context(), tournamentID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Tournament not found")
+		return false
+	}
+	if tournament.HostID != authID {
+		respondError(w, http.StatusForbidden, "forbidden", "Only the host can do that")
+		return false
+	}
+	return true
+}
+
+// requireMatchPlayerOrHost loads the match and checks the logged-in player
+// is playing in it or runs the tournament.
+func (h *Handler) requireMatchPlayerOrHost(w http.ResponseWriter, r *http.Request, tournamentID, matchID int64) bool {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return false
+	}
+
+	tournament, err := h.queries.GetTournament(r.Context(), tournamentID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Tournament not found")
+		return false
+	}
+	match, err := h.queries.GetTournamentMatch(r.Context(), store.GetTournamentMatchParams{
+		ID:           matchID,
+		TournamentID: tournamentID,
+	})
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Match not found")
+		return false
+	}
+	if authID != tournament.HostID && authID != match.Player1ID.Int64 && authID != match.Player2ID.Int64 {
+		respondError(w, http.StatusForbidden, "forbidden", "Only the match's players or the host can do that")
+		return false
+	}
+	return true
+}
+
+// respondTournament writes the tournament with its seeded players and the
+// bracket round by round.
+func (h *Handler) respondTournament(w http.ResponseWriter, r *http.Request, tournamentID int64, status int) {
+	tournament, err := h.queries.GetTournament(r.Context(), tournamentID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Tournament not found")
+		return
+	}
+	players, err := h.queries.ListTournamentPlayers(r.Context(), tournamentID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch players")
+		return
+	}
+	matches, err := h.queries.ListTournamentMatches(r.Context(), tournamentID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to fetch matches")
+		return
+	}
+
+	resp := model.TournamentResponse{
+		ID:            tournament.ID,
+		Name:          tournament.Name,
+		HostID:        tournament.HostID,
+		CourseID:      tournament.CourseID,
+		NumberOfHoles: tournament.NumberOfHoles,
+		Net:           tournament.Net,
+		Status:        tournamentStatusToString(tournament.Status),
+		WinnerID:      optionalID(tournament.WinnerID),
+		Players:       make([]model.TournamentPlayerResponse, len(players)),
+		Rounds:        []model.TournamentRoundResponse{},
+	}
+	for i, p := range players {
+		resp.Players[i] = model.TournamentPlayerResponse{PlayerID: p.PlayerID}
+		if p.Seed.Valid {
+			resp.Players[i].Seed = &p.Seed.Int32
+		}
+	}
+	// Matches come ordered by round.
+	for _, m := range matches {
+		if n := len(resp.Rounds); n == 0 || resp.Rounds[n-1].Round != m.Round {
+			resp.Rounds = append(resp.Rounds, model.TournamentRoundResponse{Round: m.Round})
+		}
+		round := &resp.Rounds[len(resp.Rounds)-1]
+		round.Matches = append(round.Matches, model.TournamentMatchResponse{
+			ID:        m.ID,
+			Round:     m.Round,
+			Position:  m.Position,
+			Player1ID: optionalID(m.Player1ID),
+			Player2ID: optionalID(m.Player2ID),
+			EventID:   optionalID(m.EventID),
+			WinnerID:  optionalID(m.WinnerID),
+			Result:    matchResultToString(m.Result),
+		})
+	}
+
+	respondJSON(w, status, resp)
+}
+
+// CreateTournament opens registration for a tournament hosted by the
+// logged-in player, optionally with players already entered.
+func (h *Handler) CreateTournament(w http.ResponseWriter, r *http.Request) {
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return
+	}
+
+	var req createTournamentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Name can't be blank")
+		return
+	}
+	if req.CourseID <= 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Course can't be blank")
+		return
+	}
+	if req.NumberOfHoles == "" {
+		respondError(w, http.StatusBadRequest, "validation_error", "Number of holes can't be blank")
+		return
+	}
+
+	if _, err := h.queries.GetCourseByID(r.Context(), req.CourseID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Course not found")
+		return
+	}
+	for _, id := range req.PlayerIDs {
+		if _, err := h.queries.GetPlayerByID(r.Context(), id); err != nil {
+			respondError(w, http.StatusNotFound, "not_found", "Player not found")
+			return
+		}
+	}
+
+	tournament, err := store.CreateTournament(r.Context(), h.db, h.queries, store.CreateTournamentParams{
+		Name:          req.Name,
+		HostID:        authID,
+		CourseID:      req.CourseID,
+		NumberOfHoles: req.NumberOfHoles,
+		Net:           req.Net,
+	}, req.PlayerIDs)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "internal_error", "Failed to create tournament")
+		return
+	}
+
+	h.respondTournament(w, r, tournament.ID, http.StatusCreated)
+}
+
+// GetTournament returns the tournament's players and bracket.
+func (h *Handler) GetTournament(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid tournament ID")
+		return
+	}
+
+	h.respondTournament(w, r, tournamentID, http.StatusOK)
+}
+
+// RegisterTournamentPlayer enters a player while registration is open.
+// Players enter themselves; the host can enter anyone.
+func (h *Handler) RegisterTournamentPlayer(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid tournament ID")
+		return
+	}
+
+	var req tournamentPlayerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	if req.PlayerID <= 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Player can't be blank")
+		return
+	}
+
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return
+	}
+	tournament, err := h.queries.GetTournament(r.Context(), tournamentID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Tournament not found")
+		return
+	}
+	if authID != req.PlayerID && authID != tournament.HostID {
+		respondError(w, http.StatusForbidden, "forbidden", "Only the host can register other players")
+		return
+	}
+	if _, err := h.queries.GetPlayerByID(r.Context(), req.PlayerID); err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Player not found")
+		return
+	}
+
+	if err := store.RegisterTournamentPlayer(r.Context(), h.db, h.queries, tournamentID, req.PlayerID); err != nil {
+		respondTournamentError(w, err)
+		return
+	}
+
+	h.respondTournament(w, r, tournamentID, http.StatusCreated)
+}
+
+// WithdrawTournamentPlayer takes a player out before the bracket is drawn.
+// Players withdraw themselves; the host can remove anyone.
+func (h *Handler) WithdrawTournamentPlayer(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid tournament ID")
+		return
+	}
+	playerID, err := strconv.ParseInt(chi.URLParam(r, "player_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid player_id")
+		return
+	}
+
+	authID, ok := authPlayerID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized", "You must be logged in")
+		return
+	}
+	tournament, err := h.queries.GetTournament(r.Context(), tournamentID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "not_found", "Tournament not found")
+		return
+	}
+	if authID != playerID && authID != tournament.HostID {
+		respondError(w, http.StatusForbidden, "forbidden", "Only the host can remove other players")
+		return
+	}
+
+	if err := store.WithdrawTournamentPlayer(r.Context(), h.db, h.queries, tournamentID, playerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "not_found", "Player isn't registered")
+			return
+		}
+		respondTournamentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, nil)
+}
+
+// StartTournament lets the host close registration and draw the bracket.
+// Players are seeded by handicap index and byes go to the top seeds.
+func (h *Handler) StartTournament(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid tournament ID")
+		return
+	}
+
+	if !h.requireTournamentHost(w, r, tournamentID) {
+		return
+	}
+
+	if err := store.StartTournament(r.Context(), h.db, h.queries, tournamentID); err != nil {
+		respondTournamentError(w, err)
+		return
+	}
+
+	h.respondTournament(w, r, tournamentID, http.StatusOK)
+}
+
+// ScheduleTournamentMatch books the match as a private event at the
+// tournament's course. Either player or the host can schedule it.
+func (h *Handler) ScheduleTournamentMatch(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid tournament ID")
+		return
+	}
+	matchID, err := strconv.ParseInt(chi.URLParam(r, "match_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid match_id")
+		return
+	}
+
+	var req scheduleMatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	if _, err := store.EventStartsAt(req.Date, req.TeeTime); err != nil {
+		respondError(w, http.StatusBadRequest, "validation_error", "Date or tee time is invalid")
+		return
+	}
+
+	if !h.requireMatchPlayerOrHost(w, r, tournamentID, matchID) {
+		return
+	}
+
+	if _, err := store.ScheduleTournamentMatch(r.Context(), h.db, h.queries, tournamentID, matchID, req.Date, req.TeeTime); err != nil {
+		respondTournamentError(w, err)
+		return
+	}
+
+	h.respondTournament(w, r, tournamentID, http.StatusCreated)
+}
+
+// ReportTournamentMatch decides the match and advances the winner. With no
+// winner given, the match is played out from the completed event's
+// scorecards, which either player or the host can ask for. Only the host can
+// name the winner outright, such as for a walkover or a playoff after a
+// halved match.
+func (h *Handler) ReportTournamentMatch(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid tournament ID")
+		return
+	}
+	matchID, err := strconv.ParseInt(chi.URLParam(r, "match_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid match_id")
+		return
+	}
+
+	var req matchResultRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "bad_request", "Invalid request body")
+		return
+	}
+	if req.Walkover && req.WinnerID == 0 {
+		respondError(w, http.StatusBadRequest, "validation_error", "Walkovers need a winner")
+		return
+	}
+
+	if req.WinnerID != 0 {
+		if !h.requireTournamentHost(w, r, tournamentID) {
+			return
+		}
+	} else if !h.requireMatchPlayerOrHost(w, r, tournamentID, matchID) {
+		return
+	}
+
+	if err := store.DecideTournamentMatch(r.Context(), h.db, h.queries, tournamentID, matchID, req.WinnerID, req.Walkover); err != nil {
+		respondTournamentError(w, err)
+		return
+	}
+
+	h.respondTournament(w, r, tournamentID, http.StatusOK)
+}
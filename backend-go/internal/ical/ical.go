@@ -0,0 +1,113 @@
+package ical
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	prodID = "-//FindFore//FindFore Calendar//EN"
+
+	// Tee times are stored as course-local wall clock times, so events are
+	// written as floating times without a zone.
+	floatingLayout = "20060102T150405"
+	utcLayout      = "20060102T150405Z"
+
+	// maxLineOctets is the RFC 5545 content line limit, excluding CRLF.
+	maxLineOctets = 75
+)
+
+// Event is a single VEVENT. UID must stay the same for the life of the event
+// and Sequence must increase whenever it changes so clients replace their copy.
+type Event struct {
+	UID         string
+	Sequence    int32
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Location    string
+	Description string
+	Cancelled   bool
+}
+
+// Calendar is a VCALENDAR object. Name is shown by clients that subscribe to
+// it as a feed and may be empty for single-event exports.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Marshal renders the calendar as an RFC 5545 iCalendar stream.
+func (c Calendar) Marshal() []byte {
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+prodID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+
+	for _, e := range c.Events {
+		status := "CONFIRMED"
+		if e.Cancelled {
+			status = "CANCELLED"
+		}
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+e.UID)
+		writeLine(&b, "SEQUENCE:"+strconv.Itoa(int(e.Sequence)))
+		writeLine(&b, "DTSTAMP:"+e.Stamp.UTC().Format(utcLayout))
+		writeLine(&b, "DTSTART:"+e.Start.Format(floatingLayout))
+		writeLine(&b, "DTEND:"+e.End.Format(floatingLayout))
+		writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+		if e.Location != "" {
+			writeLine(&b, "LOCATION:"+escapeText(e.Location))
+		}
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		writeLine(&b, "STATUS:"+status)
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// writeLine writes a content line, folding it at 75 octets without splitting
+// a multi-byte character.
+func writeLine(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,78 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func testEvent() Event {
+	start := time.Date(2025, 6, 3, 17, 0, 0, 0, time.UTC)
+	return Event{
+		UID:         "event-7@findfore",
+		Sequence:    2,
+		Stamp:       time.Date(2025, 5, 1, 12, 30, 0, 0, time.UTC),
+		Start:       start,
+		End:         start.Add(4*time.Hour + 30*time.Minute),
+		Summary:     "Golf at Green Valley",
+		Location:    "Green Valley, 1 Fairway Dr, Denver, CO 80202",
+		Description: "18 holes; hosted by Amy",
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	out := string(Calendar{Name: "Amy's rounds", Events: []Event{testEvent()}}.Marshal())
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"VERSION:2.0\r\n",
+		"X-WR-CALNAME:Amy's rounds\r\n",
+		"UID:event-7@findfore\r\n",
+		"SEQUENCE:2\r\n",
+		"DTSTAMP:20250501T123000Z\r\n",
+		"DTSTART:20250603T170000\r\n",
+		"DTEND:20250603T213000\r\n",
+		"LOCATION:Green Valley\\, 1 Fairway Dr\\, Denver\\, CO 80202\r\n",
+		"DESCRIPTION:18 holes\\; hosted by Amy\r\n",
+		"STATUS:CONFIRMED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarshal_Cancelled(t *testing.T) {
+	e := testEvent()
+	e.Cancelled = true
+	out := string(Calendar{Events: []Event{e}}.Marshal())
+
+	if !strings.Contains(out, "STATUS:CANCELLED\r\n") {
+		t.Errorf("expected cancelled status, got:\n%s", out)
+	}
+	if strings.Contains(out, "X-WR-CALNAME") {
+		t.Errorf("expected no calendar name, got:\n%s", out)
+	}
+}
+
+func TestMarshal_FoldsLongLines(t *testing.T) {
+	e := testEvent()
+	e.Description = strings.Repeat("Bogey golf é ", 20)
+	out := string(Calendar{Events: []Event{e}}.Marshal())
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line exceeds 75 octets: %q", line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a multi-byte character: %q", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, "DESCRIPTION:"+e.Description+"\r\n") {
+		t.Errorf("expected description to survive folding, got:\n%s", unfolded)
+	}
+}
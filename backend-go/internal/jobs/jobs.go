@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/ericrabun/findfore-go/internal/store"
+)
+
+const (
+	// Interval is how often the scheduled jobs run.
+	Interval = time.Minute
+
+	// ReminderLead is how long before an event's respond by deadline pending
+	// invitees are reminded.
+	ReminderLead = 24 * time.Hour
+)
+
+// Job is a unit of background work run on every tick.
+type Job struct {
+	Name string
+	Run  func(ctx context.Context, now time.Time) error
+}
+
+// Scheduler runs jobs on a fixed interval until its context is cancelled.
+type Scheduler struct {
+	interval time.Duration
+	jobs     []Job
+}
+
+func NewScheduler(interval time.Duration, jobs ...Job) *Scheduler {
+	return &Scheduler{interval: interval, jobs: jobs}
+}
+
+// Start runs every job once immediately and then on each tick. It blocks
+// until ctx is cancelled. A failing job is logged and retried next tick.
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.runAll(ctx, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.runAll(ctx, now)
+		}
+	}
+}
+
+func (s *Scheduler) runAll(ctx context.Context, now time.Time) {
+	for _, job := range s.jobs {
+		if err := job.Run(ctx, now); err != nil && ctx.Err() == nil {
+			log.Printf("Job %s failed: %v", job.Name, err)
+		}
+	}
+}
+
+// AdvanceEventStatuses moves events to in progress once their tee time
+// passes at the course and to completed once the round's estimated duration
+// has elapsed.
+func AdvanceEventStatuses(q *store.Queries) Job {
+	return Job{
+		Name: "advance_event_statuses",
+		Run: func(ctx context.Context, now time.Time) error {
+			if _, err := q.CompleteFinishedEvents(ctx, now); err != nil {
+				return err
+			}
+			_, err := q.StartDueEvents(ctx, now)
+			return err
+		},
+	}
+}
+
+// ExpireInvitations flips pending invitations to expired once their event's
+// respond by deadline has passed.
+func ExpireInvitations(q *store.Queries) Job {
+	return Job{
+		Name: "expire_invitations",
+		Run: func(ctx context.Context, now time.Time) error {
+			_, err := q.ExpirePendingInvitations(ctx, now.UTC())
+			return err
+		},
+	}
+}
+
+// SendRsvpReminders notifies pending invitees ReminderLead before the
+// respond by deadline.
+func SendRsvpReminders(db *sql.DB, q *store.Queries) Job {
+	return Job{
+		Name: "send_rsvp_reminders",
+		Run: func(ctx context.Context, now time.Time) error {
+			_, err := store.SendRsvpReminders(ctx, db, q, now, ReminderLead)
+			return err
+		},
+	}
+}
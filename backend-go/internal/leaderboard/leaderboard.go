@@ -0,0 +1,84 @@
+package leaderboard
+
+import (
+	"sort"
+	"time"
+)
+
+// Round is a complete round that can go on a leaderboard.
+type Round struct {
+	ScorecardID int64
+	PlayerID    int64
+	EventID     int64
+	CourseID    int64
+	PlayedAt    time.Time
+	Gross       int
+	Net         int
+}
+
+// Entry is a player's best round and their place on the board.
+type Entry struct {
+	Round
+	Score    int
+	Position int
+}
+
+// Key is where an entry sits on the board, used to pick up the next page.
+type Key struct {
+	Score    int
+	PlayedAt time.Time
+	PlayerID int64
+}
+
+// Key is the entry's place in the order.
+func (e Entry) Key() Key {
+	return Key{Score: e.Score, PlayedAt: e.PlayedAt, PlayerID: e.PlayerID}
+}
+
+func (k Key) less(o Key) bool {
+	if k.Score != o.Score {
+		return k.Score < o.Score
+	}
+	if !k.PlayedAt.Equal(o.PlayedAt) {
+		return k.PlayedAt.Before(o.PlayedAt)
+	}
+	return k.PlayerID < o.PlayerID
+}
+
+// Rank keeps each player's best round, net or gross, and orders the board
+// lowest score first. Tied players share a position and whoever posted the
+// score first is listed first, then the lower player ID, so the order is the
+// same on every request.
+func Rank(rounds []Round, net bool) []Entry {
+	best := map[int64]Entry{}
+	for _, r := range rounds {
+		e := Entry{Round: r, Score: r.Gross}
+		if net {
+			e.Score = r.Net
+		}
+		cur, ok := best[r.PlayerID]
+		if !ok || e.Key().less(cur.Key()) || (e.Key() == cur.Key() && e.ScorecardID < cur.ScorecardID) {
+			best[r.PlayerID] = e
+		}
+	}
+
+	entries := make([]Entry, 0, len(best))
+	for _, e := range best {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key().less(entries[j].Key()) })
+	for i := range entries {
+		if i > 0 && entries[i].Score == entries[i-1].Score {
+			entries[i].Position = entries[i-1].Position
+		} else {
+			entries[i].Position = i + 1
+		}
+	}
+	return entries
+}
+
+// After is the part of the board that comes after key.
+func After(entries []Entry, key Key) []Entry {
+	i := sort.Search(len(entries), func(i int) bool { return key.less(entries[i].Key()) })
+	return entries[i:]
+}
@@ -0,0 +1,82 @@
+package leaderboard
+
+import (
+	"testing"
+	"time"
+)
+
+var day = time.Date(2030, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func round(id, playerID int64, daysIn, gross, net int) Round {
+	return Round{ScorecardID: id, PlayerID: playerID, PlayedAt: day.AddDate(0, 0, daysIn), Gross: gross, Net: net}
+}
+
+func TestRank_BestRoundPerPlayer(t *testing.T) {
+	entries := Rank([]Round{
+		round(1, 1, 0, 80, 70),
+		round(2, 1, 1, 78, 72),
+		round(3, 2, 0, 85, 68),
+	}, false)
+
+	if len(entries) != 2 {
+		t.Fatalf("expected one entry per player, got %+v", entries)
+	}
+	if entries[0].PlayerID != 1 || entries[0].ScorecardID != 2 || entries[0].Score != 78 {
+		t.Errorf("expected player 1's 78 to lead, got %+v", entries[0])
+	}
+
+	entries = Rank([]Round{
+		round(1, 1, 0, 80, 70),
+		round(2, 1, 1, 78, 72),
+		round(3, 2, 0, 85, 68),
+	}, true)
+	if entries[0].PlayerID != 2 || entries[0].Score != 68 || entries[1].ScorecardID != 1 {
+		t.Errorf("expected net scores to rank player 2 first and keep player 1's net 70, got %+v", entries)
+	}
+}
+
+func TestRank_Ties(t *testing.T) {
+	entries := Rank([]Round{
+		round(1, 3, 2, 75, 75),
+		round(2, 2, 1, 75, 75),
+		round(3, 4, 1, 75, 75),
+		round(4, 1, 0, 74, 74),
+		round(5, 5, 0, 80, 80),
+	}, false)
+
+	wantPlayers := []int64{1, 2, 4, 3, 5}
+	wantPositions := []int{1, 2, 2, 2, 5}
+	for i, e := range entries {
+		if e.PlayerID != wantPlayers[i] || e.Position != wantPositions[i] {
+			t.Errorf("entry %d: expected player %d in position %d, got %+v", i, wantPlayers[i], wantPositions[i], e)
+		}
+	}
+}
+
+func TestRank_SameScoreTwice(t *testing.T) {
+	entries := Rank([]Round{
+		round(9, 1, 0, 75, 75),
+		round(4, 1, 0, 75, 75),
+	}, false)
+
+	if len(entries) != 1 || entries[0].ScorecardID != 4 {
+		t.Errorf("expected the lower scorecard to break an exact tie, got %+v", entries)
+	}
+}
+
+func TestAfter(t *testing.T) {
+	entries := Rank([]Round{
+		round(1, 1, 0, 72, 72),
+		round(2, 2, 0, 74, 74),
+		round(3, 3, 1, 74, 74),
+		round(4, 4, 0, 76, 76),
+	}, false)
+
+	rest := After(entries, entries[1].Key())
+	if len(rest) != 2 || rest[0].PlayerID != 3 || rest[0].Position != 2 {
+		t.Errorf("expected the rest of the board from player 3, keeping their position, got %+v", rest)
+	}
+	if rest := After(entries, entries[3].Key()); len(rest) != 0 {
+		t.Errorf("expected nothing after the last entry, got %+v", rest)
+	}
+}
@@ -0,0 +1,134 @@
+package league
+
+import (
+	"sort"
+
+	"github.com/ericrabun/findfore-go/internal/games"
+)
+
+// Rules is how a season awards points for each round.
+type Rules struct {
+	Net bool
+	// FinishPoints is the points for finishing first, second and so on.
+	// Finishing lower earns nothing.
+	FinishPoints []float64
+	// MatchWinPoints and MatchHalvePoints are earned in a match against
+	// every other member in the round.
+	MatchWinPoints   float64
+	MatchHalvePoints float64
+}
+
+// Result is how a member did in one round.
+type Result struct {
+	PlayerID     int64
+	Score        int
+	Position     int
+	FinishPoints float64
+	MatchPoints  float64
+}
+
+// Standing is a member's place in the season.
+type Standing struct {
+	PlayerID     int64
+	Position     int
+	Rounds       int
+	FinishPoints float64
+	MatchPoints  float64
+	Points       float64
+}
+
+// Round scores one round of the season. Only cards with all holes played
+// count, so a member who didn't finish earns nothing. Members who tie split
+// the points for the places they share.
+func Round(rules Rules, holes int, cards []games.Card) []Result {
+	var finished []games.Card
+	for _, c := range cards {
+		if len(c.Holes) == holes {
+			finished = append(finished, c)
+		}
+	}
+
+	results := make([]Result, len(finished))
+	for i, c := range finished {
+		results[i] = Result{PlayerID: c.PlayerID, Score: games.Score(rules.Net, c)}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score < results[j].Score
+		}
+		return results[i].PlayerID < results[j].PlayerID
+	})
+	for start := 0; start < len(results); {
+		end := start + 1
+		for end < len(results) && results[end].Score == results[start].Score {
+			end++
+		}
+		var points float64
+		for place := start; place < end && place < len(rules.FinishPoints); place++ {
+			points += rules.FinishPoints[place]
+		}
+		for i := start; i < end; i++ {
+			results[i].Position = start + 1
+			results[i].FinishPoints = points / float64(end-start)
+		}
+		start = end
+	}
+
+	if rules.MatchWinPoints != 0 || rules.MatchHalvePoints != 0 {
+		index := make(map[int64]int, len(results))
+		for i, r := range results {
+			index[r.PlayerID] = i
+		}
+		for i, a := range finished {
+			for _, b := range finished[i+1:] {
+				winner, ok := games.Match(rules.Net, a, b)
+				if !ok {
+					results[index[a.PlayerID]].MatchPoints += rules.MatchHalvePoints
+					results[index[b.PlayerID]].MatchPoints += rules.MatchHalvePoints
+					continue
+				}
+				results[index[winner]].MatchPoints += rules.MatchWinPoints
+			}
+		}
+	}
+	return results
+}
+
+// Standings totals the rounds for each member, most points first. Members
+// who haven't played a round are listed with none.
+func Standings(members []int64, rounds [][]Result) []Standing {
+	totals := make(map[int64]*Standing, len(members))
+	standings := make([]Standing, 0, len(members))
+	for _, id := range members {
+		totals[id] = &Standing{PlayerID: id}
+	}
+	for _, round := range rounds {
+		for _, r := range round {
+			s, ok := totals[r.PlayerID]
+			if !ok {
+				continue
+			}
+			s.Rounds++
+			s.FinishPoints += r.FinishPoints
+			s.MatchPoints += r.MatchPoints
+			s.Points += r.FinishPoints + r.MatchPoints
+		}
+	}
+	for _, id := range members {
+		standings = append(standings, *totals[id])
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		return standings[i].PlayerID < standings[j].PlayerID
+	})
+	for i := range standings {
+		standings[i].Position = i + 1
+		if i > 0 && standings[i].Points == standings[i-1].Points {
+			standings[i].Position = standings[i-1].Position
+		}
+	}
+	return standings
+}
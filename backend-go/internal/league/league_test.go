@@ -0,0 +1,95 @@
+package league
+
+import (
+	"testing"
+
+	"github.com/ericrabun/findfore-go/internal/games"
+)
+
+func card(playerID int64, courseHandicap int, strokes ...int32) games.Card {
+	c := games.Card{PlayerID: playerID, CourseHandicap: courseHandicap, Holes: map[int32]games.Hole{}}
+	for i, s := range strokes {
+		c.Holes[int32(i+1)] = games.Hole{Strokes: s, Par: 4, StrokeIndex: int32(i + 1)}
+	}
+	return c
+}
+
+func TestRound_FinishPoints(t *testing.T) {
+	rules := Rules{FinishPoints: []float64{10, 6, 3}}
+	results := Round(rules, 3, []games.Card{
+		card(1, 0, 4, 4, 4),
+		card(2, 0, 4, 4, 5),
+		card(3, 0, 4, 5, 4),
+		card(4, 0, 5, 5, 5),
+		card(5, 0, 3, 3), // didn't finish
+	})
+
+	if len(results) != 4 {
+		t.Fatalf("expected four finished cards, got %+v", results)
+	}
+	want := []Result{
+		{PlayerID: 1, Score: 12, Position: 1, FinishPoints: 10},
+		{PlayerID: 2, Score: 13, Position: 2, FinishPoints: 4.5},
+		{PlayerID: 3, Score: 13, Position: 2, FinishPoints: 4.5},
+		{PlayerID: 4, Score: 15, Position: 4, FinishPoints: 0},
+	}
+	for i, w := range want {
+		if results[i] != w {
+			t.Errorf("expected %+v, got %+v", w, results[i])
+		}
+	}
+}
+
+func TestRound_Net(t *testing.T) {
+	rules := Rules{Net: true, FinishPoints: []float64{2, 1}}
+	results := Round(rules, 2, []games.Card{card(1, 0, 4, 4), card(2, 2, 5, 4)})
+
+	if results[0].PlayerID != 2 || results[0].Score != 7 {
+		t.Errorf("expected player 2 to win on a net 7, got %+v", results)
+	}
+}
+
+func TestRound_MatchPoints(t *testing.T) {
+	rules := Rules{MatchWinPoints: 2, MatchHalvePoints: 1}
+	results := Round(rules, 2, []games.Card{
+		card(1, 0, 3, 5),
+		card(2, 0, 4, 4),
+		card(3, 0, 5, 5),
+	})
+
+	// 1 halves with 2 and beats 3; 2 beats 3.
+	points := map[int64]float64{}
+	for _, r := range results {
+		points[r.PlayerID] = r.MatchPoints
+	}
+	if points[1] != 3 || points[2] != 3 || points[3] != 0 {
+		t.Errorf("expected 3/3/0 match points, got %v", points)
+	}
+}
+
+func TestStandings(t *testing.T) {
+	rounds := [][]Result{
+		{{PlayerID: 1, FinishPoints: 10}, {PlayerID: 2, FinishPoints: 6, MatchPoints: 2}},
+		{{PlayerID: 2, FinishPoints: 10}, {PlayerID: 3, FinishPoints: 2}},
+	}
+	standings := Standings([]int64{1, 2, 3, 4}, rounds)
+
+	want := []Standing{
+		{PlayerID: 2, Position: 1, Rounds: 2, FinishPoints: 16, MatchPoints: 2, Points: 18},
+		{PlayerID: 1, Position: 2, Rounds: 1, FinishPoints: 10, Points: 10},
+		{PlayerID: 3, Position: 3, Rounds: 1, FinishPoints: 2, Points: 2},
+		{PlayerID: 4, Position: 4},
+	}
+	for i, w := range want {
+		if standings[i] != w {
+			t.Errorf("expected %+v, got %+v", w, standings[i])
+		}
+	}
+}
+
+func TestStandings_TiesSharePosition(t *testing.T) {
+	standings := Standings([]int64{1, 2, 3}, [][]Result{{{PlayerID: 1, FinishPoints: 5}, {PlayerID: 2, FinishPoints: 5}}})
+	if standings[0].Position != 1 || standings[1].Position != 1 || standings[2].Position != 3 {
+		t.Errorf("expected positions 1, 1, 3, got %+v", standings)
+	}
+}
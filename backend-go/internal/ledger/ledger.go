@@ -0,0 +1,139 @@
+package ledger
+
+import "sort"
+
+// Share is one player's part in an event's costs. Weight is the number of
+// spots they take, so a player bringing two guests has a weight of 3.
+type Share struct {
+	PlayerID int64
+	Weight   int64
+}
+
+// Expense is a payment one player made for the group.
+type Expense struct {
+	PayerID     int64
+	AmountCents int64
+}
+
+// Balance is where a player stands on an event: what they paid, what their
+// share of the costs came to, and the difference. A positive balance means
+// the others owe them.
+type Balance struct {
+	PlayerID     int64
+	PaidCents    int64
+	ShareCents   int64
+	BalanceCents int64
+}
+
+// Debt is an amount one player owes another.
+type Debt struct {
+	From        int64
+	To          int64
+	AmountCents int64
+}
+
+// Split divides amount between the shares in proportion to their weights.
+// Cents that don't divide evenly go to the largest remainders, earliest share
+// first, so the parts always add up to amount.
+func Split(amount int64, shares []Share) map[int64]int64 {
+	parts := make(map[int64]int64, len(shares))
+	var total int64
+	for _, s := range shares {
+		total += s.Weight
+	}
+	if total <= 0 {
+		return parts
+	}
+
+	remainders := make([]int64, len(shares))
+	order := make([]int, len(shares))
+	left := amount
+	for i, s := range shares {
+		parts[s.PlayerID] += amount * s.Weight / total
+		remainders[i] = amount * s.Weight % total
+		left -= amount * s.Weight / total
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for i := 0; left > 0; i++ {
+		parts[shares[order[i%len(order)]].PlayerID]++
+		left--
+	}
+	return parts
+}
+
+// Balances works out each player's balance for an event. Every expense is
+// split between the shares; payers who aren't sharing the costs are included
+// with a share of zero. Results are ordered by player ID.
+func Balances(expenses []Expense, shares []Share) []Balance {
+	byID := map[int64]*Balance{}
+	get := func(id int64) *Balance {
+		b, ok := byID[id]
+		if !ok {
+			b = &Balance{PlayerID: id}
+			byID[id] = b
+		}
+		return b
+	}
+
+	for _, s := range shares {
+		get(s.PlayerID)
+	}
+	for _, e := range expenses {
+		get(e.PayerID).PaidCents += e.AmountCents
+		for id, part := range Split(e.AmountCents, shares) {
+			get(id).ShareCents += part
+		}
+	}
+
+	out := make([]Balance, 0, len(byID))
+	for _, b := range byID {
+		b.BalanceCents = b.PaidCents - b.ShareCents
+		out = append(out, *b)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PlayerID < out[j].PlayerID })
+	return out
+}
+
+// Debts works out who owes whom for an event: each player owes every payer
+// their share of what that payer spent. Debts running both ways between two
+// players are netted into one. Results are ordered by From, then To.
+func Debts(expenses []Expense, shares []Share) []Debt {
+	var debts []Debt
+	for _, e := range expenses {
+		for id, part := range Split(e.AmountCents, shares) {
+			if id == e.PayerID || part == 0 {
+				continue
+			}
+			debts = append(debts, Debt{From: id, To: e.PayerID, AmountCents: part})
+		}
+	}
+	return Net(debts)
+}
+
+// Net adds up debts between the same two players, netting ones that run
+// both ways into one. Results are ordered by From, then To.
+func Net(debts []Debt) []Debt {
+	type pair struct{ from, to int64 }
+	owed := map[pair]int64{}
+	for _, d := range debts {
+		owed[pair{d.From, d.To}] += d.AmountCents
+	}
+
+	var out []Debt
+	for p, amount := range owed {
+		net := amount - owed[pair{p.to, p.from}]
+		if net > 0 {
+			out = append(out, Debt{From: p.from, To: p.to, AmountCents: net})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].From != out[j].From {
+			return out[i].From < out[j].From
+		}
+		return out[i].To < out[j].To
+	})
+	return out
+}
@@ -0,0 +1,63 @@
+package ledger
+
+import "testing"
+
+func TestSplit_RoundsToTheCent(t *testing.T) {
+	parts := Split(1000, []Share{{PlayerID: 1, Weight: 1}, {PlayerID: 2, Weight: 1}, {PlayerID: 3, Weight: 1}})
+	if parts[1] != 334 || parts[2] != 333 || parts[3] != 333 {
+		t.Errorf("expected 334/333/333, got %v", parts)
+	}
+}
+
+func TestSplit_Weighted(t *testing.T) {
+	// Player 1 brings two guests
+	parts := Split(12000, []Share{{PlayerID: 1, Weight: 3}, {PlayerID: 2, Weight: 1}})
+	if parts[1] != 9000 || parts[2] != 3000 {
+		t.Errorf("expected 9000/3000, got %v", parts)
+	}
+}
+
+func TestBalances(t *testing.T) {
+	shares := []Share{{PlayerID: 1, Weight: 1}, {PlayerID: 2, Weight: 1}, {PlayerID: 3, Weight: 1}, {PlayerID: 4, Weight: 1}}
+	balances := Balances([]Expense{
+		{PayerID: 1, AmountCents: 24000}, // tee times
+		{PayerID: 2, AmountCents: 4000},  // carts
+	}, shares)
+
+	want := map[int64]int64{1: 17000, 2: -3000, 3: -7000, 4: -7000}
+	var sum int64
+	for _, b := range balances {
+		if b.BalanceCents != want[b.PlayerID] {
+			t.Errorf("player %d: expected balance %d, got %d", b.PlayerID, want[b.PlayerID], b.BalanceCents)
+		}
+		sum += b.BalanceCents
+	}
+	if sum != 0 {
+		t.Errorf("expected balances to sum to zero, got %d", sum)
+	}
+}
+
+func TestDebts_Netted(t *testing.T) {
+	shares := []Share{{PlayerID: 1, Weight: 1}, {PlayerID: 2, Weight: 1}}
+	debts := Debts([]Expense{
+		{PayerID: 1, AmountCents: 5000},
+		{PayerID: 2, AmountCents: 2000},
+	}, shares)
+
+	if len(debts) != 1 || debts[0] != (Debt{From: 2, To: 1, AmountCents: 1500}) {
+		t.Errorf("expected 2 to owe 1 1500, got %+v", debts)
+	}
+}
+
+func TestNet(t *testing.T) {
+	debts := Net([]Debt{
+		{From: 1, To: 2, AmountCents: 500},
+		{From: 2, To: 1, AmountCents: 200},
+		{From: 1, To: 2, AmountCents: 100},
+		{From: 3, To: 1, AmountCents: 300},
+		{From: 1, To: 3, AmountCents: 300},
+	})
+	if len(debts) != 1 || debts[0] != (Debt{From: 1, To: 2, AmountCents: 400}) {
+		t.Errorf("expected 1 to owe 2 400, got %+v", debts)
+	}
+}
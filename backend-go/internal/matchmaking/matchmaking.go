@@ -0,0 +1,171 @@
+package matchmaking
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Score weights. Handicap similarity matters most, then how close the course
+// is and who's playing, and finally how well the tee time fits the window.
+const (
+	handicapWeight = 0.35
+	distanceWeight = 0.25
+	socialWeight   = 0.25
+	timeWeight     = 0.15
+
+	// Each factor halves at these distances from a perfect fit.
+	handicapHalf = 5.0  // strokes
+	distanceHalf = 15.0 // kilometers
+	timeHalf     = 12.0 // hours
+
+	// neutral is the score of a factor that can't be judged, like handicap
+	// similarity when nobody's index is known.
+	neutral = 0.5
+
+	earthRadiusKm = 6371.0
+)
+
+// Point is a location in degrees.
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// DistanceKm is the great-circle distance between two points.
+func DistanceKm(a, b Point) float64 {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(b.Lat - a.Lat)
+	dLng := rad(b.Lng - a.Lng)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(a.Lat))*math.Cos(rad(b.Lat))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Player is someone already accepted into an event, as seen by the player
+// searching. Handicap is nil when unknown.
+type Player struct {
+	ID       int64
+	Handicap *float64
+	// Followed is set when the searcher follows the player.
+	Followed bool
+	// FriendsFollowing counts the players the searcher follows who follow
+	// the player too.
+	FriendsFollowing int
+	// PlayWith and Avoid are the searcher's pairing preferences for the
+	// player.
+	PlayWith bool
+	Avoid    bool
+}
+
+// Event is an open event the searcher could join. Location is nil when the
+// course hasn't been placed on a map.
+type Event struct {
+	ID       int64
+	StartsAt time.Time
+	Location *Point
+	Roster   []Player
+}
+
+// Search is who's looking for a game and when they'd like to play.
+type Search struct {
+	Handicap *float64
+	Home     *Point
+	From     time.Time
+	To       time.Time
+}
+
+// Match is a recommended event with the reasons behind its score.
+type Match struct {
+	Event
+	Score float64
+	// HandicapGap is how far the searcher's index is from the roster's
+	// average, nil when either is unknown.
+	HandicapGap *float64
+	// DistanceKm is from the searcher's home course, nil when either course
+	// has no location.
+	DistanceKm *float64
+	// Friends counts roster players the searcher follows or asked to play
+	// with.
+	Friends int
+	// HoursAway is how far the tee time falls outside the search window.
+	HoursAway float64
+}
+
+// halving scores a perfect fit 1 and halves the score every half units away.
+func halving(away, half float64) float64 {
+	return 1 / (1 + away/half)
+}
+
+// Rank scores each event for the search and orders them best first. Events
+// with a player the searcher avoids are left out. Equal scores are ordered by
+// tee time, then event ID.
+func Rank(search Search, events []Event) []Match {
+	matches := make([]Match, 0, len(events))
+outer:
+	for _, e := range events {
+		m := Match{Event: e}
+
+		var total float64
+		var known int
+		var social float64
+		for _, p := range e.Roster {
+			if p.Avoid {
+				continue outer
+			}
+			if p.Handicap != nil {
+				total += *p.Handicap
+				known++
+			}
+			switch {
+			case p.PlayWith:
+				social += 1.5
+				m.Friends++
+			case p.Followed:
+				social++
+				m.Friends++
+			case p.FriendsFollowing > 0:
+				social += 0.5
+			}
+		}
+
+		handicapScore := neutral
+		if search.Handicap != nil && known > 0 {
+			gap := math.Abs(*search.Handicap - total/float64(known))
+			m.HandicapGap = &gap
+			handicapScore = halving(gap, handicapHalf)
+		}
+
+		distanceScore := neutral
+		if search.Home != nil && e.Location != nil {
+			km := DistanceKm(*search.Home, *e.Location)
+			m.DistanceKm = &km
+			distanceScore = halving(km, distanceHalf)
+		}
+
+		switch {
+		case e.StartsAt.Before(search.From):
+			m.HoursAway = search.From.Sub(e.StartsAt).Hours()
+		case e.StartsAt.After(search.To):
+			m.HoursAway = e.StartsAt.Sub(search.To).Hours()
+		}
+
+		m.Score = handicapWeight*handicapScore +
+			distanceWeight*distanceScore +
+			socialWeight*(1-1/(1+social)) +
+			timeWeight*halving(m.HoursAway, timeHalf)
+		matches = append(matches, m)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.StartsAt.Equal(b.StartsAt) {
+			return a.StartsAt.Before(b.StartsAt)
+		}
+		return a.ID < b.ID
+	})
+	return matches
+}
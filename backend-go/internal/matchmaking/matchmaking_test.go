@@ -0,0 +1,99 @@
+package matchmaking
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	from = time.Date(2030, 6, 1, 8, 0, 0, 0, time.UTC)
+	to   = time.Date(2030, 6, 1, 11, 0, 0, 0, time.UTC)
+)
+
+func hcp(v float64) *float64 { return &v }
+
+func ids(matches []Match) []int64 {
+	out := make([]int64, len(matches))
+	for i, m := range matches {
+		out[i] = m.ID
+	}
+	return out
+}
+
+func TestDistanceKm(t *testing.T) {
+	// Denver to Boulder is about 39 km.
+	d := DistanceKm(Point{39.7392, -104.9903}, Point{40.0150, -105.2705})
+	if math.Abs(d-38.8) > 1 {
+		t.Errorf("expected about 39 km, got %.1f", d)
+	}
+	if d := DistanceKm(Point{39.7, -105}, Point{39.7, -105}); d != 0 {
+		t.Errorf("expected 0 for the same point, got %f", d)
+	}
+}
+
+func TestRank_HandicapSimilarity(t *testing.T) {
+	search := Search{Handicap: hcp(12), From: from, To: to}
+	matches := Rank(search, []Event{
+		{ID: 1, StartsAt: from, Roster: []Player{{ID: 10, Handicap: hcp(30)}}},
+		{ID: 2, StartsAt: from, Roster: []Player{{ID: 11, Handicap: hcp(10)}, {ID: 12, Handicap: hcp(16)}}},
+		{ID: 3, StartsAt: from, Roster: []Player{{ID: 13}}},
+	})
+
+	if got := ids(matches); got[0] != 2 || got[1] != 3 || got[2] != 1 {
+		t.Fatalf("expected the closest roster first and unknown handicaps in the middle, got %v", got)
+	}
+	if matches[0].HandicapGap == nil || *matches[0].HandicapGap != 1 {
+		t.Errorf("expected a gap of 1 to the roster average of 13, got %v", matches[0].HandicapGap)
+	}
+	if matches[1].HandicapGap != nil {
+		t.Errorf("expected no gap without roster handicaps, got %v", *matches[1].HandicapGap)
+	}
+}
+
+func TestRank_DistanceAndTime(t *testing.T) {
+	home := &Point{39.7392, -104.9903}
+	near := &Point{39.7509, -104.9520}
+	far := &Point{40.0150, -105.2705}
+	matches := Rank(Search{Home: home, From: from, To: to}, []Event{
+		{ID: 1, StartsAt: from, Location: far},
+		{ID: 2, StartsAt: from, Location: near},
+		{ID: 3, StartsAt: to.Add(24 * time.Hour), Location: near},
+	})
+
+	if got := ids(matches); got[0] != 2 || got[1] != 3 || got[2] != 1 {
+		t.Errorf("expected the nearby course first, the next day after, got %v", got)
+	}
+	if matches[1].HoursAway != 24 || matches[0].HoursAway != 0 {
+		t.Errorf("expected the next day to be 24 hours away, got %v and %v", matches[1].HoursAway, matches[0].HoursAway)
+	}
+}
+
+func TestRank_Social(t *testing.T) {
+	matches := Rank(Search{From: from, To: to}, []Event{
+		{ID: 1, StartsAt: from, Roster: []Player{{ID: 10}}},
+		{ID: 2, StartsAt: from, Roster: []Player{{ID: 11, FriendsFollowing: 2}}},
+		{ID: 3, StartsAt: from, Roster: []Player{{ID: 12, Followed: true}}},
+		{ID: 4, StartsAt: from, Roster: []Player{{ID: 13, PlayWith: true}}},
+		{ID: 5, StartsAt: from, Roster: []Player{{ID: 14, Followed: true}, {ID: 15, Avoid: true}}},
+	})
+
+	if got := ids(matches); len(got) != 4 || got[0] != 4 || got[1] != 3 || got[2] != 2 || got[3] != 1 {
+		t.Errorf("expected play-with, followed, friend of friend, stranger and no avoided player, got %v", got)
+	}
+	if matches[0].Friends != 1 || matches[2].Friends != 0 {
+		t.Errorf("expected friends counted only for followed or play-with players, got %+v", matches)
+	}
+}
+
+func TestRank_TiesAreDeterministic(t *testing.T) {
+	matches := Rank(Search{From: from, To: to}, []Event{
+		{ID: 3, StartsAt: from.Add(time.Hour)},
+		{ID: 2, StartsAt: from.Add(time.Hour)},
+		{ID: 1, StartsAt: from.Add(2 * time.Hour)},
+	})
+
+	if got := ids(matches); got[0] != 2 || got[1] != 3 || got[2] != 1 {
+		t.Errorf("expected ties ordered by tee time then ID, got %v", got)
+	}
+}
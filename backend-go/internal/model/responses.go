@@ -1,41 +1,444 @@
 package model
 
 type CourseResponse struct {
-	ID      int64  `json:"id"`
-	Name    string `json:"name"`
-	Street  string `json:"street"`
-	City    string `json:"city"`
-	State   string `json:"state"`
-	ZipCode string `json:"zip_code"`
-	Phone   string `json:"phone"`
-	Cost    string `json:"cost"`
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Street      string   `json:"street"`
+	City        string   `json:"city"`
+	State       string   `json:"state"`
+	ZipCode     string   `json:"zip_code"`
+	Phone       string   `json:"phone"`
+	Cost        string   `json:"cost"`
+	FeeCents    *int32   `json:"fee_cents"`
+	FeeCurrency string   `json:"fee_currency"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	TimeZone    string   `json:"time_zone"`
+}
+
+type TeeSetHoleResponse struct {
+	Hole        int32  `json:"hole"`
+	Par         int32  `json:"par"`
+	StrokeIndex int32  `json:"stroke_index"`
+	Yardage     *int32 `json:"yardage"`
+}
+
+type TeeSetResponse struct {
+	ID           int64                `json:"id"`
+	Name         string               `json:"name"`
+	Color        string               `json:"color"`
+	CourseRating float64              `json:"course_rating"`
+	SlopeRating  int32                `json:"slope_rating"`
+	Yardage      *int32               `json:"yardage"`
+	Par          int32                `json:"par"`
+	Holes        []TeeSetHoleResponse `json:"holes"`
+}
+
+type CourseDetailResponse struct {
+	CourseResponse
+	TeeSets []TeeSetResponse `json:"tee_sets"`
 }
 
 type EventResponse struct {
-	ID             int64   `json:"id"`
-	CourseName     string  `json:"course_name"`
-	Date           string  `json:"date"`
-	TeeTime        string  `json:"tee_time"`
-	OpenSpots      int32   `json:"open_spots"`
-	NumberOfHoles  string  `json:"number_of_holes"`
-	Private        bool    `json:"private"`
-	HostName       string  `json:"host_name"`
-	HostID         int32   `json:"host_id"`
-	Accepted       []int64 `json:"accepted"`
-	Declined       []int64 `json:"declined"`
-	Pending        []int64 `json:"pending"`
-	Closed         []int64 `json:"closed"`
-	RemainingSpots int32   `json:"remaining_spots"`
+	ID             int64           `json:"id"`
+	CourseName     string          `json:"course_name"`
+	Date           string          `json:"date"`
+	TeeTime        string          `json:"tee_time"`
+	OpenSpots      int32           `json:"open_spots"`
+	NumberOfHoles  string          `json:"number_of_holes"`
+	Private        bool            `json:"private"`
+	HostName       string          `json:"host_name"`
+	HostID         int32           `json:"host_id"`
+	Cohosts        []int64         `json:"cohosts"`
+	Accepted       []int64         `json:"accepted"`
+	Declined       []int64         `json:"declined"`
+	Pending        []int64         `json:"pending"`
+	Closed         []int64         `json:"closed"`
+	Expired        []int64         `json:"expired"`
+	Guests         []GuestResponse `json:"guests"`
+	RemainingSpots int32           `json:"remaining_spots"`
+	Status         string          `json:"status"`
+	Cancelled      bool            `json:"cancelled"`
+	RespondBy      *string         `json:"respond_by"`
+	FeeCents       *int32          `json:"fee_cents"`
+	FeeCurrency    string          `json:"fee_currency"`
+}
+
+type GuestResponse struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	SponsorID int64  `json:"sponsor_id"`
+}
+
+type GroupResponse struct {
+	ID        int64   `json:"id"`
+	TeeTime   string  `json:"tee_time"`
+	PlayerIDs []int64 `json:"player_ids"`
+	GuestIDs  []int64 `json:"guest_ids"`
+	Spots     int     `json:"spots"`
+}
+
+type EventGroupsResponse struct {
+	Groups    []GroupResponse `json:"groups"`
+	Ungrouped []int64         `json:"ungrouped"`
+}
+
+type ExpenseResponse struct {
+	ID          int64  `json:"id"`
+	EventID     int64  `json:"event_id"`
+	PayerID     int64  `json:"payer_id"`
+	AmountCents int32  `json:"amount_cents"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type BalanceResponse struct {
+	PlayerID     int64 `json:"player_id"`
+	PaidCents    int64 `json:"paid_cents"`
+	ShareCents   int64 `json:"share_cents"`
+	BalanceCents int64 `json:"balance_cents"`
+}
+
+type DebtResponse struct {
+	FromPlayerID int64 `json:"from_player_id"`
+	ToPlayerID   int64 `json:"to_player_id"`
+	AmountCents  int64 `json:"amount_cents"`
+}
+
+type EventLedgerResponse struct {
+	EventID    int64             `json:"event_id"`
+	Currency   string            `json:"currency"`
+	TotalCents int64             `json:"total_cents"`
+	Expenses   []ExpenseResponse `json:"expenses"`
+	Balances   []BalanceResponse `json:"balances"`
+	Debts      []DebtResponse    `json:"debts"`
+}
+
+type HoleScoreResponse struct {
+	Hole              int32  `json:"hole"`
+	Strokes           int32  `json:"strokes"`
+	Putts             *int32 `json:"putts"`
+	FairwayHit        *bool  `json:"fairway_hit"`
+	GreenInRegulation *bool  `json:"green_in_regulation"`
+	Par               *int32 `json:"par"`
+}
+
+type ScorecardTotalsResponse struct {
+	HolesPlayed        int  `json:"holes_played"`
+	Strokes            int  `json:"strokes"`
+	Putts              int  `json:"putts"`
+	Par                int  `json:"par"`
+	ToPar              *int `json:"to_par"`
+	FairwaysHit        int  `json:"fairways_hit"`
+	Fairways           int  `json:"fairways"`
+	GreensInRegulation int  `json:"greens_in_regulation"`
+	Greens             int  `json:"greens"`
+}
+
+type ScorecardResponse struct {
+	PlayerID  int64                   `json:"player_id"`
+	TeeSetID  *int64                  `json:"tee_set_id"`
+	EnteredBy *int64                  `json:"entered_by"`
+	UpdatedAt *string                 `json:"updated_at"`
+	Holes     []HoleScoreResponse     `json:"holes"`
+	Totals    ScorecardTotalsResponse `json:"totals"`
+}
+
+type EventScoresResponse struct {
+	EventID       int64               `json:"event_id"`
+	NumberOfHoles int32               `json:"number_of_holes"`
+	Scorecards    []ScorecardResponse `json:"scorecards"`
+}
+
+type GameResultResponse struct {
+	PlayerID      int64 `json:"player_id"`
+	Points        int   `json:"points"`
+	WinningsCents int64 `json:"winnings_cents"`
+}
+
+type GameResponse struct {
+	ID         int64                `json:"id"`
+	Format     string               `json:"format"`
+	Net        bool                 `json:"net"`
+	StakeCents int32                `json:"stake_cents"`
+	Carryover  bool                 `json:"carryover"`
+	PlayerIDs  []int64              `json:"player_ids"`
+	SettledAt  *string              `json:"settled_at"`
+	Results    []GameResultResponse `json:"results"`
+	Debts      []DebtResponse       `json:"debts"`
+}
+
+type RoundBalanceResponse struct {
+	PlayerID      int64 `json:"player_id"`
+	ExpensesCents int64 `json:"expenses_cents"`
+	GamesCents    int64 `json:"games_cents"`
+	TotalCents    int64 `json:"total_cents"`
+}
+
+type RoundSettlementResponse struct {
+	Balances []RoundBalanceResponse `json:"balances"`
+	Debts    []DebtResponse         `json:"debts"`
+}
+
+type EventGamesResponse struct {
+	EventID    int64                   `json:"event_id"`
+	Currency   string                  `json:"currency"`
+	Games      []GameResponse          `json:"games"`
+	Settlement RoundSettlementResponse `json:"settlement"`
+}
+
+type LeagueSeasonResponse struct {
+	ID               int64   `json:"id"`
+	Name             string  `json:"name"`
+	CourseID         int64   `json:"course_id"`
+	StartsOn         string  `json:"starts_on"`
+	TeeTime          string  `json:"tee_time"`
+	OpenSpots        int32   `json:"open_spots"`
+	NumberOfHoles    string  `json:"number_of_holes"`
+	RRule            string  `json:"rrule"`
+	Net              bool    `json:"net"`
+	FinishPoints     []int32 `json:"finish_points"`
+	MatchWinPoints   int32   `json:"match_win_points"`
+	MatchHalvePoints int32   `json:"match_halve_points"`
+	EventIDs         []int64 `json:"event_ids"`
+}
+
+type LeagueResponse struct {
+	ID             int64                  `json:"id"`
+	Name           string                 `json:"name"`
+	CommissionerID int64                  `json:"commissioner_id"`
+	MemberIDs      []int64                `json:"member_ids"`
+	Seasons        []LeagueSeasonResponse `json:"seasons"`
+}
+
+type LeagueStandingResponse struct {
+	PlayerID     int64   `json:"player_id"`
+	Position     int     `json:"position"`
+	Rounds       int     `json:"rounds"`
+	FinishPoints float64 `json:"finish_points"`
+	MatchPoints  float64 `json:"match_points"`
+	Points       float64 `json:"points"`
+}
+
+type LeagueRoundResultResponse struct {
+	PlayerID     int64   `json:"player_id"`
+	Score        int     `json:"score"`
+	Position     int     `json:"position"`
+	FinishPoints float64 `json:"finish_points"`
+	MatchPoints  float64 `json:"match_points"`
+}
+
+type LeagueRoundResponse struct {
+	EventID int64                       `json:"event_id"`
+	Date    string                      `json:"date"`
+	Results []LeagueRoundResultResponse `json:"results"`
+}
+
+type LeagueStandingsResponse struct {
+	LeagueID  int64                    `json:"league_id"`
+	SeasonID  int64                    `json:"season_id"`
+	Standings []LeagueStandingResponse `json:"standings"`
+	Rounds    []LeagueRoundResponse    `json:"rounds"`
+}
+
+type TournamentPlayerResponse struct {
+	PlayerID int64  `json:"player_id"`
+	Seed     *int32 `json:"seed"`
+}
+
+type TournamentMatchResponse struct {
+	ID        int64  `json:"id"`
+	Round     int32  `json:"round"`
+	Position  int32  `json:"position"`
+	Player1ID *int64 `json:"player1_id"`
+	Player2ID *int64 `json:"player2_id"`
+	EventID   *int64 `json:"event_id"`
+	WinnerID  *int64 `json:"winner_id"`
+	Result    string `json:"result"`
+}
+
+type TournamentRoundResponse struct {
+	Round   int32                     `json:"round"`
+	Matches []TournamentMatchResponse `json:"matches"`
+}
+
+type TournamentResponse struct {
+	ID            int64                      `json:"id"`
+	Name          string                     `json:"name"`
+	HostID        int64                      `json:"host_id"`
+	CourseID      int64                      `json:"course_id"`
+	NumberOfHoles string                     `json:"number_of_holes"`
+	Net           bool                       `json:"net"`
+	Status        string                     `json:"status"`
+	WinnerID      *int64                     `json:"winner_id"`
+	Players       []TournamentPlayerResponse `json:"players"`
+	Rounds        []TournamentRoundResponse  `json:"rounds"`
+}
+
+type SeriesResponse struct {
+	ID            int64           `json:"id"`
+	HostID        int64           `json:"host_id"`
+	CourseID      int64           `json:"course_id"`
+	StartsOn      string          `json:"starts_on"`
+	TeeTime       string          `json:"tee_time"`
+	OpenSpots     int32           `json:"open_spots"`
+	NumberOfHoles string          `json:"number_of_holes"`
+	Private       bool            `json:"private"`
+	RRule         string          `json:"rrule"`
+	Exceptions    []string        `json:"exceptions"`
+	Invitees      []int64         `json:"invitees"`
+	Occurrences   []EventResponse `json:"occurrences"`
 }
 
 type PlayerResponse struct {
-	ID       int64   `json:"id"`
-	Name     string  `json:"name"`
-	Phone    string  `json:"phone"`
-	Email    string  `json:"email"`
-	Username string  `json:"username"`
-	Friends  []int64 `json:"friends"`
-	Events   []int64 `json:"events"`
+	ID            int64    `json:"id"`
+	Name          string   `json:"name"`
+	Phone         string   `json:"phone"`
+	Email         string   `json:"email"`
+	Username      string   `json:"username"`
+	Friends       []int64  `json:"friends"`
+	Events        []int64  `json:"events"`
+	HandicapIndex *float64 `json:"handicap_index"`
+}
+
+type PairingPreferenceResponse struct {
+	OtherPlayerID int64  `json:"other_player_id"`
+	Preference    string `json:"preference"`
+}
+
+type HandicapResponse struct {
+	PlayerID      int64    `json:"player_id"`
+	HandicapIndex *float64 `json:"handicap_index"`
+}
+
+type HandicapScoreResponse struct {
+	EventID            int64    `json:"event_id"`
+	PlayedAt           string   `json:"played_at"`
+	AdjustedGrossScore int32    `json:"adjusted_gross_score"`
+	ScoreDifferential  float64  `json:"score_differential"`
+	Counted            bool     `json:"counted"`
+	HandicapIndex      *float64 `json:"handicap_index"`
+}
+
+type HandicapDetailResponse struct {
+	PlayerID         int64                   `json:"player_id"`
+	HandicapIndex    *float64                `json:"handicap_index"`
+	Calculated       bool                    `json:"calculated"`
+	LowHandicapIndex *float64                `json:"low_handicap_index"`
+	Scores           []HandicapScoreResponse `json:"scores"`
+}
+
+type ParAverageResponse struct {
+	Par     int     `json:"par"`
+	Holes   int     `json:"holes"`
+	Average float64 `json:"average"`
+}
+
+type BestRoundResponse struct {
+	EventID  int64  `json:"event_id"`
+	CourseID int64  `json:"course_id"`
+	PlayedAt string `json:"played_at"`
+	Strokes  int    `json:"strokes"`
+	ToPar    *int   `json:"to_par"`
+}
+
+type CourseStatsResponse struct {
+	CourseID        int64    `json:"course_id"`
+	Rounds          int      `json:"rounds"`
+	ScoringAverage  *float64 `json:"scoring_average"`
+	NineHoleAverage *float64 `json:"nine_hole_average"`
+}
+
+type LeaderboardEntryResponse struct {
+	Position   int    `json:"position"`
+	PlayerID   int64  `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Score      int    `json:"score"`
+	Gross      int    `json:"gross"`
+	Net        int    `json:"net"`
+	EventID    int64  `json:"event_id"`
+	CourseID   int64  `json:"course_id"`
+	PlayedAt   string `json:"played_at"`
+}
+
+type PrivacyResponse struct {
+	PlayerID         int64  `json:"player_id"`
+	ScoresVisibility string `json:"scores_visibility"`
+}
+
+type HomeCourseResponse struct {
+	PlayerID     int64  `json:"player_id"`
+	HomeCourseID *int32 `json:"home_course_id"`
+}
+
+type AvailabilityWindowResponse struct {
+	Day  string `json:"day"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type AvailabilityResponse struct {
+	PlayerID           int64                        `json:"player_id"`
+	Windows            []AvailabilityWindowResponse `json:"windows"`
+	PreferredCourseIDs []int64                      `json:"preferred_course_ids"`
+}
+
+type GameProposalResponse struct {
+	TeeTime       string  `json:"tee_time"`
+	LatestTeeTime string  `json:"latest_tee_time"`
+	Available     []int64 `json:"available"`
+	Unavailable   []int64 `json:"unavailable"`
+	PreferCourse  int     `json:"prefer_course"`
+}
+
+type GameProposalsResponse struct {
+	Proposals []GameProposalResponse `json:"proposals"`
+	Event     *EventResponse         `json:"event"`
+}
+
+type EventMatchResponse struct {
+	Event       EventResponse `json:"event"`
+	Score       float64       `json:"score"`
+	HandicapGap *float64      `json:"handicap_gap"`
+	DistanceKm  *float64      `json:"distance_km"`
+	Friends     int           `json:"friends"`
+	HoursAway   float64       `json:"hours_away"`
+}
+
+type PlayerStatsResponse struct {
+	PlayerID          int64                 `json:"player_id"`
+	Rounds            int                   `json:"rounds"`
+	ScoringAverage    *float64              `json:"scoring_average"`
+	NineHoleAverage   *float64              `json:"nine_hole_average"`
+	PuttsPerRound     *float64              `json:"putts_per_round"`
+	FairwayPercentage *float64              `json:"fairway_percentage"`
+	GIRPercentage     *float64              `json:"gir_percentage"`
+	ParAverages       []ParAverageResponse  `json:"par_averages"`
+	BestRounds        []BestRoundResponse   `json:"best_rounds"`
+	Courses           []CourseStatsResponse `json:"courses"`
+}
+
+type SettlementResponse struct {
+	ID          int64  `json:"id"`
+	PayerID     int64  `json:"payer_id"`
+	PayeeID     int64  `json:"payee_id"`
+	AmountCents int32  `json:"amount_cents"`
+	Currency    string `json:"currency"`
+	Note        string `json:"note"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type PlayerBalanceResponse struct {
+	PlayerID    int64  `json:"player_id"`
+	Currency    string `json:"currency"`
+	AmountCents int64  `json:"amount_cents"`
+	Direction   string `json:"direction"`
+}
+
+type PlayerLedgerResponse struct {
+	Balances    []PlayerBalanceResponse `json:"balances"`
+	Settlements []SettlementResponse    `json:"settlements"`
 }
 
 type LoginResponse struct {
@@ -68,6 +471,7 @@ type PostResponse struct {
 	ID         int64              `json:"id"`
 	PlayerID   int64              `json:"player_id"`
 	PlayerName string             `json:"player_name"`
+	EventID    *int64             `json:"event_id"`
 	Body       string             `json:"body"`
 	CreatedAt  string             `json:"created_at"`
 	Reactions  []ReactionResponse `json:"reactions"`
@@ -89,6 +493,36 @@ type ReplyResponse struct {
 	CreatedAt  string `json:"created_at"`
 }
 
+type CalendarTokenResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+type InviteLinkResponse struct {
+	ID        int64   `json:"id"`
+	EventID   int64   `json:"event_id"`
+	Token     string  `json:"token"`
+	URL       string  `json:"url"`
+	ExpiresAt *string `json:"expires_at"`
+	MaxUses   *int32  `json:"max_uses"`
+	Uses      int32   `json:"uses"`
+}
+
+type InvitePreviewResponse struct {
+	Event         EventResponse `json:"event"`
+	ExpiresAt     *string       `json:"expires_at"`
+	UsesRemaining *int32        `json:"uses_remaining"`
+}
+
+type NotificationResponse struct {
+	ID        int64  `json:"id"`
+	EventID   *int64 `json:"event_id"`
+	Kind      string `json:"kind"`
+	Message   string `json:"message"`
+	Read      bool   `json:"read"`
+	CreatedAt string `json:"created_at"`
+}
+
 type ErrorDetail struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
@@ -97,3 +531,8 @@ type ErrorDetail struct {
 type ErrorResponse struct {
 	Errors []ErrorDetail `json:"errors"`
 }
+
+type ScheduleConflictResponse struct {
+	Errors           []ErrorDetail  `json:"errors"`
+	ConflictingEvent *EventResponse `json:"conflicting_event"`
+}
@@ -0,0 +1,231 @@
+package pairing
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrTooManyPlayers is returned when the players don't fit in the available
+// groups.
+var ErrTooManyPlayers = errors.New("not enough tee times for the players")
+
+// Cost weights. Avoid requests are effectively hard constraints, play-with
+// requests outweigh handicap balance, and friendships only break ties.
+const (
+	avoidPenalty    = 1000.0
+	playWithPenalty = 100.0
+	friendBonus     = 1.0
+
+	maxPasses = 100
+)
+
+// Player is one accepted player to place. Guests play in their sponsor's
+// group, so a player with guests takes 1+Guests spots in it. Handicap is nil
+// when unknown; those players count as the field's average.
+type Player struct {
+	ID       int64
+	Handicap *float64
+	Guests   int
+}
+
+// Pair is an unordered pair of player IDs.
+type Pair [2]int64
+
+// Preferences are the soft constraints between players.
+type Preferences struct {
+	PlayWith []Pair
+	Avoid    []Pair
+	Friends  []Pair
+}
+
+type unit struct {
+	id       int64
+	spots    int
+	handicap float64 // total across the unit's spots
+}
+
+type pairWeights map[Pair]float64
+
+func key(a, b int64) Pair {
+	if a > b {
+		a, b = b, a
+	}
+	return Pair{a, b}
+}
+
+// Generate splits the players into as few groups of at most groupSize spots
+// as possible, up to maxGroups, keeping group sizes even. Groups are then
+// improved by swapping players to balance average handicaps and honor
+// preferences. Each returned group lists player IDs; the result is
+// deterministic for the same input.
+func Generate(players []Player, prefs Preferences, groupSize, maxGroups int) ([][]int64, error) {
+	if len(players) == 0 {
+		return [][]int64{}, nil
+	}
+
+	units, totalSpots := buildUnits(players)
+	weights := buildWeights(prefs)
+
+	for numGroups := (totalSpots + groupSize - 1) / groupSize; numGroups <= maxGroups; numGroups++ {
+		groups, ok := place(units, totalSpots, numGroups, groupSize)
+		if !ok {
+			continue
+		}
+		improve(groups, weights, float64(totalSpots))
+
+		out := make([][]int64, len(groups))
+		for i, g := range groups {
+			out[i] = make([]int64, len(g))
+			for j, u := range g {
+				out[i][j] = u.id
+			}
+			sort.Slice(out[i], func(a, b int) bool { return out[i][a] < out[i][b] })
+		}
+		return out, nil
+	}
+	return nil, ErrTooManyPlayers
+}
+
+func buildUnits(players []Player) ([]unit, int) {
+	var known, sum float64
+	for _, p := range players {
+		if p.Handicap != nil {
+			known++
+			sum += *p.Handicap
+		}
+	}
+	avg := 0.0
+	if known > 0 {
+		avg = sum / known
+	}
+
+	units := make([]unit, len(players))
+	total := 0
+	for i, p := range players {
+		h := avg
+		if p.Handicap != nil {
+			h = *p.Handicap
+		}
+		spots := 1 + p.Guests
+		// Guests' handicaps aren't known.
+		units[i] = unit{id: p.ID, spots: spots, handicap: h + float64(p.Guests)*avg}
+		total += spots
+	}
+
+	// Biggest units first so they aren't left without room, then by
+	// handicap so players are spread across groups by ability.
+	sort.Slice(units, func(i, j int) bool {
+		if units[i].spots != units[j].spots {
+			return units[i].spots > units[j].spots
+		}
+		if units[i].handicap != units[j].handicap {
+			return units[i].handicap < units[j].handicap
+		}
+		return units[i].id < units[j].id
+	})
+	return units, total
+}
+
+func buildWeights(prefs Preferences) pairWeights {
+	w := pairWeights{}
+	for _, p := range prefs.Friends {
+		w[key(p[0], p[1])] -= friendBonus
+	}
+	for _, p := range prefs.PlayWith {
+		// Rewarding the pair for sharing a group is the same as charging
+		// them for being split.
+		w[key(p[0], p[1])] -= playWithPenalty
+	}
+	for _, p := range prefs.Avoid {
+		w[key(p[0], p[1])] += avoidPenalty
+	}
+	return w
+}
+
+// place fills numGroups groups with capacities as even as possible, putting
+// each unit in the group with the most room left.
+func place(units []unit, totalSpots, numGroups, groupSize int) ([][]unit, bool) {
+	room := make([]int, numGroups)
+	for i := range room {
+		room[i] = totalSpots / numGroups
+		if i < totalSpots%numGroups {
+			room[i]++
+		}
+		if room[i] > groupSize {
+			return nil, false
+		}
+	}
+
+	groups := make([][]unit, numGroups)
+	for _, u := range units {
+		best := -1
+		for i := range groups {
+			if room[i] >= u.spots && (best == -1 || room[i] > room[best]) {
+				best = i
+			}
+		}
+		if best == -1 {
+			return nil, false
+		}
+		groups[best] = append(groups[best], u)
+		room[best] -= u.spots
+	}
+	return groups, true
+}
+
+func groupCost(g []unit, w pairWeights, fieldAvg float64) float64 {
+	spots, handicap := 0, 0.0
+	for _, u := range g {
+		spots += u.spots
+		handicap += u.handicap
+	}
+	cost := 0.0
+	if spots > 0 {
+		d := handicap/float64(spots) - fieldAvg
+		cost += d * d
+	}
+	for i := range g {
+		for j := i + 1; j < len(g); j++ {
+			cost += w[key(g[i].id, g[j].id)]
+		}
+	}
+	return cost
+}
+
+// improve swaps same-sized units between groups while that lowers the total
+// cost, so group sizes never change.
+func improve(groups [][]unit, w pairWeights, totalSpots float64) {
+	sum := 0.0
+	for _, g := range groups {
+		for _, u := range g {
+			sum += u.handicap
+		}
+	}
+	fieldAvg := sum / totalSpots
+
+	for pass := 0; pass < maxPasses; pass++ {
+		improved := false
+		for a := range groups {
+			for b := a + 1; b < len(groups); b++ {
+				for i := range groups[a] {
+					for j := range groups[b] {
+						if groups[a][i].spots != groups[b][j].spots {
+							continue
+						}
+						before := groupCost(groups[a], w, fieldAvg) + groupCost(groups[b], w, fieldAvg)
+						groups[a][i], groups[b][j] = groups[b][j], groups[a][i]
+						after := groupCost(groups[a], w, fieldAvg) + groupCost(groups[b], w, fieldAvg)
+						if after < before-1e-9 {
+							improved = true
+							continue
+						}
+						groups[a][i], groups[b][j] = groups[b][j], groups[a][i]
+					}
+				}
+			}
+		}
+		if !improved {
+			return
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package pairing
+
+import (
+	"math"
+	"testing"
+)
+
+func hcp(v float64) *float64 {
+	return &v
+}
+
+// spread is the difference between the highest and lowest group average
+// handicap, a quick measure of how balanced the groups are.
+func spread(groups [][]int64, handicaps map[int64]float64) float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, g := range groups {
+		if len(g) == 0 {
+			continue
+		}
+		sum := 0.0
+		for _, id := range g {
+			sum += handicaps[id]
+		}
+		avg := sum / float64(len(g))
+		lo, hi = math.Min(lo, avg), math.Max(hi, avg)
+	}
+	if math.IsInf(lo, 1) {
+		return 0
+	}
+	return hi - lo
+}
+
+func groupOf(groups [][]int64, id int64) int {
+	for i, g := range groups {
+		for _, p := range g {
+			if p == id {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func TestGenerate_BalancesHandicaps(t *testing.T) {
+	var players []Player
+	handicaps := map[int64]float64{}
+	for i := int64(1); i <= 16; i++ {
+		h := float64(i * 2)
+		players = append(players, Player{ID: i, Handicap: hcp(h)})
+		handicaps[i] = h
+	}
+
+	groups, err := Generate(players, Preferences{}, 4, 4)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(groups) != 4 {
+		t.Fatalf("expected 4 groups, got %d", len(groups))
+	}
+	for _, g := range groups {
+		if len(g) != 4 {
+			t.Errorf("expected foursomes, got %v", groups)
+		}
+	}
+	if s := spread(groups, handicaps); s > 1 {
+		t.Errorf("expected balanced groups, spread %.2f in %v", s, groups)
+	}
+}
+
+func TestGenerate_EvenSizes(t *testing.T) {
+	var players []Player
+	for i := int64(1); i <= 10; i++ {
+		players = append(players, Player{ID: i})
+	}
+
+	groups, err := Generate(players, Preferences{}, 4, 4)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	sizes := []int{}
+	for _, g := range groups {
+		sizes = append(sizes, len(g))
+	}
+	if len(sizes) != 3 || sizes[0] != 4 || sizes[1] != 3 || sizes[2] != 3 {
+		t.Errorf("expected sizes [4 3 3], got %v", sizes)
+	}
+}
+
+func TestGenerate_Preferences(t *testing.T) {
+	var players []Player
+	for i := int64(1); i <= 8; i++ {
+		players = append(players, Player{ID: i, Handicap: hcp(float64(i))})
+	}
+
+	groups, err := Generate(players, Preferences{
+		PlayWith: []Pair{{1, 2}},
+		Avoid:    []Pair{{3, 4}, {5, 6}},
+	}, 4, 2)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if groupOf(groups, 1) != groupOf(groups, 2) {
+		t.Errorf("expected 1 and 2 together, got %v", groups)
+	}
+	if groupOf(groups, 3) == groupOf(groups, 4) || groupOf(groups, 5) == groupOf(groups, 6) {
+		t.Errorf("expected avoided pairs apart, got %v", groups)
+	}
+}
+
+func TestGenerate_GuestsStayWithSponsor(t *testing.T) {
+	players := []Player{
+		{ID: 1, Guests: 2},
+		{ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}, {ID: 6},
+	}
+
+	groups, err := Generate(players, Preferences{}, 4, 2)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	sponsor := groupOf(groups, 1)
+	if len(groups[sponsor]) != 2 {
+		t.Errorf("expected the sponsor's group to have room for two guests, got %v", groups)
+	}
+}
+
+func TestGenerate_TooManyPlayers(t *testing.T) {
+	var players []Player
+	for i := int64(1); i <= 9; i++ {
+		players = append(players, Player{ID: i})
+	}
+	if _, err := Generate(players, Preferences{}, 4, 2); err != ErrTooManyPlayers {
+		t.Errorf("expected ErrTooManyPlayers, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "20060102"
+
+type Frequency string
+
+const (
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// Rule is the subset of an RFC 5545 RRULE that FindFore supports: weekly
+// (optionally every other week via INTERVAL=2) or monthly on the start day,
+// bounded by UNTIL or COUNT.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	Until    time.Time
+	Count    int
+}
+
+// Parse reads an RRULE value such as "FREQ=WEEKLY;INTERVAL=2;COUNT=10".
+// A leading "RRULE:" prefix is accepted.
+func Parse(s string) (Rule, error) {
+	rule := Rule{Interval: 1}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, fmt.Errorf("rrule is empty")
+	}
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("invalid rrule part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			switch Frequency(strings.ToUpper(value)) {
+			case Weekly:
+				rule.Freq = Weekly
+			case Monthly:
+				rule.Freq = Monthly
+			default:
+				return Rule{}, fmt.Errorf("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			rule.Interval = n
+		case "UNTIL":
+			until, err := time.Parse(dateLayout, value[:min(len(value), len(dateLayout))])
+			if err != nil {
+				return Rule{}, fmt.Errorf("invalid UNTIL %q", value)
+			}
+			rule.Until = until
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("invalid COUNT %q", value)
+			}
+			rule.Count = n
+		default:
+			return Rule{}, fmt.Errorf("unsupported rrule part %q", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return Rule{}, fmt.Errorf("rrule requires FREQ")
+	}
+	if !rule.Until.IsZero() && rule.Count > 0 {
+		return Rule{}, fmt.Errorf("rrule can't have both UNTIL and COUNT")
+	}
+	return rule, nil
+}
+
+// String formats the rule back into RRULE value syntax.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format(dateLayout))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Occurrences returns the dates produced by the rule starting at start that
+// fall within [from, to], skipping any date in exceptions. As in RFC 5545,
+// excluded dates still count towards COUNT.
+func (r Rule) Occurrences(start, from, to time.Time, exceptions []time.Time) []time.Time {
+	start = truncateDay(start)
+	from = truncateDay(from)
+	to = truncateDay(to)
+
+	skip := make(map[time.Time]bool, len(exceptions))
+	for _, ex := range exceptions {
+		skip[truncateDay(ex)] = true
+	}
+
+	var dates []time.Time
+	generated := 0
+	for i := 0; ; i++ {
+		d, ok := r.nth(start, i)
+		if !ok {
+			continue
+		}
+		if !r.Until.IsZero() && d.After(truncateDay(r.Until)) {
+			break
+		}
+		if d.After(to) {
+			break
+		}
+		generated++
+		if r.Count > 0 && generated > r.Count {
+			break
+		}
+		if d.Before(from) || skip[d] {
+			continue
+		}
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// nth returns the i-th candidate date of the rule. Monthly rules on days that
+// don't exist in a given month (e.g. the 31st) produce no occurrence for it.
+func (r Rule) nth(start time.Time, i int) (time.Time, bool) {
+	interval := max(r.Interval, 1)
+	switch r.Freq {
+	case Monthly:
+		d := time.Date(start.Year(), start.Month()+time.Month(i*interval), start.Day(), 0, 0, 0, 0, time.UTC)
+		if d.Day() != start.Day() {
+			return time.Time{}, false
+		}
+		return d, true
+	default:
+		return start.AddDate(0, 0, 7*i*interval), true
+	}
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
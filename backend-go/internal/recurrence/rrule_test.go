@@ -0,0 +1,93 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func formatDates(dates []time.Time) []string {
+	out := make([]string, len(dates))
+	for i, d := range dates {
+		out[i] = d.Format("2006-01-02")
+	}
+	return out
+}
+
+func assertDates(t *testing.T, got []time.Time, want ...string) {
+	t.Helper()
+	gotStr := formatDates(got)
+	if len(gotStr) != len(want) {
+		t.Fatalf("got %v, want %v", gotStr, want)
+	}
+	for i := range want {
+		if gotStr[i] != want[i] {
+			t.Fatalf("got %v, want %v", gotStr, want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	rule, err := Parse("RRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=20250930")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if rule.Freq != Weekly || rule.Interval != 2 || !rule.Until.Equal(date("2025-09-30")) {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+	if rule.String() != "FREQ=WEEKLY;INTERVAL=2;UNTIL=20250930" {
+		t.Errorf("unexpected String() %q", rule.String())
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"FREQ=DAILY",
+		"INTERVAL=2",
+		"FREQ=WEEKLY;COUNT=0",
+		"FREQ=WEEKLY;COUNT=3;UNTIL=20250101",
+		"FREQ=WEEKLY;BYDAY=TU",
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+}
+
+func TestOccurrences_WeeklyCount(t *testing.T) {
+	rule, _ := Parse("FREQ=WEEKLY;COUNT=3")
+	got := rule.Occurrences(date("2025-06-03"), date("2025-01-01"), date("2026-01-01"), nil)
+	assertDates(t, got, "2025-06-03", "2025-06-10", "2025-06-17")
+}
+
+func TestOccurrences_BiweeklyUntilWithException(t *testing.T) {
+	rule, _ := Parse("FREQ=WEEKLY;INTERVAL=2;UNTIL=20250715")
+	got := rule.Occurrences(date("2025-06-03"), date("2025-01-01"), date("2026-01-01"), []time.Time{date("2025-06-17")})
+	assertDates(t, got, "2025-06-03", "2025-07-01", "2025-07-15")
+}
+
+func TestOccurrences_ExceptionCountsTowardsCount(t *testing.T) {
+	rule, _ := Parse("FREQ=WEEKLY;COUNT=2")
+	got := rule.Occurrences(date("2025-06-03"), date("2025-01-01"), date("2026-01-01"), []time.Time{date("2025-06-03")})
+	assertDates(t, got, "2025-06-10")
+}
+
+func TestOccurrences_MonthlySkipsShortMonths(t *testing.T) {
+	rule, _ := Parse("FREQ=MONTHLY;COUNT=3")
+	got := rule.Occurrences(date("2025-01-31"), date("2025-01-01"), date("2026-01-01"), nil)
+	assertDates(t, got, "2025-01-31", "2025-03-31", "2025-05-31")
+}
+
+func TestOccurrences_Window(t *testing.T) {
+	rule, _ := Parse("FREQ=WEEKLY")
+	got := rule.Occurrences(date("2025-06-03"), date("2025-06-09"), date("2025-06-24"), nil)
+	assertDates(t, got, "2025-06-10", "2025-06-17", "2025-06-24")
+}
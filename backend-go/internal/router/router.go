@@ -19,16 +19,90 @@ func New(h *handler.Handler, jwtSecret string) *chi.Mux {
 
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Get("/courses", h.ListCourses)
+		r.Get("/courses/{id}", h.GetCourse)
+		r.Get("/courses/{id}/leaderboard", h.GetCourseLeaderboard)
+		r.Post("/courses/{id}/tee-sets", h.CreateTeeSet)
+		r.Post("/courses/{id}/tee-sets/import", h.ImportTeeSets)
+		r.Put("/courses/{id}/tee-sets/{tee_set_id}", h.UpdateTeeSet)
+		r.Delete("/courses/{id}/tee-sets/{tee_set_id}", h.DeleteTeeSet)
 
 		r.Get("/players", h.ListPlayers)
 		r.Post("/players", h.CreatePlayer)
 		r.Get("/players/{player_id}/events", h.ListEvents)
 		r.Get("/players/{player_id}/friends-events", h.ListFriendsEvents)
+		r.Get("/players/{player_id}/history", h.ListPlayerHistory)
+		r.Get("/players/{player_id}/calendar.ics", h.GetPlayerCalendar)
+		r.Post("/players/{player_id}/calendar-token", h.CreateCalendarToken)
+		r.Get("/players/{player_id}/notifications", h.ListNotifications)
+		r.Post("/players/{player_id}/notifications/{notification_id}/read", h.MarkNotificationRead)
+		r.Get("/players/{player_id}/handicap", h.GetHandicap)
+		r.Put("/players/{player_id}/handicap", h.UpdateHandicap)
+		r.Get("/players/{player_id}/stats", h.GetPlayerStats)
+		r.Get("/players/{player_id}/friends-leaderboard", h.GetFriendsLeaderboard)
+		r.Get("/players/{player_id}/privacy", h.GetPrivacy)
+		r.Put("/players/{player_id}/privacy", h.UpdatePrivacy)
+		r.Get("/players/{player_id}/home-course", h.GetHomeCourse)
+		r.Put("/players/{player_id}/home-course", h.UpdateHomeCourse)
+		r.Get("/players/{player_id}/recommended-events", h.ListRecommendedEvents)
+		r.Get("/players/{player_id}/availability", h.GetAvailability)
+		r.Put("/players/{player_id}/availability", h.UpdateAvailability)
+		r.Post("/players/{player_id}/game-proposals", h.ProposeGame)
+		r.Get("/players/{player_id}/balances", h.GetPlayerBalances)
+		r.Post("/players/{player_id}/settlements", h.CreateSettlement)
+		r.Get("/players/{player_id}/pairing-preferences", h.ListPairingPreferences)
+		r.Put("/players/{player_id}/pairing-preferences/{other_player_id}", h.SetPairingPreference)
+		r.Delete("/players/{player_id}/pairing-preferences/{other_player_id}", h.DeletePairingPreference)
 
 		r.Get("/events", h.ListEvents)
 		r.Get("/event/{id}", h.GetEvent)
+		r.Get("/event/{id}.ics", h.GetEventCalendar)
 		r.Post("/event", h.CreateEvent)
 		r.Delete("/event/{id}", h.DeleteEvent)
+		r.Post("/event/{id}/cancel", h.CancelEvent)
+		r.Post("/event/{id}/transfer", h.TransferEventHost)
+		r.Post("/event/{id}/cohosts", h.AddEventCohost)
+		r.Delete("/event/{id}/cohosts/{player_id}", h.RemoveEventCohost)
+		r.Post("/event/{id}/invite-links", h.CreateInviteLink)
+		r.Delete("/event/{id}/invite-links/{link_id}", h.RevokeInviteLink)
+		r.Post("/event/{id}/guests", h.AddEventGuest)
+		r.Delete("/event/{id}/guests/{guest_id}", h.RemoveEventGuest)
+		r.Get("/event/{id}/groups", h.ListEventGroups)
+		r.Post("/event/{id}/groups/generate", h.GenerateEventGroups)
+		r.Put("/event/{id}/groups", h.ReplaceEventGroups)
+		r.Get("/event/{id}/posts", h.ListEventPosts)
+		r.Get("/event/{id}/expenses", h.GetEventExpenses)
+		r.Post("/event/{id}/expenses", h.CreateEventExpense)
+		r.Delete("/event/{id}/expenses/{expense_id}", h.DeleteEventExpense)
+		r.Get("/event/{id}/scores", h.GetEventScores)
+		r.Put("/event/{id}/scores/{player_id}", h.SaveScorecard)
+		r.Get("/event/{id}/games", h.ListEventGames)
+		r.Post("/event/{id}/games", h.CreateEventGame)
+		r.Post("/event/{id}/games/settle", h.SettleEventGames)
+		r.Delete("/event/{id}/games/{game_id}", h.DeleteEventGame)
+
+		r.Post("/leagues", h.CreateLeague)
+		r.Get("/leagues/{id}", h.GetLeague)
+		r.Post("/leagues/{id}/members", h.AddLeagueMember)
+		r.Delete("/leagues/{id}/members/{player_id}", h.RemoveLeagueMember)
+		r.Post("/leagues/{id}/seasons", h.CreateLeagueSeason)
+		r.Get("/leagues/{id}/standings", h.GetLeagueStandings)
+
+		r.Post("/tournaments", h.CreateTournament)
+		r.Get("/tournaments/{id}", h.GetTournament)
+		r.Post("/tournaments/{id}/players", h.RegisterTournamentPlayer)
+		r.Delete("/tournaments/{id}/players/{player_id}", h.WithdrawTournamentPlayer)
+		r.Post("/tournaments/{id}/start", h.StartTournament)
+		r.Post("/tournaments/{id}/matches/{match_id}/schedule", h.ScheduleTournamentMatch)
+		r.Post("/tournaments/{id}/matches/{match_id}/result", h.ReportTournamentMatch)
+
+		r.Get("/invites/{token}", h.PreviewInvite)
+		r.Post("/invites/{token}/redeem", h.RedeemInvite)
+
+		r.Post("/series", h.CreateEventSeries)
+		r.Get("/series/{id}", h.GetEventSeries)
+		r.Post("/series/{id}/rsvp", h.RsvpEventSeries)
+		r.Patch("/series/{id}/occurrences/{date}", h.OverrideSeriesOccurrence)
+		r.Delete("/series/{id}/occurrences/{date}", h.CancelSeriesOccurrence)
 
 		r.Post("/friendship", h.CreateFriendship)
 		r.Delete("/friendship", h.DeleteFriendship)
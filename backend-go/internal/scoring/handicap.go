@@ -0,0 +1,233 @@
+package scoring
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// StandardSlope is the slope rating of a course of standard difficulty.
+	StandardSlope = 113
+	// MaxIndex is the highest handicap index the World Handicap System allows.
+	MaxIndex = 54.0
+	// differentialWindow is how many of the most recent scores the index is
+	// drawn from.
+	differentialWindow = 20
+	// softCap and hardCap limit how far the index can climb above the low
+	// handicap index of the past year.
+	softCap = 3.0
+	hardCap = 5.0
+)
+
+// RatedHole is a hole played with the tee set's par and stroke index.
+type RatedHole struct {
+	Strokes     int32
+	Par         int32
+	StrokeIndex int32
+}
+
+// Round is an 18 hole score from a rated tee set. ID is the caller's
+// reference to it, such as a scorecard ID.
+type Round struct {
+	ID           int64
+	PlayedAt     time.Time
+	CourseRating float64
+	SlopeRating  int32
+	Holes        []RatedHole
+}
+
+// Revision is the handicap after a round was posted.
+type Revision struct {
+	RoundID            int64
+	PlayedAt           time.Time
+	AdjustedGrossScore int
+	Differential       float64
+	Index              *float64
+	LowIndex           *float64
+}
+
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// CourseHandicap is how many strokes a player with the index receives from
+// the tee set.
+func CourseHandicap(index float64, slopeRating int32, courseRating float64, par int) int {
+	return int(math.Round(index*float64(slopeRating)/StandardSlope + courseRating - float64(par)))
+}
+
+// StrokesReceived is how many strokes a course handicap gets on a hole of an
+// 18 hole round. Plus handicaps give strokes back on the easiest holes.
+func StrokesReceived(courseHandicap int, strokeIndex int32) int {
+	if courseHandicap >= 0 {
+		strokes := courseHandicap / 18
+		if int(strokeIndex) <= courseHandicap%18 {
+			strokes++
+		}
+		return strokes
+	}
+	plus := -courseHandicap
+	strokes := plus / 18
+	if int(strokeIndex) > 18-plus%18 {
+		strokes++
+	}
+	return -strokes
+}
+
+// AdjustedGrossScore caps each hole at net double bogey: par plus two plus
+// any strokes received. A player without a handicap yet is capped at par
+// plus five.
+func AdjustedGrossScore(holes []RatedHole, courseHandicap *int) int {
+	total := 0
+	for _, h := range holes {
+		limit := int(h.Par) + 5
+		if courseHandicap != nil {
+			limit = int(h.Par) + 2 + StrokesReceived(*courseHandicap, h.StrokeIndex)
+		}
+		total += min(int(h.Strokes), limit)
+	}
+	return total
+}
+
+// Differential is how a score compares to the tee set's rating, adjusted to
+// a course of standard difficulty.
+func Differential(adjustedGrossScore int, courseRating float64, slopeRating int32) float64 {
+	return roundTenth(StandardSlope / float64(slopeRating) * (float64(adjustedGrossScore) - courseRating))
+}
+
+// counting is how many of the lowest differentials make up the index, and
+// the adjustment applied, for a player with n scores.
+func counting(n int) (int, float64) {
+	switch {
+	case n <= 3:
+		return 1, -2.0
+	case n == 4:
+		return 1, -1.0
+	case n == 5:
+		return 1, 0
+	case n == 6:
+		return 2, -1.0
+	case n <= 8:
+		return 2, 0
+	case n <= 11:
+		return 3, 0
+	case n <= 14:
+		return 4, 0
+	case n <= 16:
+		return 5, 0
+	case n <= 18:
+		return 6, 0
+	case n == 19:
+		return 7, 0
+	default:
+		return 8, 0
+	}
+}
+
+// recent is the most recent differentials the index is drawn from.
+func recent(differentials []float64) []float64 {
+	if len(differentials) > differentialWindow {
+		return differentials[len(differentials)-differentialWindow:]
+	}
+	return differentials
+}
+
+// Counted reports which of the differentials, oldest first, count toward the
+// index: the lowest of the most recent 20. Ties go to the older score.
+func Counted(differentials []float64) []bool {
+	counted := make([]bool, len(differentials))
+	if len(differentials) < 3 {
+		return counted
+	}
+	offset := len(differentials) - len(recent(differentials))
+	order := make([]int, len(differentials)-offset)
+	for i := range order {
+		order[i] = offset + i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return differentials[order[a]] < differentials[order[b]]
+	})
+	n, _ := counting(len(order))
+	for _, i := range order[:n] {
+		counted[i] = true
+	}
+	return counted
+}
+
+// Index averages the lowest of the most recent differentials, oldest first.
+// A player needs at least three scores for an index.
+func Index(differentials []float64) (float64, bool) {
+	window := recent(differentials)
+	if len(window) < 3 {
+		return 0, false
+	}
+	lowest := append([]float64(nil), window...)
+	sort.Float64s(lowest)
+	n, adjustment := counting(len(window))
+	sum := 0.0
+	for _, d := range lowest[:n] {
+		sum += d
+	}
+	return math.Min(roundTenth(sum/float64(n)+adjustment), MaxIndex), true
+}
+
+// Cap limits an index's rise above the low handicap index. Half of any rise
+// beyond the soft cap is taken off, and it can never rise past the hard cap.
+func Cap(index, lowIndex float64) float64 {
+	if index-lowIndex > softCap {
+		index = lowIndex + softCap + (index-lowIndex-softCap)/2
+	}
+	return roundTenth(math.Min(index, lowIndex+hardCap))
+}
+
+// Revisions posts the rounds in order, oldest first, and returns the
+// handicap after each. Each round is adjusted using the index the player had
+// going into it. The low handicap index is the lowest index of the year
+// before the round, once the player has 20 scores.
+func Revisions(rounds []Round) []Revision {
+	revisions := make([]Revision, 0, len(rounds))
+	differentials := make([]float64, 0, len(rounds))
+	var current *float64
+	for _, round := range rounds {
+		par := 0
+		for _, h := range round.Holes {
+			par += int(h.Par)
+		}
+		var courseHandicap *int
+		if current != nil {
+			ch := CourseHandicap(*current, round.SlopeRating, round.CourseRating, par)
+			courseHandicap = &ch
+		}
+
+		rev := Revision{
+			RoundID:            round.ID,
+			PlayedAt:           round.PlayedAt,
+			AdjustedGrossScore: AdjustedGrossScore(round.Holes, courseHandicap),
+		}
+		rev.Differential = Differential(rev.AdjustedGrossScore, round.CourseRating, round.SlopeRating)
+		differentials = append(differentials, rev.Differential)
+
+		if len(differentials) >= differentialWindow {
+			yearAgo := round.PlayedAt.AddDate(-1, 0, 0)
+			for _, prev := range revisions {
+				if prev.Index == nil || prev.PlayedAt.Before(yearAgo) {
+					continue
+				}
+				if rev.LowIndex == nil || *prev.Index < *rev.LowIndex {
+					low := *prev.Index
+					rev.LowIndex = &low
+				}
+			}
+		}
+		if index, ok := Index(differentials); ok {
+			if rev.LowIndex != nil {
+				index = Cap(index, *rev.LowIndex)
+			}
+			rev.Index = &index
+			current = &index
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions
+}
@@ -0,0 +1,162 @@
+package scoring
+
+import (
+	"testing"
+	"time"
+)
+
+// parSeventyTwo is an 18 hole layout with stroke indexes in hole order.
+func parSeventyTwo(strokes int32) []RatedHole {
+	pars := []int32{4, 4, 3, 5, 4, 4, 3, 4, 5, 4, 3, 4, 5, 4, 4, 3, 5, 4}
+	holes := make([]RatedHole, len(pars))
+	for i, par := range pars {
+		holes[i] = RatedHole{Strokes: par + strokes, Par: par, StrokeIndex: int32(i + 1)}
+	}
+	return holes
+}
+
+func TestCourseHandicap(t *testing.T) {
+	if got := CourseHandicap(10.4, 130, 71.5, 72); got != 11 {
+		t.Errorf("expected 11, got %d", got)
+	}
+	if got := CourseHandicap(-2.0, 113, 72.0, 72); got != -2 {
+		t.Errorf("expected -2, got %d", got)
+	}
+}
+
+func TestStrokesReceived(t *testing.T) {
+	tests := []struct {
+		courseHandicap int
+		strokeIndex    int32
+		want           int
+	}{
+		{10, 10, 1},
+		{10, 11, 0},
+		{20, 2, 2},
+		{20, 3, 1},
+		{-2, 17, -1},
+		{-2, 16, 0},
+	}
+	for _, tt := range tests {
+		if got := StrokesReceived(tt.courseHandicap, tt.strokeIndex); got != tt.want {
+			t.Errorf("StrokesReceived(%d, %d) = %d, want %d", tt.courseHandicap, tt.strokeIndex, got, tt.want)
+		}
+	}
+}
+
+func TestAdjustedGrossScore_NetDoubleBogey(t *testing.T) {
+	holes := parSeventyTwo(0)
+	holes[0].Strokes = 10 // par 4, stroke index 1
+	holes[17].Strokes = 9 // par 4, stroke index 18
+
+	ch := 9
+	// Hole 1 is capped at 4+2+1, hole 18 at 4+2
+	if got := AdjustedGrossScore(holes, &ch); got != 72-8+7+6 {
+		t.Errorf("expected 77, got %d", got)
+	}
+	// Without a handicap both are capped at par plus five
+	if got := AdjustedGrossScore(holes, nil); got != 72-8+9+9 {
+		t.Errorf("expected 82, got %d", got)
+	}
+}
+
+func TestDifferential(t *testing.T) {
+	if got := Differential(85, 71.5, 130); got != 11.7 {
+		t.Errorf("expected 11.7, got %v", got)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	if _, ok := Index([]float64{10, 12}); ok {
+		t.Error("expected no index from two scores")
+	}
+	if got, _ := Index([]float64{10, 12, 14}); got != 8.0 {
+		t.Errorf("expected the lowest of three less 2.0, got %v", got)
+	}
+
+	// 25 scores: only the last 20 count, and the lowest 8 of those
+	diffs := []float64{1, 1, 1, 1, 1}
+	for i := 0; i < 20; i++ {
+		diffs = append(diffs, float64(10+i))
+	}
+	if got, _ := Index(diffs); got != 13.5 {
+		t.Errorf("expected 13.5, got %v", got)
+	}
+	counted := Counted(diffs)
+	for i, c := range counted {
+		if want := i >= 5 && i < 13; c != want {
+			t.Errorf("score %d: expected counted %v, got %v", i, want, c)
+		}
+	}
+}
+
+func TestCap(t *testing.T) {
+	tests := []struct {
+		index, low, want float64
+	}{
+		{12.0, 10.0, 12.0},
+		{14.0, 10.0, 13.5},
+		{20.0, 10.0, 15.0},
+	}
+	for _, tt := range tests {
+		if got := Cap(tt.index, tt.low); got != tt.want {
+			t.Errorf("Cap(%v, %v) = %v, want %v", tt.index, tt.low, got, tt.want)
+		}
+	}
+}
+
+func TestRevisions(t *testing.T) {
+	start := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+	var rounds []Round
+	for i := 0; i < 3; i++ {
+		rounds = append(rounds, Round{
+			ID:           int64(i + 1),
+			PlayedAt:     start.AddDate(0, 0, 7*i),
+			CourseRating: 72.0,
+			SlopeRating:  113,
+			Holes:        parSeventyTwo(1),
+		})
+	}
+
+	revisions := Revisions(rounds)
+	if len(revisions) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(revisions))
+	}
+	if revisions[1].Index != nil {
+		t.Errorf("expected no index after two rounds, got %v", *revisions[1].Index)
+	}
+	last := revisions[2]
+	if last.AdjustedGrossScore != 90 || last.Differential != 18.0 {
+		t.Errorf("expected 90 for an 18.0 differential, got %d and %v", last.AdjustedGrossScore, last.Differential)
+	}
+	if last.Index == nil || *last.Index != 16.0 {
+		t.Errorf("expected a 16.0 index, got %v", last.Index)
+	}
+}
+
+func TestRevisions_CapsRiseAboveLowIndex(t *testing.T) {
+	start := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+	var rounds []Round
+	for i := 0; i < 40; i++ {
+		over := int32(0)
+		if i >= 20 {
+			over = 2 // blow-up holes are capped, but bogeys add up
+		}
+		rounds = append(rounds, Round{
+			ID:           int64(i + 1),
+			PlayedAt:     start.AddDate(0, 0, 7*i),
+			CourseRating: 72.0,
+			SlopeRating:  113,
+			Holes:        parSeventyTwo(over),
+		})
+	}
+
+	// The low index is the -2.0 from the first three rounds
+	last := Revisions(rounds)[39]
+	if last.LowIndex == nil || *last.LowIndex != -2.0 {
+		t.Fatalf("expected a low index of -2.0, got %v", last.LowIndex)
+	}
+	if last.Index == nil || *last.Index != 3.0 {
+		t.Errorf("expected the hard cap to hold the index at 3.0, got %v", last.Index)
+	}
+}
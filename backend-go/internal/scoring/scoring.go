@@ -0,0 +1,61 @@
+package scoring
+
+// Hole is one hole of a scorecard. Everything but strokes is optional since
+// not every player tracks stats.
+type Hole struct {
+	Number            int32
+	Strokes           int32
+	Putts             *int32
+	FairwayHit        *bool
+	GreenInRegulation *bool
+	Par               *int32
+}
+
+// Totals sums up a scorecard. Par and ToPar only count holes with a known
+// par, so a partly entered card still shows where the player stands.
+type Totals struct {
+	HolesPlayed        int
+	Strokes            int
+	Putts              int
+	Par                int
+	ToPar              *int
+	FairwaysHit        int
+	Fairways           int
+	GreensInRegulation int
+	Greens             int
+}
+
+// Total adds up the holes.
+func Total(holes []Hole) Totals {
+	var t Totals
+	parStrokes, withPar := 0, 0
+	for _, h := range holes {
+		t.HolesPlayed++
+		t.Strokes += int(h.Strokes)
+		if h.Putts != nil {
+			t.Putts += int(*h.Putts)
+		}
+		if h.Par != nil {
+			t.Par += int(*h.Par)
+			parStrokes += int(h.Strokes)
+			withPar++
+		}
+		if h.FairwayHit != nil {
+			t.Fairways++
+			if *h.FairwayHit {
+				t.FairwaysHit++
+			}
+		}
+		if h.GreenInRegulation != nil {
+			t.Greens++
+			if *h.GreenInRegulation {
+				t.GreensInRegulation++
+			}
+		}
+	}
+	if withPar > 0 {
+		toPar := parStrokes - t.Par
+		t.ToPar = &toPar
+	}
+	return t
+}
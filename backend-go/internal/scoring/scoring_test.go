@@ -0,0 +1,38 @@
+package scoring
+
+import "testing"
+
+func i32(v int32) *int32 { return &v }
+func b(v bool) *bool     { return &v }
+
+func TestTotal(t *testing.T) {
+	totals := Total([]Hole{
+		{Number: 1, Strokes: 5, Putts: i32(2), FairwayHit: b(true), GreenInRegulation: b(false), Par: i32(4)},
+		{Number: 2, Strokes: 3, Putts: i32(1), GreenInRegulation: b(true), Par: i32(3)},
+		{Number: 3, Strokes: 6, FairwayHit: b(false), Par: i32(5)},
+	})
+
+	if totals.HolesPlayed != 3 || totals.Strokes != 14 || totals.Putts != 3 || totals.Par != 12 {
+		t.Errorf("unexpected totals %+v", totals)
+	}
+	if totals.ToPar == nil || *totals.ToPar != 2 {
+		t.Errorf("expected +2, got %v", totals.ToPar)
+	}
+	if totals.FairwaysHit != 1 || totals.Fairways != 2 || totals.GreensInRegulation != 1 || totals.Greens != 2 {
+		t.Errorf("unexpected stats %+v", totals)
+	}
+}
+
+func TestTotal_ToParOnlyCountsHolesWithPar(t *testing.T) {
+	totals := Total([]Hole{
+		{Number: 1, Strokes: 4, Par: i32(4)},
+		{Number: 2, Strokes: 7},
+	})
+	if totals.ToPar == nil || *totals.ToPar != 0 {
+		t.Errorf("expected even par, got %v", totals.ToPar)
+	}
+
+	if totals := Total([]Hole{{Number: 1, Strokes: 4}}); totals.ToPar != nil {
+		t.Errorf("expected no to-par without pars, got %d", *totals.ToPar)
+	}
+}
@@ -0,0 +1,148 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// bestRounds is how many of a player's lowest rounds are listed.
+const bestRounds = 5
+
+// Total is strokes over a number of holes.
+type Total struct {
+	Strokes int
+	Holes   int
+}
+
+// Round is the totals from one complete scorecard. Par is nil when any hole
+// was entered without one.
+type Round struct {
+	EventID      int64
+	CourseID     int64
+	PlayedAt     time.Time
+	Holes        int
+	Strokes      int
+	Par          *int
+	Putts        int
+	PuttHoles    int
+	FairwaysHit  int
+	FairwayHoles int
+	GreensHit    int
+	GreenHoles   int
+	ByPar        map[int]Total
+}
+
+// Averages are scoring averages, kept apart for nine and 18 hole rounds so
+// they stay comparable. Either is nil without a round of that length.
+type Averages struct {
+	Rounds          int
+	ScoringAverage  *float64
+	NineHoleAverage *float64
+}
+
+// ParAverage is the average score on holes of one par.
+type ParAverage struct {
+	Par     int
+	Holes   int
+	Average float64
+}
+
+// CourseAverages are the averages for rounds at one course.
+type CourseAverages struct {
+	CourseID int64
+	Averages
+}
+
+// Summary is a player's statistics over a set of rounds. Percentages and
+// putts are nil until a round records them. Putts are per 18 holes.
+type Summary struct {
+	Averages
+	PuttsPerRound     *float64
+	FairwayPercentage *float64
+	GIRPercentage     *float64
+	ParAverages       []ParAverage
+	BestRounds        []Round
+	Courses           []CourseAverages
+}
+
+func ratio(n, d int, scale float64) *float64 {
+	if d == 0 {
+		return nil
+	}
+	v := float64(n) / float64(d) * scale
+	return &v
+}
+
+func averages(rounds []Round) Averages {
+	var eighteen, nine Total
+	for _, r := range rounds {
+		switch r.Holes {
+		case 18:
+			eighteen.Strokes += r.Strokes
+			eighteen.Holes++
+		case 9:
+			nine.Strokes += r.Strokes
+			nine.Holes++
+		}
+	}
+	return Averages{
+		Rounds:          len(rounds),
+		ScoringAverage:  ratio(eighteen.Strokes, eighteen.Holes, 1),
+		NineHoleAverage: ratio(nine.Strokes, nine.Holes, 1),
+	}
+}
+
+// Summarize works out the statistics for the rounds. Best rounds are the
+// lowest 18 hole scores, earlier rounds first on ties, and courses are in the
+// order they were first played.
+func Summarize(rounds []Round) Summary {
+	s := Summary{Averages: averages(rounds)}
+
+	var putts, puttHoles, fairways, fairwayHoles, greens, greenHoles int
+	byPar := map[int]Total{}
+	byCourse := map[int64][]Round{}
+	var courses []int64
+	for _, r := range rounds {
+		putts += r.Putts
+		puttHoles += r.PuttHoles
+		fairways += r.FairwaysHit
+		fairwayHoles += r.FairwayHoles
+		greens += r.GreensHit
+		greenHoles += r.GreenHoles
+		for par, t := range r.ByPar {
+			total := byPar[par]
+			total.Strokes += t.Strokes
+			total.Holes += t.Holes
+			byPar[par] = total
+		}
+		if _, ok := byCourse[r.CourseID]; !ok {
+			courses = append(courses, r.CourseID)
+		}
+		byCourse[r.CourseID] = append(byCourse[r.CourseID], r)
+	}
+	s.PuttsPerRound = ratio(putts, puttHoles, 18)
+	s.FairwayPercentage = ratio(fairways, fairwayHoles, 100)
+	s.GIRPercentage = ratio(greens, greenHoles, 100)
+
+	for par, t := range byPar {
+		if t.Holes > 0 {
+			s.ParAverages = append(s.ParAverages, ParAverage{Par: par, Holes: t.Holes, Average: float64(t.Strokes) / float64(t.Holes)})
+		}
+	}
+	sort.Slice(s.ParAverages, func(i, j int) bool { return s.ParAverages[i].Par < s.ParAverages[j].Par })
+
+	for _, r := range rounds {
+		if r.Holes == 18 {
+			s.BestRounds = append(s.BestRounds, r)
+		}
+	}
+	sort.SliceStable(s.BestRounds, func(i, j int) bool { return s.BestRounds[i].Strokes < s.BestRounds[j].Strokes })
+	if len(s.BestRounds) > bestRounds {
+		s.BestRounds = s.BestRounds[:bestRounds]
+	}
+
+	for _, id := range courses {
+		s.Courses = append(s.Courses, CourseAverages{CourseID: id, Averages: averages(byCourse[id])})
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+package stats
+
+import "testing"
+
+func round(courseID int64, holes, strokes int) Round {
+	return Round{CourseID: courseID, Holes: holes, Strokes: strokes}
+}
+
+func TestSummarize_Averages(t *testing.T) {
+	s := Summarize([]Round{
+		round(1, 18, 90),
+		round(2, 18, 84),
+		round(1, 9, 44),
+	})
+
+	if s.Rounds != 3 {
+		t.Errorf("expected 3 rounds, got %d", s.Rounds)
+	}
+	if s.ScoringAverage == nil || *s.ScoringAverage != 87 {
+		t.Errorf("expected an 18 hole average of 87, got %v", s.ScoringAverage)
+	}
+	if s.NineHoleAverage == nil || *s.NineHoleAverage != 44 {
+		t.Errorf("expected a nine hole average of 44, got %v", s.NineHoleAverage)
+	}
+
+	if len(s.Courses) != 2 || s.Courses[0].CourseID != 1 || s.Courses[0].Rounds != 2 {
+		t.Fatalf("expected two rounds at course 1 then course 2, got %+v", s.Courses)
+	}
+	if avg := s.Courses[1].ScoringAverage; avg == nil || *avg != 84 {
+		t.Errorf("expected 84 at course 2, got %v", avg)
+	}
+	if s.Courses[1].NineHoleAverage != nil {
+		t.Errorf("expected no nine hole average at course 2, got %v", *s.Courses[1].NineHoleAverage)
+	}
+}
+
+func TestSummarize_Percentages(t *testing.T) {
+	a := round(1, 9, 40)
+	a.Putts, a.PuttHoles = 16, 9
+	a.FairwaysHit, a.FairwayHoles = 3, 7
+	a.GreensHit, a.GreenHoles = 4, 9
+	a.ByPar = map[int]Total{3: {Strokes: 7, Holes: 2}, 4: {Strokes: 25, Holes: 6}, 5: {Strokes: 8, Holes: 1}}
+	b := round(1, 9, 42)
+	b.FairwaysHit, b.FairwayHoles = 4, 7
+	b.ByPar = map[int]Total{3: {Strokes: 5, Holes: 1}}
+
+	s := Summarize([]Round{a, b})
+
+	if s.PuttsPerRound == nil || *s.PuttsPerRound != 32 {
+		t.Errorf("expected 32 putts per 18 holes, got %v", s.PuttsPerRound)
+	}
+	if s.FairwayPercentage == nil || *s.FairwayPercentage != 50 {
+		t.Errorf("expected half the fairways hit, got %v", s.FairwayPercentage)
+	}
+	if s.GIRPercentage == nil || *s.GIRPercentage < 44.4 || *s.GIRPercentage > 44.5 {
+		t.Errorf("expected 4 of 9 greens hit, got %v", s.GIRPercentage)
+	}
+	want := []ParAverage{{Par: 3, Holes: 3, Average: 4}, {Par: 4, Holes: 6, Average: 25.0 / 6}, {Par: 5, Holes: 1, Average: 8}}
+	if len(s.ParAverages) != len(want) {
+		t.Fatalf("expected %+v, got %+v", want, s.ParAverages)
+	}
+	for i, w := range want {
+		if s.ParAverages[i] != w {
+			t.Errorf("expected %+v, got %+v", w, s.ParAverages[i])
+		}
+	}
+}
+
+func TestSummarize_NoStats(t *testing.T) {
+	s := Summarize([]Round{round(1, 18, 80)})
+
+	if s.PuttsPerRound != nil || s.FairwayPercentage != nil || s.GIRPercentage != nil {
+		t.Errorf("expected no putting or accuracy stats without any recorded, got %+v", s)
+	}
+	if s := Summarize(nil); s.Rounds != 0 || s.ScoringAverage != nil || len(s.BestRounds) != 0 {
+		t.Errorf("expected an empty summary, got %+v", s)
+	}
+}
+
+func TestSummarize_BestRounds(t *testing.T) {
+	var rounds []Round
+	for i, strokes := range []int{88, 79, 92, 79, 85, 81, 90} {
+		r := round(1, 18, strokes)
+		r.EventID = int64(i + 1)
+		rounds = append(rounds, r)
+	}
+	rounds = append(rounds, round(1, 9, 36))
+
+	s := Summarize(rounds)
+
+	want := []int64{2, 4, 6, 5, 1}
+	if len(s.BestRounds) != len(want) {
+		t.Fatalf("expected %d best rounds, got %+v", len(want), s.BestRounds)
+	}
+	for i, id := range want {
+		if s.BestRounds[i].EventID != id {
+			t.Errorf("best round %d: expected event %d, got %+v", i, id, s.BestRounds[i])
+		}
+	}
+}
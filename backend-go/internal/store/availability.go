@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type SetPlayerAvailabilityParams struct {
+	PlayerID  int64
+	Windows   []CreatePlayerAvailabilityParams
+	CourseIDs []int64
+}
+
+// SetPlayerAvailability replaces the player's weekly availability windows and
+// preferred courses. Returns sql.ErrNoRows if the player doesn't exist.
+func SetPlayerAvailability(ctx context.Context, db *sql.DB, q *Queries, params SetPlayerAvailabilityParams) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if _, err := qtx.LockPlayerForUpdate(ctx, params.PlayerID); err != nil {
+		return err
+	}
+	if err := qtx.DeletePlayerAvailability(ctx, params.PlayerID); err != nil {
+		return fmt.Errorf("failed to clear availability: %w", err)
+	}
+	for _, w := range params.Windows {
+		w.PlayerID = params.PlayerID
+		if err := qtx.CreatePlayerAvailability(ctx, w); err != nil {
+			return fmt.Errorf("failed to save availability: %w", err)
+		}
+	}
+	if err := qtx.DeletePlayerPreferredCourses(ctx, params.PlayerID); err != nil {
+		return fmt.Errorf("failed to clear preferred courses: %w", err)
+	}
+	for _, courseID := range params.CourseIDs {
+		if err := qtx.CreatePlayerPreferredCourse(ctx, CreatePlayerPreferredCourseParams{
+			PlayerID: params.PlayerID,
+			CourseID: courseID,
+		}); err != nil {
+			return fmt.Errorf("failed to save preferred course: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -10,21 +10,87 @@ import (
 	"database/sql"
 )
 
+const getCourseByID = `-- name: GetCourseByID :one
+SELECT id, name, street, city, state, zip_code, phone, cost, fee_cents, fee_currency, latitude, longitude, time_zone
+FROM courses
+WHERE id = $1
+`
+
+type GetCourseByIDRow struct {
+	ID          int64
+	Name        sql.NullString
+	Street      sql.NullString
+	City        sql.NullString
+	State       sql.NullString
+	ZipCode     sql.NullString
+	Phone       sql.NullString
+	Cost        sql.NullString
+	FeeCents    sql.NullInt32
+	FeeCurrency string
+	Latitude    sql.NullFloat64
+	Longitude   sql.NullFloat64
+	TimeZone    string
+}
+
+func (q *Queries) GetCourseByID(ctx context.Context, id int64) (GetCourseByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getCourseByID, id)
+	var i GetCourseByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Street,
+		&i.City,
+		&i.State,
+		&i.ZipCode,
+		&i.Phone,
+		&i.Cost,
+		&i.FeeCents,
+		&i.FeeCurrency,
+		&i.Latitude,
+		&i.Longitude,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
+const getCourseFee = `-- name: GetCourseFee :one
+SELECT fee_cents, fee_currency
+FROM courses
+WHERE id = $1
+`
+
+type GetCourseFeeRow struct {
+	FeeCents    sql.NullInt32
+	FeeCurrency string
+}
+
+func (q *Queries) GetCourseFee(ctx context.Context, id int64) (GetCourseFeeRow, error) {
+	row := q.db.QueryRowContext(ctx, getCourseFee, id)
+	var i GetCourseFeeRow
+	err := row.Scan(&i.FeeCents, &i.FeeCurrency)
+	return i, err
+}
+
 const listCourses = `-- name: ListCourses :many
-SELECT id, name, street, city, state, zip_code, phone, cost
+SELECT id, name, street, city, state, zip_code, phone, cost, fee_cents, fee_currency, latitude, longitude, time_zone
 FROM courses
 ORDER BY id
 `
 
 type ListCoursesRow struct {
-	ID      int64
-	Name    sql.NullString
-	Street  sql.NullString
-	City    sql.NullString
-	State   sql.NullString
-	ZipCode sql.NullString
-	Phone   sql.NullString
-	Cost    sql.NullString
+	ID          int64
+	Name        sql.NullString
+	Street      sql.NullString
+	City        sql.NullString
+	State       sql.NullString
+	ZipCode     sql.NullString
+	Phone       sql.NullString
+	Cost        sql.NullString
+	FeeCents    sql.NullInt32
+	FeeCurrency string
+	Latitude    sql.NullFloat64
+	Longitude   sql.NullFloat64
+	TimeZone    string
 }
 
 func (q *Queries) ListCourses(ctx context.Context) ([]ListCoursesRow, error) {
@@ -45,6 +111,11 @@ func (q *Queries) ListCourses(ctx context.Context) ([]ListCoursesRow, error) {
 			&i.ZipCode,
 			&i.Phone,
 			&i.Cost,
+			&i.FeeCents,
+			&i.FeeCurrency,
+			&i.Latitude,
+			&i.Longitude,
+			&i.TimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrPlayerNotAccepted = errors.New("hosts and co-hosts must have accepted the event")
+
+// lockManageableEvent locks the event and checks it can still change hands.
+func lockManageableEvent(ctx context.Context, qtx *Queries, eventID int64) (LockEventForUpdateRow, error) {
+	event, err := qtx.LockEventForUpdate(ctx, eventID)
+	if err != nil {
+		return LockEventForUpdateRow{}, err
+	}
+	if event.CancelledAt.Valid {
+		return LockEventForUpdateRow{}, ErrEventCancelled
+	}
+	if event.Status == 2 { // completed
+		return LockEventForUpdateRow{}, ErrEventCompleted
+	}
+	return event, nil
+}
+
+func requireAccepted(ctx context.Context, qtx *Queries, eventID, playerID int64) error {
+	pe, err := qtx.GetPlayerEvent(ctx, GetPlayerEventParams{
+		PlayerID: sql.NullInt64{Int64: playerID, Valid: true},
+		EventID:  sql.NullInt64{Int64: eventID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && pe.InviteStatus.Int32 != 1) {
+		return ErrPlayerNotAccepted
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check player_event: %w", err)
+	}
+	return nil
+}
+
+type TransferEventHostParams struct {
+	EventID   int64
+	NewHostID int64
+}
+
+// TransferEventHost hands the event to another accepted player. The new host
+// stops being a co-host; the previous host keeps their spot as a player.
+func TransferEventHost(ctx context.Context, db *sql.DB, q *Queries, params TransferEventHostParams) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	event, err := lockManageableEvent(ctx, qtx, params.EventID)
+	if err != nil {
+		return err
+	}
+	if int64(event.HostID.Int32) == params.NewHostID {
+		return nil
+	}
+
+	if err := requireAccepted(ctx, qtx, params.EventID, params.NewHostID); err != nil {
+		return err
+	}
+
+	if err := qtx.UpdateEventHost(ctx, UpdateEventHostParams{
+		ID:     params.EventID,
+		HostID: sql.NullInt32{Int32: int32(params.NewHostID), Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to update host: %w", err)
+	}
+
+	if _, err := qtx.DeleteEventCohost(ctx, DeleteEventCohostParams{
+		EventID:  params.EventID,
+		PlayerID: params.NewHostID,
+	}); err != nil {
+		return fmt.Errorf("failed to remove co-host: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// AddEventCohost gives an accepted player the host's edit and cancel
+// permissions. Adding an existing co-host or the host is a no-op.
+func AddEventCohost(ctx context.Context, db *sql.DB, q *Queries, eventID, playerID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	// The lock keeps a concurrent decline from leaving a co-host who isn't
+	// playing.
+	event, err := lockManageableEvent(ctx, qtx, eventID)
+	if err != nil {
+		return err
+	}
+	if int64(event.HostID.Int32) == playerID {
+		return nil
+	}
+
+	if err := requireAccepted(ctx, qtx, eventID, playerID); err != nil {
+		return err
+	}
+
+	if err := qtx.CreateEventCohost(ctx, CreateEventCohostParams{
+		EventID:  eventID,
+		PlayerID: playerID,
+	}); err != nil {
+		return fmt.Errorf("failed to create co-host: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultCurrency is used for fees when neither the event nor its course
+// names a currency.
+const DefaultCurrency = "USD"
+
+var ErrCurrencyMismatch = errors.New("expense currency must match the event's fee currency")
+
+// RecordEventExpense adds a payment to the event's ledger. The payer must
+// have accepted, since only players in the round share its costs, and every
+// expense is in the event's currency so balances never mix currencies.
+func RecordEventExpense(ctx context.Context, q *Queries, params CreateEventExpenseParams) (EventExpense, error) {
+	fee, err := q.GetEventFee(ctx, params.EventID)
+	if err != nil {
+		return EventExpense{}, err
+	}
+	if params.Currency == "" {
+		params.Currency = fee.FeeCurrency
+	}
+	if params.Currency != fee.FeeCurrency {
+		return EventExpense{}, ErrCurrencyMismatch
+	}
+
+	if err := requireAccepted(ctx, q, params.EventID, params.PayerID); err != nil {
+		return EventExpense{}, err
+	}
+
+	expense, err := q.CreateEventExpense(ctx, params)
+	if err != nil {
+		return EventExpense{}, fmt.Errorf("failed to create expense: %w", err)
+	}
+	return expense, nil
+}
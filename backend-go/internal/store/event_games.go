@@ -0,0 +1,297 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ericrabun/findfore-go/internal/games"
+	"github.com/ericrabun/findfore-go/internal/ledger"
+	"github.com/ericrabun/findfore-go/internal/scoring"
+)
+
+var (
+	ErrGamePlayerNotAccepted = errors.New("game players must have accepted the event")
+	ErrTooFewGamePlayers     = errors.New("games need at least two players")
+	ErrGameSettled           = errors.New("game has already been settled")
+	ErrRoundNotCompleted     = errors.New("games are settled once the round is completed")
+	ErrCardsIncomplete       = errors.New("every game player needs a complete scorecard")
+)
+
+// PlayedGame is a game with its results from the scorecards so far. Settled
+// games owe what was recorded when they were settled.
+type PlayedGame struct {
+	EventGame
+	PlayerIDs []int64
+	Results   []games.Result
+	Debts     []ledger.Debt
+}
+
+// CreateEventGame attaches a game to the event between the given players,
+// who must all have accepted. With no players given, everyone who has
+// accepted plays. Games can be added until the event is
+// cancelled, so a round played on paper can still be settled afterwards.
+func CreateEventGame(ctx context.Context, db *sql.DB, q *Queries, params CreateEventGameParams, playerIDs []int64) (EventGame, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return EventGame{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	event, err := qtx.LockEventForUpdate(ctx, params.EventID)
+	if err != nil {
+		return EventGame{}, err
+	}
+	if event.CancelledAt.Valid {
+		return EventGame{}, ErrEventCancelled
+	}
+
+	if len(playerIDs) == 0 {
+		candidates, err := qtx.ListPairingCandidates(ctx, sql.NullInt64{Int64: params.EventID, Valid: true})
+		if err != nil {
+			return EventGame{}, fmt.Errorf("failed to fetch players: %w", err)
+		}
+		for _, c := range candidates {
+			playerIDs = append(playerIDs, c.PlayerID.Int64)
+		}
+	}
+	if len(playerIDs) < 2 {
+		return EventGame{}, ErrTooFewGamePlayers
+	}
+	for _, id := range playerIDs {
+		if err := requireAccepted(ctx, qtx, params.EventID, id); err != nil {
+			if errors.Is(err, ErrPlayerNotAccepted) {
+				return EventGame{}, ErrGamePlayerNotAccepted
+			}
+			return EventGame{}, err
+		}
+	}
+
+	game, err := qtx.CreateEventGame(ctx, params)
+	if err != nil {
+		return EventGame{}, fmt.Errorf("failed to create game: %w", err)
+	}
+	for _, id := range playerIDs {
+		if err := qtx.CreateEventGamePlayer(ctx, CreateEventGamePlayerParams{GameID: game.ID, PlayerID: id}); err != nil {
+			return EventGame{}, fmt.Errorf("failed to add game player: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return EventGame{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return game, nil
+}
+
+// DeleteEventGame removes a game that hasn't been settled.
+func DeleteEventGame(ctx context.Context, db *sql.DB, q *Queries, eventID, gameID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if _, err := qtx.LockEventForUpdate(ctx, eventID); err != nil {
+		return err
+	}
+	game, err := qtx.GetEventGame(ctx, GetEventGameParams{ID: gameID, EventID: eventID})
+	if err != nil {
+		return err
+	}
+	if game.SettledAt.Valid {
+		return ErrGameSettled
+	}
+	if err := qtx.DeleteEventGame(ctx, gameID); err != nil {
+		return fmt.Errorf("failed to delete game: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// courseHandicap is the player's handicap for the tees they played. Players
+// without an index play off scratch, and cards without a rated tee set take
+// the index as it is.
+func courseHandicap(index, courseRating sql.NullFloat64, slopeRating sql.NullInt32, par int32) int {
+	if !index.Valid {
+		return 0
+	}
+	if !courseRating.Valid || !slopeRating.Valid || par == 0 {
+		return int(math.Round(index.Float64))
+	}
+	return scoring.CourseHandicap(index.Float64, slopeRating.Int32, courseRating.Float64, int(par))
+}
+
+// loadCards builds each accepted player's card for the event, keyed by
+// player.
+func loadCards(ctx context.Context, q *Queries, eventID int64) (map[int64]games.Card, error) {
+	handicaps, err := q.ListGameHandicapsByEventID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch handicaps: %w", err)
+	}
+	holes, err := q.ListGameHolesByEventID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch scores: %w", err)
+	}
+
+	cards := make(map[int64]games.Card, len(handicaps))
+	for _, h := range handicaps {
+		cards[h.PlayerID.Int64] = games.Card{
+			PlayerID:       h.PlayerID.Int64,
+			CourseHandicap: courseHandicap(h.HandicapIndex, h.CourseRating, h.SlopeRating, h.Par),
+			Holes:          map[int32]games.Hole{},
+		}
+	}
+	for _, h := range holes {
+		if c, ok := cards[h.PlayerID.Int64]; ok {
+			c.Holes[h.HoleNumber] = games.Hole{Strokes: h.Strokes, Par: h.Par, StrokeIndex: h.StrokeIndex}
+		}
+	}
+	return cards, nil
+}
+
+// PlayEventGames scores each of the event's games from its scorecards.
+func PlayEventGames(ctx context.Context, q *Queries, eventID int64) ([]PlayedGame, error) {
+	eventGames, err := q.ListEventGames(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch games: %w", err)
+	}
+	if len(eventGames) == 0 {
+		return nil, nil
+	}
+	players, err := q.ListEventGamePlayers(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch game players: %w", err)
+	}
+	cards, err := loadCards(ctx, q, eventID)
+	if err != nil {
+		return nil, err
+	}
+	payouts, err := q.ListGamePayoutsByEventID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch payouts: %w", err)
+	}
+
+	gamePlayers := map[int64][]int64{}
+	for _, p := range players {
+		gamePlayers[p.GameID] = append(gamePlayers[p.GameID], p.PlayerID)
+	}
+	settled := map[int64][]ledger.Debt{}
+	for _, p := range payouts {
+		settled[p.GameID] = append(settled[p.GameID], ledger.Debt{From: p.PayerID, To: p.PayeeID, AmountCents: int64(p.AmountCents)})
+	}
+
+	played := make([]PlayedGame, len(eventGames))
+	for i, g := range eventGames {
+		gameCards := make([]games.Card, len(gamePlayers[g.ID]))
+		for j, id := range gamePlayers[g.ID] {
+			// Players who have since dropped out have an empty card.
+			gameCards[j] = cards[id]
+			gameCards[j].PlayerID = id
+		}
+		results, debts := games.Play(games.Game{
+			Format:     games.Format(g.Format),
+			Net:        g.Net,
+			StakeCents: int64(g.StakeCents),
+			Carryover:  g.Carryover,
+		}, gameCards)
+
+		if g.SettledAt.Valid {
+			debts = settled[g.ID]
+			winnings := map[int64]int64{}
+			for _, d := range debts {
+				winnings[d.From] -= d.AmountCents
+				winnings[d.To] += d.AmountCents
+			}
+			for j := range results {
+				results[j].WinningsCents = winnings[results[j].PlayerID]
+			}
+		}
+		played[i] = PlayedGame{EventGame: g, PlayerIDs: gamePlayers[g.ID], Results: results, Debts: debts}
+	}
+	return played, nil
+}
+
+// SettleEventGames records what each unsettled game owes, in the event's
+// currency, once the round is completed and every game player's card has all
+// of its holes. Payouts then count toward players'
+// balances and stay fixed if scorecards are corrected later.
+func SettleEventGames(ctx context.Context, db *sql.DB, q *Queries, eventID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	event, err := qtx.LockEventForUpdate(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if event.CancelledAt.Valid {
+		return ErrEventCancelled
+	}
+	if event.Status != 2 { // completed
+		return ErrRoundNotCompleted
+	}
+	fee, err := qtx.GetEventFee(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	played, err := PlayEventGames(ctx, qtx, eventID)
+	if err != nil {
+		return err
+	}
+	// Games only score holes everyone has finished, so a missing hole would
+	// quietly settle a shorter round.
+	cards, err := loadCards(ctx, qtx, eventID)
+	if err != nil {
+		return err
+	}
+	holes := int(HoleCount(event.NumberOfHoles.String))
+	for _, g := range played {
+		if g.SettledAt.Valid {
+			continue
+		}
+		for _, id := range g.PlayerIDs {
+			if len(cards[id].Holes) < holes {
+				return ErrCardsIncomplete
+			}
+		}
+	}
+
+	for _, g := range played {
+		if g.SettledAt.Valid {
+			continue
+		}
+		for _, d := range g.Debts {
+			if err := qtx.CreateGamePayout(ctx, CreateGamePayoutParams{
+				GameID:      g.ID,
+				PayerID:     d.From,
+				PayeeID:     d.To,
+				AmountCents: int32(d.AmountCents),
+				Currency:    fee.FeeCurrency,
+			}); err != nil {
+				return fmt.Errorf("failed to record payout: %w", err)
+			}
+		}
+		if err := qtx.SettleEventGame(ctx, g.ID); err != nil {
+			return fmt.Errorf("failed to settle game: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
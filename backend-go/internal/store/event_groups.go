@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrGroupMemberNotAccepted = errors.New("grouped players must have accepted the event")
+
+// EventGroupInput is one group to save: a tee time and the players in it.
+type EventGroupInput struct {
+	TeeTime   string
+	PlayerIDs []int64
+}
+
+// ReplaceEventGroups swaps the event's groups for the given ones. The event is
+// locked so a player can't leave between the check that everyone has
+// accepted and the insert.
+func ReplaceEventGroups(ctx context.Context, db *sql.DB, q *Queries, eventID int64, groups []EventGroupInput) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if _, err := lockManageableEvent(ctx, qtx, eventID); err != nil {
+		return err
+	}
+
+	if err := qtx.DeleteEventGroups(ctx, eventID); err != nil {
+		return fmt.Errorf("failed to delete groups: %w", err)
+	}
+
+	for i, g := range groups {
+		group, err := qtx.CreateEventGroup(ctx, CreateEventGroupParams{
+			EventID:  eventID,
+			TeeTime:  g.TeeTime,
+			Position: int32(i),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create group: %w", err)
+		}
+		for _, playerID := range g.PlayerIDs {
+			if err := requireAccepted(ctx, qtx, eventID, playerID); err != nil {
+				if errors.Is(err, ErrPlayerNotAccepted) {
+					return ErrGroupMemberNotAccepted
+				}
+				return err
+			}
+			if err := qtx.CreateEventGroupMember(ctx, CreateEventGroupMemberParams{
+				EventID:  eventID,
+				GroupID:  group.ID,
+				PlayerID: playerID,
+			}); err != nil {
+				return fmt.Errorf("failed to create group member: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
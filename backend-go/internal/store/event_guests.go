@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrSponsorNotAccepted = errors.New("guests can only be added by players who have accepted")
+
+type AddEventGuestParams struct {
+	EventID   int64
+	SponsorID int64
+	Name      string
+}
+
+// AddEventGuest adds a named guest who takes one of the event's spots under
+// the same event lock as JoinEvent. The sponsor must have accepted the event.
+func AddEventGuest(ctx context.Context, db *sql.DB, q *Queries, params AddEventGuestParams) (EventGuest, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return EventGuest{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	event, err := qtx.LockEventForUpdate(ctx, params.EventID)
+	if err != nil {
+		return EventGuest{}, err
+	}
+	if event.CancelledAt.Valid {
+		return EventGuest{}, ErrEventCancelled
+	}
+	if event.Status == 2 { // completed
+		return EventGuest{}, ErrEventCompleted
+	}
+
+	sponsor, err := qtx.GetPlayerEvent(ctx, GetPlayerEventParams{
+		PlayerID: sql.NullInt64{Int64: params.SponsorID, Valid: true},
+		EventID:  sql.NullInt64{Int64: params.EventID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && sponsor.InviteStatus.Int32 != 1) {
+		return EventGuest{}, ErrSponsorNotAccepted
+	}
+	if err != nil {
+		return EventGuest{}, fmt.Errorf("failed to check sponsor: %w", err)
+	}
+
+	filledSpots, err := qtx.CountFilledSpotsForEvent(ctx, params.EventID)
+	if err != nil {
+		return EventGuest{}, fmt.Errorf("failed to count filled spots: %w", err)
+	}
+	if event.OpenSpots.Int32-int32(filledSpots) <= 0 {
+		return EventGuest{}, ErrEventFull
+	}
+
+	guest, err := qtx.CreateEventGuest(ctx, CreateEventGuestParams{
+		EventID:   params.EventID,
+		SponsorID: params.SponsorID,
+		Name:      params.Name,
+	})
+	if err != nil {
+		return EventGuest{}, fmt.Errorf("failed to create guest: %w", err)
+	}
+
+	if err := closeOrOpenInvitations(ctx, qtx, params.EventID, event.OpenSpots.Int32); err != nil {
+		return EventGuest{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return EventGuest{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return guest, nil
+}
+
+// RemoveEventGuest frees the guest's spot and reopens closed invitations.
+// It returns sql.ErrNoRows when the guest isn't on the event.
+func RemoveEventGuest(ctx context.Context, db *sql.DB, q *Queries, eventID, guestID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	event, err := qtx.LockEventForUpdate(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if event.Status == 2 { // completed
+		return ErrEventCompleted
+	}
+
+	n, err := qtx.DeleteEventGuest(ctx, DeleteEventGuestParams{ID: guestID, EventID: eventID})
+	if err != nil {
+		return fmt.Errorf("failed to delete guest: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := closeOrOpenInvitations(ctx, qtx, eventID, event.OpenSpots.Int32); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInviteExpired = errors.New("invite link has expired")
+	ErrInviteUsedUp  = errors.New("invite link has no uses left")
+)
+
+// CheckInviteLink reports why a link can no longer be redeemed, if anything.
+// Revoked links are treated as expired.
+func CheckInviteLink(link EventInviteLink, now time.Time) error {
+	if link.RevokedAt.Valid || (link.ExpiresAt.Valid && !now.Before(link.ExpiresAt.Time)) {
+		return ErrInviteExpired
+	}
+	if link.MaxUses.Valid && link.Uses >= link.MaxUses.Int32 {
+		return ErrInviteUsedUp
+	}
+	return nil
+}
+
+type RedeemInviteLinkParams struct {
+	LinkID   int64
+	PlayerID int64
+
+	// AllowConflicts skips the double-booking check.
+	AllowConflicts bool
+}
+
+// RedeemInviteLink adds the player to the link's event as accepted. The event
+// and the link are locked together so capacity and max uses both hold under
+// concurrent redemptions. A player who was already invited has their existing
+// invitation accepted. Like JoinEvent, it refuses to double-book the player
+// unless AllowConflicts is set.
+func RedeemInviteLink(ctx context.Context, db *sql.DB, q *Queries, params RedeemInviteLinkParams) (UpdatePlayerEventStatusRow, error) {
+	link, err := q.GetEventInviteLink(ctx, params.LinkID)
+	if err != nil {
+		return UpdatePlayerEventStatusRow{}, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	// Lock order matches JoinEvent: event first, then the link.
+	event, err := qtx.LockEventForUpdate(ctx, link.EventID)
+	if err != nil {
+		return UpdatePlayerEventStatusRow{}, err
+	}
+	if event.CancelledAt.Valid {
+		return UpdatePlayerEventStatusRow{}, ErrEventCancelled
+	}
+	if event.Status == 2 { // completed
+		return UpdatePlayerEventStatusRow{}, ErrEventCompleted
+	}
+	if rsvpClosed(event.RespondBy, time.Now()) {
+		return UpdatePlayerEventStatusRow{}, ErrRsvpClosed
+	}
+
+	link, err = qtx.LockEventInviteLinkForUpdate(ctx, params.LinkID)
+	if err != nil {
+		return UpdatePlayerEventStatusRow{}, err
+	}
+	if err := CheckInviteLink(link, time.Now()); err != nil {
+		return UpdatePlayerEventStatusRow{}, err
+	}
+
+	current, err := qtx.GetPlayerEvent(ctx, GetPlayerEventParams{
+		PlayerID: sql.NullInt64{Int64: params.PlayerID, Valid: true},
+		EventID:  sql.NullInt64{Int64: link.EventID, Valid: true},
+	})
+	hasRow := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to check player_event: %w", err)
+	}
+	if hasRow && current.InviteStatus.Int32 == 1 { // accepted
+		return UpdatePlayerEventStatusRow{}, ErrAlreadyJoined
+	}
+
+	filledSpots, err := qtx.CountFilledSpotsForEvent(ctx, link.EventID)
+	if err != nil {
+		return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to count filled spots: %w", err)
+	}
+	if event.OpenSpots.Int32-int32(filledSpots) <= 0 {
+		return UpdatePlayerEventStatusRow{}, ErrEventFull
+	}
+	if !params.AllowConflicts {
+		if err := checkScheduleConflict(ctx, qtx, params.PlayerID, event); err != nil {
+			return UpdatePlayerEventStatusRow{}, err
+		}
+	}
+
+	var pe UpdatePlayerEventStatusRow
+	if hasRow {
+		pe, err = qtx.UpdatePlayerEventStatus(ctx, UpdatePlayerEventStatusParams{
+			PlayerID:     sql.NullInt64{Int64: params.PlayerID, Valid: true},
+			EventID:      sql.NullInt64{Int64: link.EventID, Valid: true},
+			InviteStatus: sql.NullInt32{Int32: 1, Valid: true}, // accepted
+		})
+	} else {
+		var created CreatePlayerEventRow
+		created, err = qtx.CreatePlayerEvent(ctx, CreatePlayerEventParams{
+			PlayerID:     sql.NullInt64{Int64: params.PlayerID, Valid: true},
+			EventID:      sql.NullInt64{Int64: link.EventID, Valid: true},
+			InviteStatus: sql.NullInt32{Int32: 1, Valid: true}, // accepted
+		})
+		pe = UpdatePlayerEventStatusRow(created)
+	}
+	if err != nil {
+		return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to accept invite: %w", err)
+	}
+
+	if err := qtx.IncrementEventInviteLinkUses(ctx, link.ID); err != nil {
+		return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to record invite use: %w", err)
+	}
+
+	if err := closeOrOpenInvitations(ctx, qtx, link.EventID, event.OpenSpots.Int32); err != nil {
+		return UpdatePlayerEventStatusRow{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return pe, nil
+}
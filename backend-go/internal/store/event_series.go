@@ -0,0 +1,332 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ericrabun/findfore-go/internal/recurrence"
+)
+
+const occurrenceDateLayout = "2006-01-02"
+
+var ErrSpotsBelowAccepted = errors.New("open spots can't be fewer than accepted players and guests")
+
+type CreateEventSeriesWithInviteesParams struct {
+	HostID        int64
+	CourseID      int64
+	StartsOn      time.Time
+	TeeTime       string
+	OpenSpots     int32
+	NumberOfHoles string
+	Private       bool
+	Rule          recurrence.Rule
+	Invitees      []int64
+	Exceptions    []time.Time
+}
+
+func CreateEventSeriesWithInvitees(ctx context.Context, db *sql.DB, q *Queries, params CreateEventSeriesWithInviteesParams) (int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	series, err := qtx.CreateEventSeries(ctx, CreateEventSeriesParams{
+		HostID:        params.HostID,
+		CourseID:      params.CourseID,
+		StartsOn:      params.StartsOn,
+		TeeTime:       params.TeeTime,
+		OpenSpots:     params.OpenSpots,
+		NumberOfHoles: params.NumberOfHoles,
+		Private:       params.Private,
+		Rrule:         params.Rule.String(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create event series: %w", err)
+	}
+
+	for _, inviteeID := range params.Invitees {
+		if inviteeID == params.HostID {
+			continue
+		}
+		if err := qtx.CreateEventSeriesInvitee(ctx, CreateEventSeriesInviteeParams{
+			SeriesID: series.ID,
+			PlayerID: inviteeID,
+		}); err != nil {
+			return 0, fmt.Errorf("failed to create series invitee: %w", err)
+		}
+	}
+
+	for _, ex := range params.Exceptions {
+		if err := qtx.CreateEventSeriesException(ctx, CreateEventSeriesExceptionParams{
+			SeriesID:       series.ID,
+			OccurrenceDate: ex,
+		}); err != nil {
+			return 0, fmt.Errorf("failed to create series exception: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return series.ID, nil
+}
+
+// MaterializeSeries creates events for every occurrence of the series between
+// from and through that doesn't exist yet and isn't an exception. Existing
+// occurrences (including overridden ones) are left untouched, and standing
+// series RSVPs are applied to each new event in the same transaction. The
+// series row is locked while occurrences are created so concurrent calls
+// can't create the same one.
+func MaterializeSeries(ctx context.Context, db *sql.DB, q *Queries, seriesID int64, from, through time.Time) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if _, err := qtx.LockEventSeriesForUpdate(ctx, seriesID); err != nil {
+		return err
+	}
+
+	series, err := qtx.GetEventSeriesByID(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+
+	rule, err := recurrence.Parse(series.Rrule)
+	if err != nil {
+		return fmt.Errorf("invalid rrule on series %d: %w", seriesID, err)
+	}
+
+	exceptions, err := qtx.ListEventSeriesExceptionDates(ctx, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to list series exceptions: %w", err)
+	}
+
+	invitees, err := qtx.ListEventSeriesInviteeIDs(ctx, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to list series invitees: %w", err)
+	}
+
+	// Standing RSVPs are applied in the same transaction, so an occurrence is
+	// never committed without them.
+	rsvps, err := qtx.ListEventSeriesRsvps(ctx, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to list series rsvps: %w", err)
+	}
+
+	for _, date := range rule.Occurrences(series.StartsOn, from, through, exceptions) {
+		_, err := qtx.GetSeriesOccurrence(ctx, GetSeriesOccurrenceParams{
+			SeriesID:       sql.NullInt64{Int64: seriesID, Valid: true},
+			OccurrenceDate: sql.NullTime{Time: date, Valid: true},
+		})
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to look up occurrence: %w", err)
+		}
+
+		eventID, err := createEventWithInvites(ctx, qtx, CreateEventWithInvitesParams{
+			CourseID:       int32(series.CourseID),
+			Date:           date.Format(occurrenceDateLayout),
+			TeeTime:        series.TeeTime,
+			OpenSpots:      series.OpenSpots,
+			NumberOfHoles:  series.NumberOfHoles,
+			Private:        series.Private,
+			HostID:         int32(series.HostID),
+			Invitees:       invitees,
+			SeriesID:       seriesID,
+			OccurrenceDate: date,
+		})
+		if err != nil {
+			return err
+		}
+		for _, rsvp := range rsvps {
+			if err := applySeriesRsvp(ctx, qtx, eventID, rsvp.PlayerID, rsvp.InviteStatus); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+type RsvpEventSeriesParams struct {
+	SeriesID     int64
+	PlayerID     int64
+	InviteStatus int32
+	From         time.Time
+}
+
+// RsvpEventSeries records a standing RSVP for the series and applies it to
+// every materialized occurrence on or after From. Occurrences that are
+// already full or would double-book the player are skipped rather than
+// failing the whole request. The series row is locked like in
+// MaterializeSeries, so an occurrence created concurrently either gets the
+// RSVP or is already visible here.
+func RsvpEventSeries(ctx context.Context, db *sql.DB, q *Queries, params RsvpEventSeriesParams) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if _, err := qtx.LockEventSeriesForUpdate(ctx, params.SeriesID); err != nil {
+		return err
+	}
+
+	if _, err := qtx.UpsertEventSeriesRsvp(ctx, UpsertEventSeriesRsvpParams{
+		SeriesID:     params.SeriesID,
+		PlayerID:     params.PlayerID,
+		InviteStatus: params.InviteStatus,
+	}); err != nil {
+		return fmt.Errorf("failed to save series rsvp: %w", err)
+	}
+
+	occurrences, err := qtx.ListSeriesOccurrences(ctx, ListSeriesOccurrencesParams{
+		SeriesID:       sql.NullInt64{Int64: params.SeriesID, Valid: true},
+		OccurrenceDate: sql.NullTime{Time: params.From, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list occurrences: %w", err)
+	}
+
+	for _, occ := range occurrences {
+		if err := applySeriesRsvp(ctx, qtx, occ.ID, params.PlayerID, params.InviteStatus); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// applySeriesRsvp applies a standing RSVP to one occurrence. The checks that
+// skip it all fail before touching the database, so they leave the caller's
+// transaction usable.
+func applySeriesRsvp(ctx context.Context, qtx *Queries, eventID, playerID int64, status int32) error {
+	_, err := updateInviteStatus(ctx, qtx, UpdateInviteStatusParams{
+		PlayerID:     playerID,
+		EventID:      eventID,
+		InviteStatus: status,
+	})
+	if errors.Is(err, sql.ErrNoRows) && status == 1 { // accepted, but not invited yet
+		_, err = joinEvent(ctx, qtx, JoinEventParams{PlayerID: playerID, EventID: eventID})
+	}
+	var conflict *ScheduleConflictError
+	if err == nil || errors.Is(err, ErrEventFull) || errors.Is(err, ErrAlreadyJoined) || errors.Is(err, ErrEventCancelled) ||
+		errors.Is(err, ErrEventCompleted) || errors.Is(err, ErrRsvpClosed) || errors.As(err, &conflict) || errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return fmt.Errorf("failed to apply series rsvp to event %d: %w", eventID, err)
+}
+
+type OverrideOccurrenceParams struct {
+	EventID       int64
+	Date          string
+	CourseID      int32
+	TeeTime       string
+	OpenSpots     int32
+	NumberOfHoles string
+}
+
+// OverrideOccurrence changes the details of a single materialized occurrence
+// without touching the series, then re-runs the invitation cascade since the
+// number of open spots may have changed.
+func OverrideOccurrence(ctx context.Context, db *sql.DB, q *Queries, params OverrideOccurrenceParams) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if _, err := qtx.LockEventForUpdate(ctx, params.EventID); err != nil {
+		return err
+	}
+
+	filledSpots, err := qtx.CountFilledSpotsForEvent(ctx, params.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to count filled spots: %w", err)
+	}
+	if params.OpenSpots < int32(filledSpots) {
+		return ErrSpotsBelowAccepted
+	}
+
+	if err := qtx.UpdateEventDetails(ctx, UpdateEventDetailsParams{
+		ID:            params.EventID,
+		CourseID:      sql.NullInt32{Int32: params.CourseID, Valid: true},
+		TeeTime:       sql.NullString{String: params.TeeTime, Valid: true},
+		OpenSpots:     sql.NullInt32{Int32: params.OpenSpots, Valid: true},
+		NumberOfHoles: sql.NullString{String: params.NumberOfHoles, Valid: true},
+		StartsAt:      nullStartsAt(params.Date, params.TeeTime),
+	}); err != nil {
+		return fmt.Errorf("failed to update event: %w", err)
+	}
+
+	if err := closeOrOpenInvitations(ctx, qtx, params.EventID, params.OpenSpots); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CancelOccurrence records the date as an exception so it is never
+// materialized again and cancels the occurrence's event if it exists, so
+// players' calendars show it as cancelled rather than silently dropping it.
+func CancelOccurrence(ctx context.Context, db *sql.DB, q *Queries, seriesID int64, date time.Time) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.CreateEventSeriesException(ctx, CreateEventSeriesExceptionParams{
+		SeriesID:       seriesID,
+		OccurrenceDate: date,
+	}); err != nil {
+		return fmt.Errorf("failed to create series exception: %w", err)
+	}
+
+	occ, err := qtx.GetSeriesOccurrence(ctx, GetSeriesOccurrenceParams{
+		SeriesID:       sql.NullInt64{Int64: seriesID, Valid: true},
+		OccurrenceDate: sql.NullTime{Time: date, Valid: true},
+	})
+	if err == nil {
+		if _, err := cancelEventWithScores(ctx, qtx, occ.ID); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to look up occurrence: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -3,7 +3,9 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 )
 
 type CreateEventWithInvitesParams struct {
@@ -15,6 +17,52 @@ type CreateEventWithInvitesParams struct {
 	Private       bool
 	HostID        int32
 	Invitees      []int64
+
+	// RespondBy is the optional deadline for invitees to RSVP. Pending
+	// invitations expire once it passes.
+	RespondBy sql.NullTime
+
+	// FeeCents and FeeCurrency are the per-player fee. Either one left unset
+	// is taken from the course.
+	FeeCents    sql.NullInt32
+	FeeCurrency string
+
+	// SeriesID and OccurrenceDate are set when materializing an occurrence
+	// of a recurring series.
+	SeriesID       int64
+	OccurrenceDate time.Time
+
+	// LeagueSeasonID is set for events on a league season's schedule.
+	LeagueSeasonID int64
+}
+
+// eventDateLayouts are the formats accepted for events.date. Events created
+// by the original Rails app use MM-DD-YYYY.
+var eventDateLayouts = []string{"2006-01-02", "01-02-2006"}
+
+// EventStartsAt combines an event's date and tee time strings into the
+// timestamp used for searching and sorting.
+func EventStartsAt(date, teeTime string) (time.Time, error) {
+	for _, layout := range eventDateLayouts {
+		if t, err := time.Parse(layout+" 15:04", date+" "+teeTime); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid event date %q or tee time %q", date, teeTime)
+}
+
+// EstimatedDuration is how long a round is expected to take, used for
+// calendar entries since events only record a start time.
+func EstimatedDuration(numberOfHoles string) time.Duration {
+	if numberOfHoles == "9" {
+		return 2*time.Hour + 15*time.Minute
+	}
+	return 4*time.Hour + 30*time.Minute
+}
+
+func nullStartsAt(date, teeTime string) sql.NullTime {
+	t, err := EventStartsAt(date, teeTime)
+	return sql.NullTime{Time: t, Valid: err == nil}
 }
 
 func CreateEventWithInvites(ctx context.Context, db *sql.DB, q *Queries, params CreateEventWithInvitesParams) (int64, error) {
@@ -24,16 +72,53 @@ func CreateEventWithInvites(ctx context.Context, db *sql.DB, q *Queries, params
 	}
 	defer tx.Rollback()
 
-	qtx := q.WithTx(tx)
+	eventID, err := createEventWithInvites(ctx, q.WithTx(tx), params)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return eventID, nil
+}
+
+// createEventWithInvites is CreateEventWithInvites inside the caller's
+// transaction.
+func createEventWithInvites(ctx context.Context, qtx *Queries, params CreateEventWithInvitesParams) (int64, error) {
+	feeCents, feeCurrency := params.FeeCents, params.FeeCurrency
+	if !feeCents.Valid || feeCurrency == "" {
+		courseFee, err := qtx.GetCourseFee(ctx, int64(params.CourseID))
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("failed to fetch course fee: %w", err)
+		}
+		if !feeCents.Valid {
+			feeCents = courseFee.FeeCents
+		}
+		if feeCurrency == "" {
+			feeCurrency = courseFee.FeeCurrency
+		}
+	}
+	if feeCurrency == "" {
+		feeCurrency = DefaultCurrency
+	}
 
 	event, err := qtx.CreateEvent(ctx, CreateEventParams{
-		CourseID:      sql.NullInt32{Int32: params.CourseID, Valid: true},
-		Date:          sql.NullString{String: params.Date, Valid: true},
-		TeeTime:       sql.NullString{String: params.TeeTime, Valid: true},
-		OpenSpots:     sql.NullInt32{Int32: params.OpenSpots, Valid: true},
-		NumberOfHoles: sql.NullString{String: params.NumberOfHoles, Valid: true},
-		Private:       sql.NullBool{Bool: params.Private, Valid: true},
-		HostID:        sql.NullInt32{Int32: params.HostID, Valid: true},
+		CourseID:       sql.NullInt32{Int32: params.CourseID, Valid: true},
+		Date:           sql.NullString{String: params.Date, Valid: true},
+		TeeTime:        sql.NullString{String: params.TeeTime, Valid: true},
+		OpenSpots:      sql.NullInt32{Int32: params.OpenSpots, Valid: true},
+		NumberOfHoles:  sql.NullString{String: params.NumberOfHoles, Valid: true},
+		Private:        sql.NullBool{Bool: params.Private, Valid: true},
+		HostID:         sql.NullInt32{Int32: params.HostID, Valid: true},
+		SeriesID:       sql.NullInt64{Int64: params.SeriesID, Valid: params.SeriesID != 0},
+		OccurrenceDate: sql.NullTime{Time: params.OccurrenceDate, Valid: !params.OccurrenceDate.IsZero()},
+		StartsAt:       nullStartsAt(params.Date, params.TeeTime),
+		RespondBy:      params.RespondBy,
+		FeeCents:       feeCents,
+		FeeCurrency:    feeCurrency,
+		LeagueSeasonID: sql.NullInt64{Int64: params.LeagueSeasonID, Valid: params.LeagueSeasonID != 0},
 	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to create event: %w", err)
@@ -49,42 +134,119 @@ func CreateEventWithInvites(ctx context.Context, db *sql.DB, q *Queries, params
 		return 0, fmt.Errorf("failed to create host player_event: %w", err)
 	}
 
-	if params.Private {
-		// Private event: invite only specified invitees
-		for _, inviteeID := range params.Invitees {
-			if inviteeID == int64(params.HostID) {
-				continue
-			}
-			_, err = qtx.CreatePlayerEvent(ctx, CreatePlayerEventParams{
-				PlayerID:     sql.NullInt64{Int64: inviteeID, Valid: true},
-				EventID:      sql.NullInt64{Int64: event.ID, Valid: true},
-				InviteStatus: sql.NullInt32{Int32: 0, Valid: true}, // pending
-			})
-			if err != nil {
-				return 0, fmt.Errorf("failed to create invitee player_event: %w", err)
-			}
+	// Only targeted invitees get a pending row. Public events are visible to
+	// every player without one; their player_events row is created when they
+	// RSVP.
+	for _, inviteeID := range params.Invitees {
+		if inviteeID == int64(params.HostID) {
+			continue
 		}
-	} else {
-		// Public event: invite all players except host
-		playerIDs, err := qtx.ListPlayersExceptHost(ctx, int64(params.HostID))
+		_, err = qtx.CreatePlayerEvent(ctx, CreatePlayerEventParams{
+			PlayerID:     sql.NullInt64{Int64: inviteeID, Valid: true},
+			EventID:      sql.NullInt64{Int64: event.ID, Valid: true},
+			InviteStatus: sql.NullInt32{Int32: 0, Valid: true}, // pending
+		})
 		if err != nil {
-			return 0, fmt.Errorf("failed to list players: %w", err)
-		}
-		for _, pid := range playerIDs {
-			_, err = qtx.CreatePlayerEvent(ctx, CreatePlayerEventParams{
-				PlayerID:     sql.NullInt64{Int64: pid, Valid: true},
-				EventID:      sql.NullInt64{Int64: event.ID, Valid: true},
-				InviteStatus: sql.NullInt32{Int32: 0, Valid: true}, // pending
-			})
-			if err != nil {
-				return 0, fmt.Errorf("failed to create player_event: %w", err)
-			}
+			return 0, fmt.Errorf("failed to create invitee player_event: %w", err)
 		}
 	}
 
+	return event.ID, nil
+}
+
+// CancelEvent cancels a scheduled or in progress event, returning 0 if it was
+// already cancelled or completed. Cancelled rounds don't count toward
+// handicaps, so the handicaps of players who posted scores are recalculated
+// and everyone's cached stats are cleared.
+func CancelEvent(ctx context.Context, db *sql.DB, q *Queries, eventID int64) (int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	n, err := cancelEventWithScores(ctx, q.WithTx(tx), eventID)
+	if err != nil {
+		return 0, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	return n, nil
+}
 
-	return event.ID, nil
+// cancelEventWithScores is CancelEvent inside the caller's transaction.
+func cancelEventWithScores(ctx context.Context, qtx *Queries, eventID int64) (int64, error) {
+	n, err := qtx.CancelEvent(ctx, eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel event: %w", err)
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	if err := qtx.DeleteEventPlayerStats(ctx, eventID); err != nil {
+		return 0, fmt.Errorf("failed to clear cached stats: %w", err)
+	}
+	cards, err := qtx.ListScorecardsByEventID(ctx, eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch scorecards: %w", err)
+	}
+	for _, card := range cards {
+		if !card.ScorecardID.Valid {
+			continue
+		}
+		if err := recalculateHandicap(ctx, qtx, card.PlayerID.Int64); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// DeleteEvent deletes the event along with everything that cascades from it,
+// including scorecards. The handicaps of players who posted scores are
+// recalculated without the round and their cached stats are cleared in the
+// same transaction, so a stats read can't cache the round again in between.
+// It returns sql.ErrNoRows when the event doesn't exist.
+func DeleteEvent(ctx context.Context, db *sql.DB, q *Queries, eventID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if _, err := qtx.LockEventForUpdate(ctx, eventID); err != nil {
+		return err
+	}
+
+	// Players' cached stats may include the round. Clear them while the
+	// event still lists who played.
+	if err := qtx.DeleteEventPlayerStats(ctx, eventID); err != nil {
+		return fmt.Errorf("failed to clear cached stats: %w", err)
+	}
+	cards, err := qtx.ListScorecardsByEventID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch scorecards: %w", err)
+	}
+
+	if err := qtx.DeleteEvent(ctx, eventID); err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+
+	for _, card := range cards {
+		if !card.ScorecardID.Valid {
+			continue
+		}
+		if err := recalculateHandicap(ctx, qtx, card.PlayerID.Int64); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
 }
@@ -8,22 +8,65 @@ package store
 import (
 	"context"
 	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
 )
 
+const cancelEvent = `-- name: CancelEvent :execrows
+UPDATE events
+SET cancelled_at = NOW(), status = 3, sequence = sequence + 1, updated_at = NOW()
+WHERE id = $1 AND status IN (0, 1)
+`
+
+func (q *Queries) CancelEvent(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cancelEvent, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const completeFinishedEvents = `-- name: CompleteFinishedEvents :execrows
+UPDATE events e
+SET status = 2, updated_at = NOW()
+FROM courses c
+WHERE c.id = e.course_id
+  AND e.status IN (0, 1)
+  AND e.starts_at + CASE WHEN e.number_of_holes = '9' THEN INTERVAL '2 hours 15 minutes' ELSE INTERVAL '4 hours 30 minutes' END <= ($1::timestamptz AT TIME ZONE c.time_zone)
+`
+
+// The interval matches EstimatedDuration. Like StartDueEvents, now is
+// compared in the course's time zone.
+func (q *Queries) CompleteFinishedEvents(ctx context.Context, now time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, completeFinishedEvents, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createEvent = `-- name: CreateEvent :one
-INSERT INTO events (course_id, date, tee_time, open_spots, number_of_holes, private, host_id, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+INSERT INTO events (course_id, date, tee_time, open_spots, number_of_holes, private, host_id, series_id, occurrence_date, starts_at, respond_by, fee_cents, fee_currency, league_season_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW(), NOW())
 RETURNING id, course_id, date, tee_time, open_spots, number_of_holes, private, host_id
 `
 
 type CreateEventParams struct {
-	CourseID      sql.NullInt32
-	Date          sql.NullString
-	TeeTime       sql.NullString
-	OpenSpots     sql.NullInt32
-	NumberOfHoles sql.NullString
-	Private       sql.NullBool
-	HostID        sql.NullInt32
+	CourseID       sql.NullInt32
+	Date           sql.NullString
+	TeeTime        sql.NullString
+	OpenSpots      sql.NullInt32
+	NumberOfHoles  sql.NullString
+	Private        sql.NullBool
+	HostID         sql.NullInt32
+	SeriesID       sql.NullInt64
+	OccurrenceDate sql.NullTime
+	StartsAt       sql.NullTime
+	RespondBy      sql.NullTime
+	FeeCents       sql.NullInt32
+	FeeCurrency    string
+	LeagueSeasonID sql.NullInt64
 }
 
 type CreateEventRow struct {
@@ -46,6 +89,13 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Creat
 		arg.NumberOfHoles,
 		arg.Private,
 		arg.HostID,
+		arg.SeriesID,
+		arg.OccurrenceDate,
+		arg.StartsAt,
+		arg.RespondBy,
+		arg.FeeCents,
+		arg.FeeCurrency,
+		arg.LeagueSeasonID,
 	)
 	var i CreateEventRow
 	err := row.Scan(
@@ -110,47 +160,105 @@ func (q *Queries) GetEventByID(ctx context.Context, id int64) (GetEventByIDRow,
 	return i, err
 }
 
-const listAllEvents = `-- name: ListAllEvents :many
-SELECT e.id, e.course_id, e.date, e.tee_time, e.open_spots, e.number_of_holes,
-       e.private, e.host_id, c.name AS course_name, p.name AS host_name
+const getEventFee = `-- name: GetEventFee :one
+SELECT fee_cents, fee_currency
+FROM events
+WHERE id = $1
+`
+
+type GetEventFeeRow struct {
+	FeeCents    sql.NullInt32
+	FeeCurrency string
+}
+
+func (q *Queries) GetEventFee(ctx context.Context, id int64) (GetEventFeeRow, error) {
+	row := q.db.QueryRowContext(ctx, getEventFee, id)
+	var i GetEventFeeRow
+	err := row.Scan(&i.FeeCents, &i.FeeCurrency)
+	return i, err
+}
+
+const getSeriesOccurrence = `-- name: GetSeriesOccurrence :one
+SELECT id, occurrence_date
+FROM events
+WHERE series_id = $1 AND occurrence_date = $2
+`
+
+type GetSeriesOccurrenceParams struct {
+	SeriesID       sql.NullInt64
+	OccurrenceDate sql.NullTime
+}
+
+type GetSeriesOccurrenceRow struct {
+	ID             int64
+	OccurrenceDate sql.NullTime
+}
+
+func (q *Queries) GetSeriesOccurrence(ctx context.Context, arg GetSeriesOccurrenceParams) (GetSeriesOccurrenceRow, error) {
+	row := q.db.QueryRowContext(ctx, getSeriesOccurrence, arg.SeriesID, arg.OccurrenceDate)
+	var i GetSeriesOccurrenceRow
+	err := row.Scan(&i.ID, &i.OccurrenceDate)
+	return i, err
+}
+
+const listCalendarEvents = `-- name: ListCalendarEvents :many
+SELECT e.id, e.number_of_holes, e.starts_at, e.sequence, e.cancelled_at, e.updated_at,
+       c.name AS course_name, c.street, c.city, c.state, c.zip_code, p.name AS host_name
 FROM events e
 JOIN courses c ON c.id = e.course_id
 JOIN players p ON p.id = e.host_id
-ORDER BY e.id
+WHERE e.starts_at IS NOT NULL
+  AND ($1::bigint IS NULL OR e.id = $1::bigint)
+  AND ($2::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = $2::bigint AND pe.invite_status = 1
+  ))
+  AND ($3::timestamp IS NULL OR e.starts_at >= $3::timestamp)
+ORDER BY e.starts_at, e.id
 `
 
-type ListAllEventsRow struct {
+type ListCalendarEventsParams struct {
+	EventID     sql.NullInt64
+	PlayerID    sql.NullInt64
+	StartsAfter sql.NullTime
+}
+
+type ListCalendarEventsRow struct {
 	ID            int64
-	CourseID      sql.NullInt32
-	Date          sql.NullString
-	TeeTime       sql.NullString
-	OpenSpots     sql.NullInt32
 	NumberOfHoles sql.NullString
-	Private       sql.NullBool
-	HostID        sql.NullInt32
+	StartsAt      sql.NullTime
+	Sequence      int32
+	CancelledAt   sql.NullTime
+	UpdatedAt     time.Time
 	CourseName    sql.NullString
+	Street        sql.NullString
+	City          sql.NullString
+	State         sql.NullString
+	ZipCode       sql.NullString
 	HostName      sql.NullString
 }
 
-func (q *Queries) ListAllEvents(ctx context.Context) ([]ListAllEventsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listAllEvents)
+func (q *Queries) ListCalendarEvents(ctx context.Context, arg ListCalendarEventsParams) ([]ListCalendarEventsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCalendarEvents, arg.EventID, arg.PlayerID, arg.StartsAfter)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListAllEventsRow
+	var items []ListCalendarEventsRow
 	for rows.Next() {
-		var i ListAllEventsRow
+		var i ListCalendarEventsRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.CourseID,
-			&i.Date,
-			&i.TeeTime,
-			&i.OpenSpots,
 			&i.NumberOfHoles,
-			&i.Private,
-			&i.HostID,
+			&i.StartsAt,
+			&i.Sequence,
+			&i.CancelledAt,
+			&i.UpdatedAt,
 			&i.CourseName,
+			&i.Street,
+			&i.City,
+			&i.State,
+			&i.ZipCode,
 			&i.HostName,
 		); err != nil {
 			return nil, err
@@ -166,18 +274,17 @@ func (q *Queries) ListAllEvents(ctx context.Context) ([]ListAllEventsRow, error)
 	return items, nil
 }
 
-const listEventsByPlayerID = `-- name: ListEventsByPlayerID :many
+const listEventsByIDs = `-- name: ListEventsByIDs :many
 SELECT e.id, e.course_id, e.date, e.tee_time, e.open_spots, e.number_of_holes,
-       e.private, e.host_id, c.name AS course_name, p.name AS host_name
+       e.private, e.host_id, e.cancelled_at, e.respond_by, e.status, e.fee_cents, e.fee_currency,
+       c.name AS course_name, p.name AS host_name
 FROM events e
 JOIN courses c ON c.id = e.course_id
 JOIN players p ON p.id = e.host_id
-JOIN player_events pe ON pe.event_id = e.id
-WHERE pe.player_id = $1
-ORDER BY e.id
+WHERE e.id = ANY($1::bigint[])
 `
 
-type ListEventsByPlayerIDRow struct {
+type ListEventsByIDsRow struct {
 	ID            int64
 	CourseID      sql.NullInt32
 	Date          sql.NullString
@@ -186,19 +293,24 @@ type ListEventsByPlayerIDRow struct {
 	NumberOfHoles sql.NullString
 	Private       sql.NullBool
 	HostID        sql.NullInt32
+	CancelledAt   sql.NullTime
+	RespondBy     sql.NullTime
+	Status        int32
+	FeeCents      sql.NullInt32
+	FeeCurrency   string
 	CourseName    sql.NullString
 	HostName      sql.NullString
 }
 
-func (q *Queries) ListEventsByPlayerID(ctx context.Context, playerID sql.NullInt64) ([]ListEventsByPlayerIDRow, error) {
-	rows, err := q.db.QueryContext(ctx, listEventsByPlayerID, playerID)
+func (q *Queries) ListEventsByIDs(ctx context.Context, ids []int64) ([]ListEventsByIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEventsByIDs, pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListEventsByPlayerIDRow
+	var items []ListEventsByIDsRow
 	for rows.Next() {
-		var i ListEventsByPlayerIDRow
+		var i ListEventsByIDsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.CourseID,
@@ -208,6 +320,11 @@ func (q *Queries) ListEventsByPlayerID(ctx context.Context, playerID sql.NullInt
 			&i.NumberOfHoles,
 			&i.Private,
 			&i.HostID,
+			&i.CancelledAt,
+			&i.RespondBy,
+			&i.Status,
+			&i.FeeCents,
+			&i.FeeCurrency,
 			&i.CourseName,
 			&i.HostName,
 		); err != nil {
@@ -228,7 +345,8 @@ const listFriendsAvailableEventIDs = `-- name: ListFriendsAvailableEventIDs :man
 SELECT DISTINCT e.id
 FROM events e
 JOIN player_events pe ON pe.event_id = e.id AND pe.invite_status = 1
-WHERE pe.player_id IN (
+WHERE e.status IN (0, 1)
+AND pe.player_id IN (
   SELECT followee_id FROM friendships WHERE follower_id = $1
 )
 AND NOT EXISTS (
@@ -238,6 +356,9 @@ AND NOT EXISTS (
 AND e.open_spots > (
   SELECT COUNT(*) FROM player_events pe3
   WHERE pe3.event_id = e.id AND pe3.invite_status = 1
+) + (
+  SELECT COUNT(*) FROM event_guests g
+  WHERE g.event_id = e.id
 )
 `
 
@@ -269,50 +390,193 @@ func (q *Queries) ListFriendsAvailableEventIDs(ctx context.Context, arg ListFrie
 	return items, nil
 }
 
-const listPublicEvents = `-- name: ListPublicEvents :many
-SELECT e.id, e.course_id, e.date, e.tee_time, e.open_spots, e.number_of_holes,
-       e.private, e.host_id, c.name AS course_name, p.name AS host_name
-FROM events e
-JOIN courses c ON c.id = e.course_id
-JOIN players p ON p.id = e.host_id
-WHERE e.private = false
-ORDER BY e.id
+const listSeriesOccurrences = `-- name: ListSeriesOccurrences :many
+SELECT id, occurrence_date
+FROM events
+WHERE series_id = $1 AND occurrence_date >= $2
+ORDER BY occurrence_date
+`
+
+type ListSeriesOccurrencesParams struct {
+	SeriesID       sql.NullInt64
+	OccurrenceDate sql.NullTime
+}
+
+type ListSeriesOccurrencesRow struct {
+	ID             int64
+	OccurrenceDate sql.NullTime
+}
+
+func (q *Queries) ListSeriesOccurrences(ctx context.Context, arg ListSeriesOccurrencesParams) ([]ListSeriesOccurrencesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSeriesOccurrences, arg.SeriesID, arg.OccurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSeriesOccurrencesRow
+	for rows.Next() {
+		var i ListSeriesOccurrencesRow
+		if err := rows.Scan(&i.ID, &i.OccurrenceDate); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockEventForUpdate = `-- name: LockEventForUpdate :one
+SELECT id, open_spots, private, cancelled_at, respond_by, status, host_id, starts_at, number_of_holes
+FROM events
+WHERE id = $1
+FOR UPDATE
 `
 
-type ListPublicEventsRow struct {
+type LockEventForUpdateRow struct {
 	ID            int64
-	CourseID      sql.NullInt32
-	Date          sql.NullString
-	TeeTime       sql.NullString
 	OpenSpots     sql.NullInt32
-	NumberOfHoles sql.NullString
 	Private       sql.NullBool
+	CancelledAt   sql.NullTime
+	RespondBy     sql.NullTime
+	Status        int32
 	HostID        sql.NullInt32
-	CourseName    sql.NullString
-	HostName      sql.NullString
+	StartsAt      sql.NullTime
+	NumberOfHoles sql.NullString
 }
 
-func (q *Queries) ListPublicEvents(ctx context.Context) ([]ListPublicEventsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listPublicEvents)
+func (q *Queries) LockEventForUpdate(ctx context.Context, id int64) (LockEventForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, lockEventForUpdate, id)
+	var i LockEventForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.OpenSpots,
+		&i.Private,
+		&i.CancelledAt,
+		&i.RespondBy,
+		&i.Status,
+		&i.HostID,
+		&i.StartsAt,
+		&i.NumberOfHoles,
+	)
+	return i, err
+}
+
+const searchEvents = `-- name: SearchEvents :many
+SELECT e.id, COALESCE(e.starts_at, 'epoch'::timestamp)::timestamp AS sort_key
+FROM events e
+JOIN courses c ON c.id = e.course_id
+WHERE e.status = ANY($1::int[])
+  AND ($2::timestamp IS NULL OR e.starts_at >= $2::timestamp)
+  AND ($3::timestamp IS NULL OR e.starts_at < $3::timestamp)
+  AND ($4::int IS NULL OR e.course_id = $4::int)
+  AND ($5::text IS NULL OR lower(c.city) = lower($5::text))
+  AND ($6::text IS NULL OR lower(c.state) = lower($6::text))
+  AND ($7::text IS NULL OR e.number_of_holes = $7::text)
+  AND ($8::int IS NULL OR e.host_id = $8::int)
+  AND ($9::bool IS NULL OR e.private = $9::bool)
+  AND ($10::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = $10::bigint
+  ))
+  AND ($11::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = $11::bigint AND pe.invite_status = 1
+  ))
+  AND ($12::int IS NULL OR EXISTS (
+    SELECT 1 FROM friendships f
+    WHERE f.follower_id = $12::int
+      AND (f.followee_id = e.host_id OR EXISTS (
+        SELECT 1 FROM player_events pe
+        WHERE pe.event_id = e.id AND pe.player_id = f.followee_id AND pe.invite_status = 1
+      ))
+  ))
+  AND ($13::bigint IS NULL OR (
+    e.private = false AND NOT EXISTS (
+      SELECT 1 FROM player_events pe
+      WHERE pe.event_id = e.id AND pe.player_id = $13::bigint
+    )
+  ) OR EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = $13::bigint AND pe.invite_status = 0
+  ))
+  AND (NOT $14::bool OR e.open_spots > (
+    SELECT COUNT(*) FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.invite_status = 1
+  ) + (
+    SELECT COUNT(*) FROM event_guests g
+    WHERE g.event_id = e.id
+  ))
+  AND ($15::bigint IS NULL
+    OR (NOT $16::bool AND (COALESCE(e.starts_at, 'epoch'::timestamp), e.id) > ($17::timestamp, $15::bigint))
+    OR ($16::bool AND (COALESCE(e.starts_at, 'epoch'::timestamp), e.id) < ($17::timestamp, $15::bigint)))
+ORDER BY
+  CASE WHEN NOT $16::bool THEN COALESCE(e.starts_at, 'epoch'::timestamp) END ASC,
+  CASE WHEN NOT $16::bool THEN e.id END ASC,
+  CASE WHEN $16::bool THEN COALESCE(e.starts_at, 'epoch'::timestamp) END DESC,
+  CASE WHEN $16::bool THEN e.id END DESC
+LIMIT $18::int
+`
+
+type SearchEventsParams struct {
+	Statuses       []int32
+	StartsAfter    sql.NullTime
+	StartsBefore   sql.NullTime
+	CourseID       sql.NullInt32
+	City           sql.NullString
+	State          sql.NullString
+	NumberOfHoles  sql.NullString
+	HostID         sql.NullInt32
+	Private        sql.NullBool
+	PlayerID       sql.NullInt64
+	AcceptedBy     sql.NullInt64
+	FriendsOf      sql.NullInt32
+	AvailableTo    sql.NullInt64
+	HasOpenSpots   bool
+	CursorID       sql.NullInt64
+	SortDesc       bool
+	CursorStartsAt sql.NullTime
+	PageLimit      sql.NullInt32
+}
+
+type SearchEventsRow struct {
+	ID      int64
+	SortKey time.Time
+}
+
+func (q *Queries) SearchEvents(ctx context.Context, arg SearchEventsParams) ([]SearchEventsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchEvents,
+		pq.Array(arg.Statuses),
+		arg.StartsAfter,
+		arg.StartsBefore,
+		arg.CourseID,
+		arg.City,
+		arg.State,
+		arg.NumberOfHoles,
+		arg.HostID,
+		arg.Private,
+		arg.PlayerID,
+		arg.AcceptedBy,
+		arg.FriendsOf,
+		arg.AvailableTo,
+		arg.HasOpenSpots,
+		arg.CursorID,
+		arg.SortDesc,
+		arg.CursorStartsAt,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListPublicEventsRow
+	var items []SearchEventsRow
 	for rows.Next() {
-		var i ListPublicEventsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.CourseID,
-			&i.Date,
-			&i.TeeTime,
-			&i.OpenSpots,
-			&i.NumberOfHoles,
-			&i.Private,
-			&i.HostID,
-			&i.CourseName,
-			&i.HostName,
-		); err != nil {
+		var i SearchEventsRow
+		if err := rows.Scan(&i.ID, &i.SortKey); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -325,3 +589,66 @@ func (q *Queries) ListPublicEvents(ctx context.Context) ([]ListPublicEventsRow,
 	}
 	return items, nil
 }
+
+const startDueEvents = `-- name: StartDueEvents :execrows
+UPDATE events e
+SET status = 1, updated_at = NOW()
+FROM courses c
+WHERE c.id = e.course_id
+  AND e.status = 0
+  AND e.starts_at <= ($1::timestamptz AT TIME ZONE c.time_zone)
+`
+
+// starts_at is the course's local time, so now is compared in the course's
+// time zone.
+func (q *Queries) StartDueEvents(ctx context.Context, now time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, startDueEvents, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateEventDetails = `-- name: UpdateEventDetails :exec
+UPDATE events
+SET course_id = $2, tee_time = $3, open_spots = $4, number_of_holes = $5, starts_at = $6,
+    sequence = sequence + 1, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateEventDetailsParams struct {
+	ID            int64
+	CourseID      sql.NullInt32
+	TeeTime       sql.NullString
+	OpenSpots     sql.NullInt32
+	NumberOfHoles sql.NullString
+	StartsAt      sql.NullTime
+}
+
+func (q *Queries) UpdateEventDetails(ctx context.Context, arg UpdateEventDetailsParams) error {
+	_, err := q.db.ExecContext(ctx, updateEventDetails,
+		arg.ID,
+		arg.CourseID,
+		arg.TeeTime,
+		arg.OpenSpots,
+		arg.NumberOfHoles,
+		arg.StartsAt,
+	)
+	return err
+}
+
+const updateEventHost = `-- name: UpdateEventHost :exec
+UPDATE events
+SET host_id = $2, sequence = sequence + 1, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateEventHostParams struct {
+	ID     int64
+	HostID sql.NullInt32
+}
+
+func (q *Queries) UpdateEventHost(ctx context.Context, arg UpdateEventHostParams) error {
+	_, err := q.db.ExecContext(ctx, updateEventHost, arg.ID, arg.HostID)
+	return err
+}
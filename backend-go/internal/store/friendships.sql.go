@@ -8,6 +8,8 @@ package store
 import (
 	"context"
 	"database/sql"
+
+	"github.com/lib/pq"
 )
 
 const createFriendship = `-- name: CreateFriendship :one
@@ -101,3 +103,37 @@ func (q *Queries) ListFolloweeIDsByFollowerID(ctx context.Context, followerID sq
 	}
 	return items, nil
 }
+
+const listFriendshipsAmong = `-- name: ListFriendshipsAmong :many
+SELECT follower_id, followee_id
+FROM friendships
+WHERE follower_id = ANY($1::int[]) AND followee_id = ANY($1::int[])
+`
+
+type ListFriendshipsAmongRow struct {
+	FollowerID sql.NullInt32
+	FolloweeID sql.NullInt32
+}
+
+func (q *Queries) ListFriendshipsAmong(ctx context.Context, playerIds []int32) ([]ListFriendshipsAmongRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFriendshipsAmong, pq.Array(playerIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFriendshipsAmongRow
+	for rows.Next() {
+		var i ListFriendshipsAmongRow
+		if err := rows.Scan(&i.FollowerID, &i.FolloweeID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ericrabun/findfore-go/internal/scoring"
+)
+
+// roundHoles is how many holes a round needs to count toward a handicap.
+const roundHoles = 18
+
+// recalculateHandicap rebuilds the player's handicap history from their
+// complete 18 hole scorecards played from a rated tee set, and keeps
+// players.handicap_index at the latest index. Until three rounds are posted
+// the player keeps their self-reported index; a calculated index is cleared
+// if the posted rounds drop below three, e.g. when an event is cancelled.
+func recalculateHandicap(ctx context.Context, qtx *Queries, playerID int64) error {
+	if _, err := qtx.LockPlayerForUpdate(ctx, playerID); err != nil {
+		return fmt.Errorf("failed to lock player: %w", err)
+	}
+
+	rows, err := qtx.ListHandicapRoundHoles(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch rounds: %w", err)
+	}
+	var rounds []scoring.Round
+	for _, row := range rows {
+		if len(rounds) == 0 || rounds[len(rounds)-1].ID != row.ScorecardID {
+			rounds = append(rounds, scoring.Round{
+				ID:           row.ScorecardID,
+				PlayedAt:     row.PlayedAt,
+				CourseRating: row.CourseRating,
+				SlopeRating:  row.SlopeRating,
+			})
+		}
+		round := &rounds[len(rounds)-1]
+		round.Holes = append(round.Holes, scoring.RatedHole{
+			Strokes:     row.Strokes,
+			Par:         row.Par,
+			StrokeIndex: row.StrokeIndex,
+		})
+	}
+	complete := rounds[:0]
+	for _, round := range rounds {
+		if len(round.Holes) == roundHoles {
+			complete = append(complete, round)
+		}
+	}
+
+	previous, err := qtx.ListHandicapRevisions(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch handicap history: %w", err)
+	}
+	wasCalculated := len(previous) > 0 && previous[len(previous)-1].HandicapIndex.Valid
+
+	if err := qtx.DeleteHandicapRevisions(ctx, playerID); err != nil {
+		return fmt.Errorf("failed to delete handicap history: %w", err)
+	}
+	revisions := scoring.Revisions(complete)
+	for _, rev := range revisions {
+		params := CreateHandicapRevisionParams{
+			PlayerID:           playerID,
+			ScorecardID:        rev.RoundID,
+			PlayedAt:           rev.PlayedAt,
+			AdjustedGrossScore: int32(rev.AdjustedGrossScore),
+			ScoreDifferential:  rev.Differential,
+		}
+		if rev.Index != nil {
+			params.HandicapIndex = sql.NullFloat64{Float64: *rev.Index, Valid: true}
+		}
+		if rev.LowIndex != nil {
+			params.LowHandicapIndex = sql.NullFloat64{Float64: *rev.LowIndex, Valid: true}
+		}
+		if err := qtx.CreateHandicapRevision(ctx, params); err != nil {
+			return fmt.Errorf("failed to save handicap history: %w", err)
+		}
+	}
+
+	var index sql.NullFloat64
+	if n := len(revisions); n > 0 && revisions[n-1].Index != nil {
+		index = sql.NullFloat64{Float64: *revisions[n-1].Index, Valid: true}
+	}
+	if !index.Valid && !wasCalculated {
+		return nil
+	}
+	if _, err := qtx.SetPlayerHandicapIndex(ctx, SetPlayerHandicapIndexParams{
+		ID:            playerID,
+		HandicapIndex: index,
+	}); err != nil {
+		return fmt.Errorf("failed to save handicap index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ericrabun/findfore-go/internal/leaderboard"
+)
+
+// LeaderboardRounds loads the rounds the viewer may see with their gross and
+// net scores, and the names of the players who posted them. Net scores take
+// off the player's current course handicap, as net games do.
+func LeaderboardRounds(ctx context.Context, q *Queries, params ListLeaderboardRoundsParams) ([]leaderboard.Round, map[int64]string, error) {
+	rows, err := q.ListLeaderboardRounds(ctx, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rounds: %w", err)
+	}
+
+	rounds := make([]leaderboard.Round, len(rows))
+	names := map[int64]string{}
+	for i, row := range rows {
+		gross := int(row.Strokes)
+		rounds[i] = leaderboard.Round{
+			ScorecardID: row.ScorecardID,
+			PlayerID:    row.PlayerID.Int64,
+			EventID:     row.EventID,
+			CourseID:    int64(row.CourseID.Int32),
+			PlayedAt:    row.PlayedAt,
+			Gross:       gross,
+			Net:         gross - courseHandicap(row.HandicapIndex, row.CourseRating, row.SlopeRating, row.Par),
+		}
+		names[row.PlayerID.Int64] = row.PlayerName.String
+	}
+	return rounds, names, nil
+}
@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ericrabun/findfore-go/internal/games"
+	"github.com/ericrabun/findfore-go/internal/league"
+	"github.com/ericrabun/findfore-go/internal/recurrence"
+)
+
+// CreateLeague starts a league with the commissioner as its first member.
+func CreateLeague(ctx context.Context, db *sql.DB, q *Queries, name string, commissionerID int64) (League, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return League{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	l, err := qtx.CreateLeague(ctx, CreateLeagueParams{Name: name, CommissionerID: commissionerID})
+	if err != nil {
+		return League{}, fmt.Errorf("failed to create league: %w", err)
+	}
+	if err := qtx.CreateLeagueMember(ctx, CreateLeagueMemberParams{LeagueID: l.ID, PlayerID: commissionerID}); err != nil {
+		return League{}, fmt.Errorf("failed to add commissioner: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return League{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return l, nil
+}
+
+// AddLeagueMember adds the player to the league and invites them to the
+// league's events still to be played.
+func AddLeagueMember(ctx context.Context, db *sql.DB, q *Queries, leagueID, playerID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.CreateLeagueMember(ctx, CreateLeagueMemberParams{LeagueID: leagueID, PlayerID: playerID}); err != nil {
+		return fmt.Errorf("failed to add league member: %w", err)
+	}
+	if err := qtx.InviteToLeagueEvents(ctx, InviteToLeagueEventsParams{PlayerID: playerID, LeagueID: leagueID}); err != nil {
+		return fmt.Errorf("failed to invite league member: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RemoveLeagueMember removes the player from the league and withdraws their
+// unanswered invitations to the league's events still to be played. Rounds
+// they've accepted are left alone. It returns sql.ErrNoRows when the player
+// isn't a member.
+func RemoveLeagueMember(ctx context.Context, db *sql.DB, q *Queries, leagueID, playerID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	n, err := qtx.DeleteLeagueMember(ctx, DeleteLeagueMemberParams{LeagueID: leagueID, PlayerID: playerID})
+	if err != nil {
+		return fmt.Errorf("failed to remove league member: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	if err := qtx.DeleteLeagueEventInvitations(ctx, DeleteLeagueEventInvitationsParams{PlayerID: playerID, LeagueID: leagueID}); err != nil {
+		return fmt.Errorf("failed to withdraw league invitations: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SeasonDates is the schedule for a season's rule, which must end by COUNT
+// or UNTIL. Schedules are cut off a year after the start.
+func SeasonDates(startsOn time.Time, rule recurrence.Rule) []time.Time {
+	return rule.Occurrences(startsOn, startsOn, startsOn.AddDate(1, 0, 0), nil)
+}
+
+// CreateLeagueSeason saves the season and generates an event for each date
+// on its schedule in one transaction. The commissioner hosts, and every
+// member is invited.
+func CreateLeagueSeason(ctx context.Context, db *sql.DB, q *Queries, params CreateLeagueSeasonParams) (LeagueSeason, error) {
+	rule, err := recurrence.Parse(params.Rrule)
+	if err != nil {
+		return LeagueSeason{}, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return LeagueSeason{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	l, err := qtx.GetLeague(ctx, params.LeagueID)
+	if err != nil {
+		return LeagueSeason{}, err
+	}
+	members, err := qtx.ListLeagueMemberIDs(ctx, params.LeagueID)
+	if err != nil {
+		return LeagueSeason{}, fmt.Errorf("failed to list league members: %w", err)
+	}
+
+	season, err := qtx.CreateLeagueSeason(ctx, params)
+	if err != nil {
+		return LeagueSeason{}, fmt.Errorf("failed to create season: %w", err)
+	}
+
+	for _, date := range SeasonDates(params.StartsOn, rule) {
+		if _, err := createEventWithInvites(ctx, qtx, CreateEventWithInvitesParams{
+			CourseID:       int32(params.CourseID),
+			Date:           date.Format(occurrenceDateLayout),
+			TeeTime:        params.TeeTime,
+			OpenSpots:      params.OpenSpots,
+			NumberOfHoles:  params.NumberOfHoles,
+			Private:        true,
+			HostID:         int32(l.CommissionerID),
+			Invitees:       members,
+			LeagueSeasonID: season.ID,
+		}); err != nil {
+			return LeagueSeason{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return LeagueSeason{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return season, nil
+}
+
+// SeasonRound is one completed round of a season.
+type SeasonRound struct {
+	EventID int64
+	Date    string
+	Results []league.Result
+}
+
+// SeasonRules is the season's points rules.
+func SeasonRules(season LeagueSeason) league.Rules {
+	rules := league.Rules{
+		Net:              season.Net,
+		FinishPoints:     make([]float64, len(season.FinishPoints)),
+		MatchWinPoints:   float64(season.MatchWinPoints),
+		MatchHalvePoints: float64(season.MatchHalvePoints),
+	}
+	for i, p := range season.FinishPoints {
+		rules.FinishPoints[i] = float64(p)
+	}
+	return rules
+}
+
+// SeasonStandings scores each completed round of the season from its
+// scorecards and totals the members' points. Only current members are
+// counted.
+func SeasonStandings(ctx context.Context, q *Queries, season LeagueSeason, members []int64) ([]SeasonRound, []league.Standing, error) {
+	events, err := q.ListLeagueSeasonEvents(ctx, []int64{season.ID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch season events: %w", err)
+	}
+
+	rules := SeasonRules(season)
+	var rounds []SeasonRound
+	var results [][]league.Result
+	for _, e := range events {
+		if e.Status != 2 { // completed
+			continue
+		}
+		cards, err := loadCards(ctx, q, e.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		var memberCards []games.Card
+		for _, id := range members {
+			if c, ok := cards[id]; ok {
+				memberCards = append(memberCards, c)
+			}
+		}
+		round := league.Round(rules, int(HoleCount(e.NumberOfHoles.String)), memberCards)
+		rounds = append(rounds, SeasonRound{EventID: e.ID, Date: e.Date.String, Results: round})
+		results = append(results, round)
+	}
+	return rounds, league.Standings(members, results), nil
+}
@@ -6,35 +6,167 @@ package store
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
 type Course struct {
+	ID          int64
+	Name        sql.NullString
+	Street      sql.NullString
+	City        sql.NullString
+	State       sql.NullString
+	ZipCode     sql.NullString
+	Phone       sql.NullString
+	Cost        sql.NullString
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	FeeCents    sql.NullInt32
+	FeeCurrency string
+	Latitude    sql.NullFloat64
+	Longitude   sql.NullFloat64
+	TimeZone    string
+}
+
+type Event struct {
+	ID             int64
+	CourseID       sql.NullInt32
+	Date           sql.NullString
+	TeeTime        sql.NullString
+	OpenSpots      sql.NullInt32
+	NumberOfHoles  sql.NullString
+	Private        sql.NullBool
+	HostID         sql.NullInt32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SeriesID       sql.NullInt64
+	OccurrenceDate sql.NullTime
+	StartsAt       sql.NullTime
+	Sequence       int32
+	CancelledAt    sql.NullTime
+	RespondBy      sql.NullTime
+	Status         int32
+	FeeCents       sql.NullInt32
+	FeeCurrency    string
+	LeagueSeasonID sql.NullInt64
+}
+
+type EventCohost struct {
 	ID        int64
-	Name      sql.NullString
-	Street    sql.NullString
-	City      sql.NullString
-	State     sql.NullString
-	ZipCode   sql.NullString
-	Phone     sql.NullString
-	Cost      sql.NullString
+	EventID   int64
+	PlayerID  int64
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
-type Event struct {
+type EventExpense struct {
+	ID          int64
+	EventID     int64
+	PayerID     int64
+	AmountCents int32
+	Currency    string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type EventGame struct {
+	ID         int64
+	EventID    int64
+	Format     int32
+	Net        bool
+	StakeCents int32
+	Carryover  bool
+	SettledAt  sql.NullTime
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type EventGamePlayer struct {
+	ID        int64
+	GameID    int64
+	PlayerID  int64
+	CreatedAt time.Time
+}
+
+type EventGroup struct {
+	ID        int64
+	EventID   int64
+	TeeTime   string
+	Position  int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type EventGroupMember struct {
+	ID        int64
+	EventID   int64
+	GroupID   int64
+	PlayerID  int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type EventGuest struct {
+	ID        int64
+	EventID   int64
+	SponsorID int64
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type EventInviteLink struct {
+	ID        int64
+	EventID   int64
+	CreatedBy int64
+	ExpiresAt sql.NullTime
+	MaxUses   sql.NullInt32
+	Uses      int32
+	RevokedAt sql.NullTime
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type EventSeries struct {
 	ID            int64
-	CourseID      sql.NullInt32
-	Date          sql.NullString
-	TeeTime       sql.NullString
-	OpenSpots     sql.NullInt32
-	NumberOfHoles sql.NullString
-	Private       sql.NullBool
-	HostID        sql.NullInt32
+	HostID        int64
+	CourseID      int64
+	StartsOn      time.Time
+	TeeTime       string
+	OpenSpots     int32
+	NumberOfHoles string
+	Private       bool
+	Rrule         string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
 
+type EventSeriesException struct {
+	ID             int64
+	SeriesID       int64
+	OccurrenceDate time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type EventSeriesInvitee struct {
+	ID        int64
+	SeriesID  int64
+	PlayerID  int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type EventSeriesRsvp struct {
+	ID           int64
+	SeriesID     int64
+	PlayerID     int64
+	InviteStatus int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
 type Friendship struct {
 	ID         int64
 	FollowerID sql.NullInt32
@@ -43,17 +175,108 @@ type Friendship struct {
 	UpdatedAt  time.Time
 }
 
-type Player struct {
+type GamePayout struct {
+	ID          int64
+	GameID      int64
+	PayerID     int64
+	PayeeID     int64
+	AmountCents int32
+	Currency    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type HandicapRevision struct {
+	ID                 int64
+	PlayerID           int64
+	ScorecardID        int64
+	PlayedAt           time.Time
+	AdjustedGrossScore int32
+	ScoreDifferential  float64
+	HandicapIndex      sql.NullFloat64
+	LowHandicapIndex   sql.NullFloat64
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type League struct {
 	ID             int64
-	Name           sql.NullString
-	Phone          sql.NullString
-	Email          sql.NullString
-	Username       sql.NullString
-	PasswordDigest sql.NullString
+	Name           string
+	CommissionerID int64
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
 
+type LeagueMember struct {
+	ID        int64
+	LeagueID  int64
+	PlayerID  int64
+	CreatedAt time.Time
+}
+
+type LeagueSeason struct {
+	ID               int64
+	LeagueID         int64
+	Name             string
+	CourseID         int64
+	StartsOn         time.Time
+	TeeTime          string
+	OpenSpots        int32
+	NumberOfHoles    string
+	Rrule            string
+	Net              bool
+	FinishPoints     []int32
+	MatchWinPoints   int32
+	MatchHalvePoints int32
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type Notification struct {
+	ID        int64
+	PlayerID  int64
+	EventID   sql.NullInt64
+	Kind      string
+	Message   string
+	ReadAt    sql.NullTime
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type PairingPreference struct {
+	ID            int64
+	PlayerID      int64
+	OtherPlayerID int64
+	Preference    int32
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type Player struct {
+	ID               int64
+	Name             sql.NullString
+	Phone            sql.NullString
+	Email            sql.NullString
+	Username         sql.NullString
+	PasswordDigest   sql.NullString
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	CalendarToken    sql.NullString
+	HandicapIndex    sql.NullFloat64
+	Admin            bool
+	ScoresVisibility int32
+	HomeCourseID     sql.NullInt32
+}
+
+type PlayerAvailability struct {
+	ID          int64
+	PlayerID    int64
+	DayOfWeek   int32
+	StartMinute int32
+	EndMinute   int32
+	CreatedAt   time.Time
+}
+
 type PlayerEvent struct {
 	ID           int64
 	PlayerID     sql.NullInt64
@@ -61,6 +284,22 @@ type PlayerEvent struct {
 	InviteStatus sql.NullInt32
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	RemindedAt   sql.NullTime
+}
+
+type PlayerPreferredCourse struct {
+	ID        int64
+	PlayerID  int64
+	CourseID  int64
+	CreatedAt time.Time
+}
+
+type PlayerStat struct {
+	ID        int64
+	PlayerID  int64
+	Filter    string
+	Summary   json.RawMessage
+	CreatedAt time.Time
 }
 
 type Post struct {
@@ -69,6 +308,7 @@ type Post struct {
 	Body      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	EventID   sql.NullInt64
 }
 
 type Reaction struct {
@@ -88,3 +328,94 @@ type Reply struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+type Scorecard struct {
+	ID            int64
+	PlayerEventID int64
+	EnteredBy     int64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	TeeSetID      sql.NullInt64
+}
+
+type ScorecardHole struct {
+	ID                int64
+	ScorecardID       int64
+	HoleNumber        int32
+	Strokes           int32
+	Putts             sql.NullInt32
+	FairwayHit        sql.NullBool
+	GreenInRegulation sql.NullBool
+	Par               sql.NullInt32
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type Settlement struct {
+	ID          int64
+	PayerID     int64
+	PayeeID     int64
+	AmountCents int32
+	Currency    string
+	Note        sql.NullString
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type TeeSet struct {
+	ID           int64
+	CourseID     int64
+	Name         string
+	Color        sql.NullString
+	CourseRating float64
+	SlopeRating  int32
+	Yardage      sql.NullInt32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type TeeSetHole struct {
+	ID          int64
+	TeeSetID    int64
+	HoleNumber  int32
+	Par         int32
+	StrokeIndex int32
+	Yardage     sql.NullInt32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Tournament struct {
+	ID            int64
+	Name          string
+	HostID        int64
+	CourseID      int64
+	NumberOfHoles string
+	Net           bool
+	Status        int32
+	WinnerID      sql.NullInt64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type TournamentMatch struct {
+	ID           int64
+	TournamentID int64
+	Round        int32
+	Position     int32
+	Player1ID    sql.NullInt64
+	Player2ID    sql.NullInt64
+	EventID      sql.NullInt64
+	WinnerID     sql.NullInt64
+	Result       sql.NullInt32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type TournamentPlayer struct {
+	ID           int64
+	TournamentID int64
+	PlayerID     int64
+	Seed         sql.NullInt32
+	CreatedAt    time.Time
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const NotificationKindRsvpReminder = "rsvp_reminder"
+
+// SendRsvpReminders notifies pending invitees whose event's respond by
+// deadline falls within lead of now. Each invitation is reminded at most
+// once, and rows being reminded by another instance are skipped.
+func SendRsvpReminders(ctx context.Context, db *sql.DB, q *Queries, now time.Time, lead time.Duration) (int, error) {
+	// respond_by is stored as UTC wall clock time.
+	now = now.UTC()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	due, err := qtx.ListInvitationsDueForReminder(ctx, ListInvitationsDueForReminderParams{
+		Now:          now,
+		RemindBefore: now.Add(lead),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list invitations due for reminder: %w", err)
+	}
+
+	for _, inv := range due {
+		_, err := qtx.CreateNotification(ctx, CreateNotificationParams{
+			PlayerID: inv.PlayerID.Int64,
+			EventID:  inv.EventID,
+			Kind:     NotificationKindRsvpReminder,
+			Message: fmt.Sprintf("Please respond to your invitation to golf at %s by %s UTC",
+				inv.CourseName.String, inv.RespondBy.Time.Format("Jan 2 15:04")),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to create notification: %w", err)
+		}
+		if err := qtx.MarkInvitationReminded(ctx, inv.ID); err != nil {
+			return 0, fmt.Errorf("failed to mark invitation reminded: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return len(due), nil
+}
@@ -0,0 +1,300 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrEventFull      = errors.New("event is full")
+	ErrAlreadyJoined  = errors.New("player is already part of this event")
+	ErrEventCancelled = errors.New("event is cancelled")
+	ErrRsvpClosed     = errors.New("the respond by deadline has passed")
+	ErrEventCompleted = errors.New("event has already been played")
+)
+
+// ScheduleConflictError is returned when accepting an event would overlap
+// another round the player has already accepted.
+type ScheduleConflictError struct {
+	EventID int64
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("overlaps accepted event %d", e.EventID)
+}
+
+// checkScheduleConflict returns a *ScheduleConflictError when the player has
+// accepted another event that overlaps this one. Rounds are assumed to last
+// EstimatedDuration. The player is locked first so two events can't both be
+// accepted at once without seeing each other.
+func checkScheduleConflict(ctx context.Context, qtx *Queries, playerID int64, event LockEventForUpdateRow) error {
+	if !event.StartsAt.Valid {
+		return nil
+	}
+	if _, err := qtx.LockPlayerForUpdate(ctx, playerID); err != nil {
+		return fmt.Errorf("failed to lock player: %w", err)
+	}
+	conflictID, err := qtx.FindConflictingAcceptedEvent(ctx, FindConflictingAcceptedEventParams{
+		PlayerID: playerID,
+		EventID:  event.ID,
+		StartsAt: event.StartsAt.Time,
+		EndsAt:   event.StartsAt.Time.Add(EstimatedDuration(event.NumberOfHoles.String)),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check schedule conflicts: %w", err)
+	}
+	return &ScheduleConflictError{EventID: conflictID}
+}
+
+// rsvpClosed reports whether the event's respond by deadline has passed.
+// Declining is still allowed after the deadline; accepting is not.
+func rsvpClosed(respondBy sql.NullTime, now time.Time) bool {
+	return respondBy.Valid && !now.Before(respondBy.Time)
+}
+
+type JoinEventParams struct {
+	PlayerID int64
+	EventID  int64
+
+	// AllowConflicts skips the double-booking check.
+	AllowConflicts bool
+}
+
+// JoinEvent adds the player to the event as accepted. The event row is locked
+// for the duration of the transaction so concurrent joins and RSVP changes on
+// the same event are serialized and can't overbook it. Like
+// UpdateInviteStatus, it refuses to double-book the player unless
+// AllowConflicts is set.
+func JoinEvent(ctx context.Context, db *sql.DB, q *Queries, params JoinEventParams) (CreatePlayerEventRow, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return CreatePlayerEventRow{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	pe, err := joinEvent(ctx, q.WithTx(tx), params)
+	if err != nil {
+		return CreatePlayerEventRow{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return CreatePlayerEventRow{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return pe, nil
+}
+
+// joinEvent is JoinEvent within the caller's transaction.
+func joinEvent(ctx context.Context, qtx *Queries, params JoinEventParams) (CreatePlayerEventRow, error) {
+	event, err := qtx.LockEventForUpdate(ctx, params.EventID)
+	if err != nil {
+		return CreatePlayerEventRow{}, err
+	}
+	if event.CancelledAt.Valid {
+		return CreatePlayerEventRow{}, ErrEventCancelled
+	}
+	if event.Status == 2 { // completed
+		return CreatePlayerEventRow{}, ErrEventCompleted
+	}
+	if rsvpClosed(event.RespondBy, time.Now()) {
+		return CreatePlayerEventRow{}, ErrRsvpClosed
+	}
+
+	_, err = qtx.GetPlayerEvent(ctx, GetPlayerEventParams{
+		PlayerID: sql.NullInt64{Int64: params.PlayerID, Valid: true},
+		EventID:  sql.NullInt64{Int64: params.EventID, Valid: true},
+	})
+	if err == nil {
+		return CreatePlayerEventRow{}, ErrAlreadyJoined
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return CreatePlayerEventRow{}, fmt.Errorf("failed to check player_event: %w", err)
+	}
+
+	filledSpots, err := qtx.CountFilledSpotsForEvent(ctx, params.EventID)
+	if err != nil {
+		return CreatePlayerEventRow{}, fmt.Errorf("failed to count filled spots: %w", err)
+	}
+	if event.OpenSpots.Int32-int32(filledSpots) <= 0 {
+		return CreatePlayerEventRow{}, ErrEventFull
+	}
+	if !params.AllowConflicts {
+		if err := checkScheduleConflict(ctx, qtx, params.PlayerID, event); err != nil {
+			return CreatePlayerEventRow{}, err
+		}
+	}
+
+	pe, err := qtx.CreatePlayerEvent(ctx, CreatePlayerEventParams{
+		PlayerID:     sql.NullInt64{Int64: params.PlayerID, Valid: true},
+		EventID:      sql.NullInt64{Int64: params.EventID, Valid: true},
+		InviteStatus: sql.NullInt32{Int32: 1, Valid: true}, // accepted
+	})
+	if err != nil {
+		return CreatePlayerEventRow{}, fmt.Errorf("failed to create player_event: %w", err)
+	}
+
+	if err := closeOrOpenInvitations(ctx, qtx, params.EventID, event.OpenSpots.Int32); err != nil {
+		return CreatePlayerEventRow{}, err
+	}
+
+	return pe, nil
+}
+
+type UpdateInviteStatusParams struct {
+	PlayerID     int64
+	EventID      int64
+	InviteStatus int32
+
+	// AllowConflicts skips the double-booking check when accepting.
+	AllowConflicts bool
+}
+
+// UpdateInviteStatus changes a player's RSVP under the same event lock as
+// JoinEvent. Accepting is rejected with ErrEventFull when no spots remain, or
+// ErrRsvpClosed once the respond by deadline has passed, unless the player
+// was already counted as accepted. It is also rejected with a
+// *ScheduleConflictError when it would double-book the player, unless
+// AllowConflicts is set. On public events a
+// player without a player_events row may accept or decline, which creates
+// the row.
+func UpdateInviteStatus(ctx context.Context, db *sql.DB, q *Queries, params UpdateInviteStatusParams) (UpdatePlayerEventStatusRow, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	pe, err := updateInviteStatus(ctx, q.WithTx(tx), params)
+	if err != nil {
+		return UpdatePlayerEventStatusRow{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return pe, nil
+}
+
+// updateInviteStatus is UpdateInviteStatus within the caller's transaction.
+func updateInviteStatus(ctx context.Context, qtx *Queries, params UpdateInviteStatusParams) (UpdatePlayerEventStatusRow, error) {
+	event, err := qtx.LockEventForUpdate(ctx, params.EventID)
+	if err != nil {
+		return UpdatePlayerEventStatusRow{}, err
+	}
+	if event.CancelledAt.Valid {
+		return UpdatePlayerEventStatusRow{}, ErrEventCancelled
+	}
+	if event.Status == 2 { // completed
+		return UpdatePlayerEventStatusRow{}, ErrEventCompleted
+	}
+
+	current, err := qtx.GetPlayerEvent(ctx, GetPlayerEventParams{
+		PlayerID: sql.NullInt64{Int64: params.PlayerID, Valid: true},
+		EventID:  sql.NullInt64{Int64: params.EventID, Valid: true},
+	})
+	hasRow := err == nil
+	if errors.Is(err, sql.ErrNoRows) {
+		// Only public events can be answered without an invitation, and only
+		// with accepted (1) or declined (2).
+		if event.Private.Bool || (params.InviteStatus != 1 && params.InviteStatus != 2) {
+			return UpdatePlayerEventStatusRow{}, err
+		}
+	} else if err != nil {
+		return UpdatePlayerEventStatusRow{}, err
+	}
+
+	if params.InviteStatus == 1 && (!hasRow || current.InviteStatus.Int32 != 1) {
+		if rsvpClosed(event.RespondBy, time.Now()) {
+			return UpdatePlayerEventStatusRow{}, ErrRsvpClosed
+		}
+		filledSpots, err := qtx.CountFilledSpotsForEvent(ctx, params.EventID)
+		if err != nil {
+			return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to count filled spots: %w", err)
+		}
+		if event.OpenSpots.Int32-int32(filledSpots) <= 0 {
+			return UpdatePlayerEventStatusRow{}, ErrEventFull
+		}
+		if !params.AllowConflicts {
+			if err := checkScheduleConflict(ctx, qtx, params.PlayerID, event); err != nil {
+				return UpdatePlayerEventStatusRow{}, err
+			}
+		}
+	}
+
+	var pe UpdatePlayerEventStatusRow
+	if hasRow {
+		pe, err = qtx.UpdatePlayerEventStatus(ctx, UpdatePlayerEventStatusParams{
+			PlayerID:     sql.NullInt64{Int64: params.PlayerID, Valid: true},
+			EventID:      sql.NullInt64{Int64: params.EventID, Valid: true},
+			InviteStatus: sql.NullInt32{Int32: params.InviteStatus, Valid: true},
+		})
+	} else {
+		var created CreatePlayerEventRow
+		created, err = qtx.CreatePlayerEvent(ctx, CreatePlayerEventParams{
+			PlayerID:     sql.NullInt64{Int64: params.PlayerID, Valid: true},
+			EventID:      sql.NullInt64{Int64: params.EventID, Valid: true},
+			InviteStatus: sql.NullInt32{Int32: params.InviteStatus, Valid: true},
+		})
+		pe = UpdatePlayerEventStatusRow(created)
+	}
+	if err != nil {
+		return UpdatePlayerEventStatusRow{}, err
+	}
+
+	// Guests come with their sponsor, so they give up their spots too, and
+	// only players in the round can co-host it or hold a place in a group.
+	if params.InviteStatus != 1 {
+		if err := qtx.DeleteEventGuestsBySponsor(ctx, DeleteEventGuestsBySponsorParams{
+			EventID:   params.EventID,
+			SponsorID: params.PlayerID,
+		}); err != nil {
+			return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to remove sponsored guests: %w", err)
+		}
+		if _, err := qtx.DeleteEventCohost(ctx, DeleteEventCohostParams{
+			EventID:  params.EventID,
+			PlayerID: params.PlayerID,
+		}); err != nil {
+			return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to remove co-host: %w", err)
+		}
+		if err := qtx.DeleteEventGroupMember(ctx, DeleteEventGroupMemberParams{
+			EventID:  params.EventID,
+			PlayerID: params.PlayerID,
+		}); err != nil {
+			return UpdatePlayerEventStatusRow{}, fmt.Errorf("failed to remove group member: %w", err)
+		}
+	}
+
+	if err := closeOrOpenInvitations(ctx, qtx, params.EventID, event.OpenSpots.Int32); err != nil {
+		return UpdatePlayerEventStatusRow{}, err
+	}
+
+	return pe, nil
+}
+
+// closeOrOpenInvitations closes pending invitations once the event is full and
+// reopens closed ones when a spot frees up. Callers must hold the event lock.
+func closeOrOpenInvitations(ctx context.Context, qtx *Queries, eventID int64, openSpots int32) error {
+	filledSpots, err := qtx.CountFilledSpotsForEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to count filled spots: %w", err)
+	}
+
+	if openSpots-int32(filledSpots) <= 0 {
+		if err := qtx.ClosePendingForEvent(ctx, sql.NullInt64{Int64: eventID, Valid: true}); err != nil {
+			return fmt.Errorf("failed to close pending invitations: %w", err)
+		}
+		return nil
+	}
+
+	if err := qtx.ReopenClosedForEvent(ctx, sql.NullInt64{Int64: eventID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to reopen closed invitations: %w", err)
+	}
+	return nil
+}
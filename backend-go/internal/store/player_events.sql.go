@@ -8,6 +8,9 @@ package store
 import (
 	"context"
 	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 const closePendingForEvent = `-- name: ClosePendingForEvent :exec
@@ -21,16 +24,17 @@ func (q *Queries) ClosePendingForEvent(ctx context.Context, eventID sql.NullInt6
 	return err
 }
 
-const countAcceptedForEvent = `-- name: CountAcceptedForEvent :one
-SELECT COUNT(*) FROM player_events
-WHERE event_id = $1 AND invite_status = 1
+const countFilledSpotsForEvent = `-- name: CountFilledSpotsForEvent :one
+SELECT (SELECT COUNT(*) FROM player_events pe WHERE pe.event_id = $1::bigint AND pe.invite_status = 1)
+     + (SELECT COUNT(*) FROM event_guests g WHERE g.event_id = $1::bigint) AS filled_spots
 `
 
-func (q *Queries) CountAcceptedForEvent(ctx context.Context, eventID sql.NullInt64) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countAcceptedForEvent, eventID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+// Accepted players plus guests, which take a spot without a player_events row.
+func (q *Queries) CountFilledSpotsForEvent(ctx context.Context, eventID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countFilledSpotsForEvent, eventID)
+	var filled_spots int64
+	err := row.Scan(&filled_spots)
+	return filled_spots, err
 }
 
 const createPlayerEvent = `-- name: CreatePlayerEvent :one
@@ -64,6 +68,57 @@ func (q *Queries) CreatePlayerEvent(ctx context.Context, arg CreatePlayerEventPa
 	return i, err
 }
 
+const expirePendingInvitations = `-- name: ExpirePendingInvitations :execrows
+UPDATE player_events pe
+SET invite_status = 4, updated_at = NOW()
+FROM events e
+WHERE pe.event_id = e.id
+  AND pe.invite_status = 0
+  AND e.respond_by IS NOT NULL
+  AND e.respond_by <= $1::timestamp
+`
+
+func (q *Queries) ExpirePendingInvitations(ctx context.Context, now time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, expirePendingInvitations, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const findConflictingAcceptedEvent = `-- name: FindConflictingAcceptedEvent :one
+SELECT e.id
+FROM events e
+JOIN player_events pe ON pe.event_id = e.id AND pe.invite_status = 1
+WHERE pe.player_id = $1::bigint
+  AND e.id <> $2::bigint
+  AND e.status IN (0, 1)
+  AND e.starts_at < $3::timestamp
+  AND e.starts_at + CASE WHEN e.number_of_holes = '9' THEN INTERVAL '2 hours 15 minutes' ELSE INTERVAL '4 hours 30 minutes' END > $4::timestamp
+ORDER BY e.starts_at, e.id
+LIMIT 1
+`
+
+type FindConflictingAcceptedEventParams struct {
+	PlayerID int64
+	EventID  int64
+	EndsAt   time.Time
+	StartsAt time.Time
+}
+
+// The interval matches EstimatedDuration.
+func (q *Queries) FindConflictingAcceptedEvent(ctx context.Context, arg FindConflictingAcceptedEventParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, findConflictingAcceptedEvent,
+		arg.PlayerID,
+		arg.EventID,
+		arg.EndsAt,
+		arg.StartsAt,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getPlayerEvent = `-- name: GetPlayerEvent :one
 SELECT id, player_id, event_id, invite_status
 FROM player_events
@@ -94,6 +149,30 @@ func (q *Queries) GetPlayerEvent(ctx context.Context, arg GetPlayerEventParams)
 	return i, err
 }
 
+const isEventParticipant = `-- name: IsEventParticipant :one
+SELECT EXISTS (
+  SELECT 1 FROM events e
+  WHERE e.id = $1::bigint AND e.host_id = $2::bigint
+) OR EXISTS (
+  SELECT 1 FROM player_events pe
+  WHERE pe.event_id = $1::bigint AND pe.player_id = $2::bigint
+) AS is_participant
+`
+
+type IsEventParticipantParams struct {
+	EventID  int64
+	PlayerID int64
+}
+
+// The host and anyone invited, whatever their RSVP. Co-hosts are always
+// accepted players so they're covered by player_events.
+func (q *Queries) IsEventParticipant(ctx context.Context, arg IsEventParticipantParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isEventParticipant, arg.EventID, arg.PlayerID)
+	var is_participant bool
+	err := row.Scan(&is_participant)
+	return is_participant, err
+}
+
 const listAcceptedEventIDsByPlayerID = `-- name: ListAcceptedEventIDsByPlayerID :many
 SELECT event_id
 FROM player_events
@@ -123,30 +202,52 @@ func (q *Queries) ListAcceptedEventIDsByPlayerID(ctx context.Context, playerID s
 	return items, nil
 }
 
-const listPlayerIDsByEventAndStatus = `-- name: ListPlayerIDsByEventAndStatus :many
-SELECT player_id
-FROM player_events
-WHERE event_id = $1 AND invite_status = $2
+const listInvitationsDueForReminder = `-- name: ListInvitationsDueForReminder :many
+SELECT pe.id, pe.player_id, pe.event_id, e.respond_by, c.name AS course_name
+FROM player_events pe
+JOIN events e ON e.id = pe.event_id
+JOIN courses c ON c.id = e.course_id
+WHERE pe.invite_status = 0
+  AND pe.reminded_at IS NULL
+  AND e.cancelled_at IS NULL
+  AND e.respond_by > $1::timestamp
+  AND e.respond_by <= $2::timestamp
+ORDER BY pe.id
+FOR UPDATE OF pe SKIP LOCKED
 `
 
-type ListPlayerIDsByEventAndStatusParams struct {
-	EventID      sql.NullInt64
-	InviteStatus sql.NullInt32
+type ListInvitationsDueForReminderParams struct {
+	Now          time.Time
+	RemindBefore time.Time
 }
 
-func (q *Queries) ListPlayerIDsByEventAndStatus(ctx context.Context, arg ListPlayerIDsByEventAndStatusParams) ([]sql.NullInt64, error) {
-	rows, err := q.db.QueryContext(ctx, listPlayerIDsByEventAndStatus, arg.EventID, arg.InviteStatus)
+type ListInvitationsDueForReminderRow struct {
+	ID         int64
+	PlayerID   sql.NullInt64
+	EventID    sql.NullInt64
+	RespondBy  sql.NullTime
+	CourseName sql.NullString
+}
+
+func (q *Queries) ListInvitationsDueForReminder(ctx context.Context, arg ListInvitationsDueForReminderParams) ([]ListInvitationsDueForReminderRow, error) {
+	rows, err := q.db.QueryContext(ctx, listInvitationsDueForReminder, arg.Now, arg.RemindBefore)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []sql.NullInt64
+	var items []ListInvitationsDueForReminderRow
 	for rows.Next() {
-		var player_id sql.NullInt64
-		if err := rows.Scan(&player_id); err != nil {
+		var i ListInvitationsDueForReminderRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PlayerID,
+			&i.EventID,
+			&i.RespondBy,
+			&i.CourseName,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, player_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -157,23 +258,32 @@ func (q *Queries) ListPlayerIDsByEventAndStatus(ctx context.Context, arg ListPla
 	return items, nil
 }
 
-const listPlayersExceptHost = `-- name: ListPlayersExceptHost :many
-SELECT id FROM players WHERE id != $1
+const listInvitesByEventIDs = `-- name: ListInvitesByEventIDs :many
+SELECT event_id, invite_status, array_agg(player_id ORDER BY id)::bigint[] AS player_ids
+FROM player_events
+WHERE event_id = ANY($1::bigint[]) AND player_id IS NOT NULL
+GROUP BY event_id, invite_status
 `
 
-func (q *Queries) ListPlayersExceptHost(ctx context.Context, id int64) ([]int64, error) {
-	rows, err := q.db.QueryContext(ctx, listPlayersExceptHost, id)
+type ListInvitesByEventIDsRow struct {
+	EventID      sql.NullInt64
+	InviteStatus sql.NullInt32
+	PlayerIds    []int64
+}
+
+func (q *Queries) ListInvitesByEventIDs(ctx context.Context, eventIds []int64) ([]ListInvitesByEventIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listInvitesByEventIDs, pq.Array(eventIds))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []int64
+	var items []ListInvitesByEventIDsRow
 	for rows.Next() {
-		var id int64
-		if err := rows.Scan(&id); err != nil {
+		var i ListInvitesByEventIDsRow
+		if err := rows.Scan(&i.EventID, &i.InviteStatus, pq.Array(&i.PlayerIds)); err != nil {
 			return nil, err
 		}
-		items = append(items, id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -184,6 +294,17 @@ func (q *Queries) ListPlayersExceptHost(ctx context.Context, id int64) ([]int64,
 	return items, nil
 }
 
+const markInvitationReminded = `-- name: MarkInvitationReminded :exec
+UPDATE player_events
+SET reminded_at = NOW(), updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkInvitationReminded(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, markInvitationReminded, id)
+	return err
+}
+
 const reopenClosedForEvent = `-- name: ReopenClosedForEvent :exec
 UPDATE player_events
 SET invite_status = 0, updated_at = NOW()
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ericrabun/findfore-go/internal/stats"
+)
+
+// statsFilter is the cache key for the filtered stats.
+func statsFilter(params ListPlayerStatRoundsParams) string {
+	format := func(t sql.NullTime) string {
+		if !t.Valid {
+			return ""
+		}
+		return t.Time.Format(time.RFC3339)
+	}
+	courseID := ""
+	if params.CourseID.Valid {
+		courseID = fmt.Sprint(params.CourseID.Int32)
+	}
+	return fmt.Sprintf("from=%s&before=%s&course_id=%s", format(params.PlayedFrom), format(params.PlayedBefore), courseID)
+}
+
+// PlayerStats summarizes the player's complete rounds matching the filters.
+// Summaries are cached per player and filter until one of the player's
+// scorecards changes. Returns sql.ErrNoRows if the player doesn't exist.
+func PlayerStats(ctx context.Context, db *sql.DB, q *Queries, params ListPlayerStatRoundsParams) (stats.Summary, error) {
+	filter := statsFilter(params)
+	var summary stats.Summary
+
+	cached, err := q.GetPlayerStats(ctx, GetPlayerStatsParams{PlayerID: params.PlayerID, Filter: filter})
+	if err == nil {
+		if err := json.Unmarshal(cached, &summary); err == nil {
+			return summary, nil
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return stats.Summary{}, fmt.Errorf("failed to fetch cached stats: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return stats.Summary{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	// Saving a scorecard locks the player too, so the summary can't be
+	// cached from scores that are being replaced.
+	if _, err := qtx.LockPlayerForUpdate(ctx, params.PlayerID); err != nil {
+		return stats.Summary{}, err
+	}
+	rows, err := qtx.ListPlayerStatRounds(ctx, params)
+	if err != nil {
+		return stats.Summary{}, fmt.Errorf("failed to fetch rounds: %w", err)
+	}
+	rounds := make([]stats.Round, len(rows))
+	for i, row := range rows {
+		rounds[i] = stats.Round{
+			EventID:      row.EventID,
+			CourseID:     int64(row.CourseID.Int32),
+			PlayedAt:     row.PlayedAt,
+			Holes:        int(row.Holes),
+			Strokes:      int(row.Strokes),
+			Putts:        int(row.Putts),
+			PuttHoles:    int(row.PuttHoles),
+			FairwaysHit:  int(row.FairwaysHit),
+			FairwayHoles: int(row.FairwayHoles),
+			GreensHit:    int(row.GreensHit),
+			GreenHoles:   int(row.GreenHoles),
+			ByPar: map[int]stats.Total{
+				3: {Strokes: int(row.Par3Strokes), Holes: int(row.Par3Holes)},
+				4: {Strokes: int(row.Par4Strokes), Holes: int(row.Par4Holes)},
+				5: {Strokes: int(row.Par5Strokes), Holes: int(row.Par5Holes)},
+			},
+		}
+		if row.Par.Valid {
+			par := int(row.Par.Int32)
+			rounds[i].Par = &par
+		}
+	}
+	summary = stats.Summarize(rounds)
+
+	encoded, err := json.Marshal(summary)
+	if err != nil {
+		return stats.Summary{}, fmt.Errorf("failed to encode stats: %w", err)
+	}
+	if err := qtx.SavePlayerStats(ctx, SavePlayerStatsParams{PlayerID: params.PlayerID, Filter: filter, Summary: encoded}); err != nil {
+		return stats.Summary{}, fmt.Errorf("failed to cache stats: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return stats.Summary{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return summary, nil
+}
@@ -13,6 +13,9 @@ type PlayerWithDetails struct {
 	Username string
 	Friends  []int64
 	Events   []int64
+	// HandicapIndex is calculated from posted scores once there are enough,
+	// and self-reported until then.
+	HandicapIndex *float64
 }
 
 func GetPlayerWithDetails(ctx context.Context, q *Queries, playerID int64) (*PlayerWithDetails, error) {
@@ -41,7 +44,7 @@ func GetPlayerWithDetails(ctx context.Context, q *Queries, playerID int64) (*Pla
 		events[i] = eid.Int64
 	}
 
-	return &PlayerWithDetails{
+	details := &PlayerWithDetails{
 		ID:       player.ID,
 		Name:     player.Name.String,
 		Phone:    player.Phone.String,
@@ -49,5 +52,9 @@ func GetPlayerWithDetails(ctx context.Context, q *Queries, playerID int64) (*Pla
 		Username: player.Username.String,
 		Friends:  friends,
 		Events:   events,
-	}, nil
+	}
+	if player.HandicapIndex.Valid {
+		details.HandicapIndex = &player.HandicapIndex.Float64
+	}
+	return details, nil
 }
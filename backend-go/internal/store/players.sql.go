@@ -81,17 +81,18 @@ func (q *Queries) GetPlayerByEmail(ctx context.Context, email sql.NullString) (G
 }
 
 const getPlayerByID = `-- name: GetPlayerByID :one
-SELECT id, name, phone, email, username
+SELECT id, name, phone, email, username, handicap_index
 FROM players
 WHERE id = $1
 `
 
 type GetPlayerByIDRow struct {
-	ID       int64
-	Name     sql.NullString
-	Phone    sql.NullString
-	Email    sql.NullString
-	Username sql.NullString
+	ID            int64
+	Name          sql.NullString
+	Phone         sql.NullString
+	Email         sql.NullString
+	Username      sql.NullString
+	HandicapIndex sql.NullFloat64
 }
 
 func (q *Queries) GetPlayerByID(ctx context.Context, id int64) (GetPlayerByIDRow, error) {
@@ -103,10 +104,76 @@ func (q *Queries) GetPlayerByID(ctx context.Context, id int64) (GetPlayerByIDRow
 		&i.Phone,
 		&i.Email,
 		&i.Username,
+		&i.HandicapIndex,
 	)
 	return i, err
 }
 
+const getPlayerCalendarToken = `-- name: GetPlayerCalendarToken :one
+SELECT calendar_token
+FROM players
+WHERE id = $1
+`
+
+func (q *Queries) GetPlayerCalendarToken(ctx context.Context, id int64) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerCalendarToken, id)
+	var calendar_token sql.NullString
+	err := row.Scan(&calendar_token)
+	return calendar_token, err
+}
+
+const getPlayerHandicapIndex = `-- name: GetPlayerHandicapIndex :one
+SELECT handicap_index
+FROM players
+WHERE id = $1
+`
+
+func (q *Queries) GetPlayerHandicapIndex(ctx context.Context, id int64) (sql.NullFloat64, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerHandicapIndex, id)
+	var handicap_index sql.NullFloat64
+	err := row.Scan(&handicap_index)
+	return handicap_index, err
+}
+
+const getPlayerHomeCourse = `-- name: GetPlayerHomeCourse :one
+SELECT home_course_id
+FROM players
+WHERE id = $1
+`
+
+func (q *Queries) GetPlayerHomeCourse(ctx context.Context, id int64) (sql.NullInt32, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerHomeCourse, id)
+	var home_course_id sql.NullInt32
+	err := row.Scan(&home_course_id)
+	return home_course_id, err
+}
+
+const getPlayerScoresVisibility = `-- name: GetPlayerScoresVisibility :one
+SELECT scores_visibility
+FROM players
+WHERE id = $1
+`
+
+func (q *Queries) GetPlayerScoresVisibility(ctx context.Context, id int64) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerScoresVisibility, id)
+	var scores_visibility int32
+	err := row.Scan(&scores_visibility)
+	return scores_visibility, err
+}
+
+const isPlayerAdmin = `-- name: IsPlayerAdmin :one
+SELECT admin
+FROM players
+WHERE id = $1
+`
+
+func (q *Queries) IsPlayerAdmin(ctx context.Context, id int64) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isPlayerAdmin, id)
+	var admin bool
+	err := row.Scan(&admin)
+	return admin, err
+}
+
 const listPlayers = `-- name: ListPlayers :many
 SELECT id, name, phone, email, username
 FROM players
@@ -149,3 +216,79 @@ func (q *Queries) ListPlayers(ctx context.Context) ([]ListPlayersRow, error) {
 	}
 	return items, nil
 }
+
+const setPlayerCalendarToken = `-- name: SetPlayerCalendarToken :execrows
+UPDATE players
+SET calendar_token = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetPlayerCalendarTokenParams struct {
+	ID            int64
+	CalendarToken sql.NullString
+}
+
+func (q *Queries) SetPlayerCalendarToken(ctx context.Context, arg SetPlayerCalendarTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setPlayerCalendarToken, arg.ID, arg.CalendarToken)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setPlayerHandicapIndex = `-- name: SetPlayerHandicapIndex :execrows
+UPDATE players
+SET handicap_index = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetPlayerHandicapIndexParams struct {
+	ID            int64
+	HandicapIndex sql.NullFloat64
+}
+
+func (q *Queries) SetPlayerHandicapIndex(ctx context.Context, arg SetPlayerHandicapIndexParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setPlayerHandicapIndex, arg.ID, arg.HandicapIndex)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setPlayerHomeCourse = `-- name: SetPlayerHomeCourse :execrows
+UPDATE players
+SET home_course_id = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetPlayerHomeCourseParams struct {
+	ID           int64
+	HomeCourseID sql.NullInt32
+}
+
+func (q *Queries) SetPlayerHomeCourse(ctx context.Context, arg SetPlayerHomeCourseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setPlayerHomeCourse, arg.ID, arg.HomeCourseID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setPlayerScoresVisibility = `-- name: SetPlayerScoresVisibility :execrows
+UPDATE players
+SET scores_visibility = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetPlayerScoresVisibilityParams struct {
+	ID               int64
+	ScoresVisibility int32
+}
+
+func (q *Queries) SetPlayerScoresVisibility(ctx context.Context, arg SetPlayerScoresVisibilityParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setPlayerScoresVisibility, arg.ID, arg.ScoresVisibility)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -12,14 +12,15 @@ import (
 )
 
 const createPost = `-- name: CreatePost :one
-INSERT INTO posts (player_id, body, created_at, updated_at)
-VALUES ($1, $2, NOW(), NOW())
+INSERT INTO posts (player_id, body, event_id, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
 RETURNING id, player_id, body, created_at
 `
 
 type CreatePostParams struct {
 	PlayerID int64
 	Body     string
+	EventID  sql.NullInt64
 }
 
 type CreatePostRow struct {
@@ -30,7 +31,7 @@ type CreatePostRow struct {
 }
 
 func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (CreatePostRow, error) {
-	row := q.db.QueryRowContext(ctx, createPost, arg.PlayerID, arg.Body)
+	row := q.db.QueryRowContext(ctx, createPost, arg.PlayerID, arg.Body, arg.EventID)
 	var i CreatePostRow
 	err := row.Scan(
 		&i.ID,
@@ -56,7 +57,7 @@ func (q *Queries) DeletePost(ctx context.Context, arg DeletePostParams) error {
 }
 
 const getPostByID = `-- name: GetPostByID :one
-SELECT p.id, p.player_id, p.body, p.created_at, pl.name AS player_name
+SELECT p.id, p.player_id, p.body, p.created_at, pl.name AS player_name, p.event_id
 FROM posts p
 JOIN players pl ON pl.id = p.player_id
 WHERE p.id = $1
@@ -68,6 +69,7 @@ type GetPostByIDRow struct {
 	Body       string
 	CreatedAt  time.Time
 	PlayerName sql.NullString
+	EventID    sql.NullInt64
 }
 
 func (q *Queries) GetPostByID(ctx context.Context, id int64) (GetPostByIDRow, error) {
@@ -79,6 +81,7 @@ func (q *Queries) GetPostByID(ctx context.Context, id int64) (GetPostByIDRow, er
 		&i.Body,
 		&i.CreatedAt,
 		&i.PlayerName,
+		&i.EventID,
 	)
 	return i, err
 }
@@ -87,6 +90,7 @@ const listPosts = `-- name: ListPosts :many
 SELECT p.id, p.player_id, p.body, p.created_at, pl.name AS player_name
 FROM posts p
 JOIN players pl ON pl.id = p.player_id
+WHERE p.event_id IS NULL
 ORDER BY p.created_at DESC
 LIMIT $1 OFFSET $2
 `
@@ -104,6 +108,7 @@ type ListPostsRow struct {
 	PlayerName sql.NullString
 }
 
+// Event threads are kept out of the global feed.
 func (q *Queries) ListPosts(ctx context.Context, arg ListPostsParams) ([]ListPostsRow, error) {
 	rows, err := q.db.QueryContext(ctx, listPosts, arg.Limit, arg.Offset)
 	if err != nil {
@@ -132,3 +137,55 @@ func (q *Queries) ListPosts(ctx context.Context, arg ListPostsParams) ([]ListPos
 	}
 	return items, nil
 }
+
+const listPostsByEventID = `-- name: ListPostsByEventID :many
+SELECT p.id, p.player_id, p.body, p.created_at, pl.name AS player_name
+FROM posts p
+JOIN players pl ON pl.id = p.player_id
+WHERE p.event_id = $1
+ORDER BY p.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListPostsByEventIDParams struct {
+	EventID sql.NullInt64
+	Limit   int32
+	Offset  int32
+}
+
+type ListPostsByEventIDRow struct {
+	ID         int64
+	PlayerID   int64
+	Body       string
+	CreatedAt  time.Time
+	PlayerName sql.NullString
+}
+
+func (q *Queries) ListPostsByEventID(ctx context.Context, arg ListPostsByEventIDParams) ([]ListPostsByEventIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPostsByEventID, arg.EventID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPostsByEventIDRow
+	for rows.Next() {
+		var i ListPostsByEventIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PlayerID,
+			&i.Body,
+			&i.CreatedAt,
+			&i.PlayerName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
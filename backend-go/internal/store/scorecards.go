@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	ErrRoundNotStarted   = errors.New("scores can't be entered before the round starts")
+	ErrScorerNotAccepted = errors.New("only accepted players have scorecards")
+	ErrTeeSetNotOnCourse = errors.New("tee set isn't on the event's course")
+	ErrHoleOutOfRange    = errors.New("hole number is outside the round")
+)
+
+// HoleCount is how many holes an event's round is played over.
+func HoleCount(numberOfHoles string) int32 {
+	n, err := strconv.Atoi(numberOfHoles)
+	if err != nil || n <= 0 {
+		return 18
+	}
+	return int32(n)
+}
+
+type SaveScorecardParams struct {
+	EventID   int64
+	PlayerID  int64
+	EnteredBy int64
+	TeeSetID  sql.NullInt64
+	Holes     []UpsertScorecardHoleParams
+}
+
+// SaveScorecard records holes on the player's scorecard, starting one if
+// needed. Holes already on the card are overwritten and the rest are kept so
+// scores can go in as the round is played. Cards stay open once the round is
+// completed so mistakes can be fixed. Once the card has a tee set, holes
+// entered without a par take the tee set's. The player's handicap is
+// recalculated and their cached stats are cleared.
+func SaveScorecard(ctx context.Context, db *sql.DB, q *Queries, params SaveScorecardParams) (Scorecard, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return Scorecard{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	event, err := qtx.LockEventForUpdate(ctx, params.EventID)
+	if err != nil {
+		return Scorecard{}, err
+	}
+	if event.CancelledAt.Valid {
+		return Scorecard{}, ErrEventCancelled
+	}
+	if event.Status == 0 { // scheduled
+		return Scorecard{}, ErrRoundNotStarted
+	}
+
+	holes := HoleCount(event.NumberOfHoles.String)
+	for _, h := range params.Holes {
+		if h.HoleNumber < 1 || h.HoleNumber > holes {
+			return Scorecard{}, ErrHoleOutOfRange
+		}
+	}
+
+	pe, err := qtx.GetPlayerEvent(ctx, GetPlayerEventParams{
+		PlayerID: sql.NullInt64{Int64: params.PlayerID, Valid: true},
+		EventID:  sql.NullInt64{Int64: params.EventID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && pe.InviteStatus.Int32 != 1) {
+		return Scorecard{}, ErrScorerNotAccepted
+	}
+	if err != nil {
+		return Scorecard{}, fmt.Errorf("failed to check player_event: %w", err)
+	}
+
+	if params.TeeSetID.Valid {
+		if err := requireCourseTeeSet(ctx, qtx, params.EventID, params.TeeSetID.Int64); err != nil {
+			return Scorecard{}, err
+		}
+	}
+
+	card, err := qtx.UpsertScorecard(ctx, UpsertScorecardParams{
+		PlayerEventID: pe.ID,
+		EnteredBy:     params.EnteredBy,
+		TeeSetID:      params.TeeSetID,
+	})
+	if err != nil {
+		return Scorecard{}, fmt.Errorf("failed to save scorecard: %w", err)
+	}
+
+	pars := map[int32]int32{}
+	if card.TeeSetID.Valid {
+		teeHoles, err := qtx.ListTeeSetHolesByTeeSetID(ctx, card.TeeSetID.Int64)
+		if err != nil {
+			return Scorecard{}, fmt.Errorf("failed to fetch tee set: %w", err)
+		}
+		for _, th := range teeHoles {
+			pars[th.HoleNumber] = th.Par
+		}
+	}
+	for _, h := range params.Holes {
+		h.ScorecardID = card.ID
+		if par, ok := pars[h.HoleNumber]; ok && !h.Par.Valid {
+			h.Par = sql.NullInt32{Int32: par, Valid: true}
+		}
+		if err := qtx.UpsertScorecardHole(ctx, h); err != nil {
+			return Scorecard{}, fmt.Errorf("failed to save hole: %w", err)
+		}
+	}
+
+	if err := recalculateHandicap(ctx, qtx, params.PlayerID); err != nil {
+		return Scorecard{}, err
+	}
+	if err := qtx.DeletePlayerStats(ctx, params.PlayerID); err != nil {
+		return Scorecard{}, fmt.Errorf("failed to clear cached stats: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Scorecard{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return card, nil
+}
+
+func requireCourseTeeSet(ctx context.Context, qtx *Queries, eventID, teeSetID int64) error {
+	event, err := qtx.GetEventByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	teeSet, err := qtx.GetTeeSetByID(ctx, teeSetID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && teeSet.CourseID != int64(event.CourseID.Int32)) {
+		return ErrTeeSetNotOnCourse
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch tee set: %w", err)
+	}
+	return nil
+}
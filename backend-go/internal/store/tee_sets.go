@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var ErrTeeSetNameTaken = errors.New("course already has a tee set with that name")
+
+// TeeSetInput is a tee set and its holes as entered by an admin.
+type TeeSetInput struct {
+	Name         string
+	Color        sql.NullString
+	CourseRating float64
+	SlopeRating  int32
+	Yardage      sql.NullInt32
+	Holes        []CreateTeeSetHoleParams
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func replaceTeeSetHoles(ctx context.Context, qtx *Queries, teeSetID int64, holes []CreateTeeSetHoleParams) error {
+	if err := qtx.DeleteTeeSetHoles(ctx, teeSetID); err != nil {
+		return fmt.Errorf("failed to delete holes: %w", err)
+	}
+	for _, h := range holes {
+		h.TeeSetID = teeSetID
+		if err := qtx.CreateTeeSetHole(ctx, h); err != nil {
+			return fmt.Errorf("failed to create hole: %w", err)
+		}
+	}
+	return nil
+}
+
+// teeSetPlayerIDs lists the players with a scorecard from the tee set, whose
+// handicaps depend on its ratings and holes.
+func teeSetPlayerIDs(ctx context.Context, qtx *Queries, teeSetID int64) ([]int64, error) {
+	rows, err := qtx.ListTeeSetPlayerIDs(ctx, sql.NullInt64{Int64: teeSetID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tee set players: %w", err)
+	}
+	playerIDs := make([]int64, 0, len(rows))
+	for _, id := range rows {
+		playerIDs = append(playerIDs, id.Int64)
+	}
+	return playerIDs, nil
+}
+
+// rescorePlayers recalculates the players' handicaps after a tee set they
+// played from changed, and clears their cached stats.
+func rescorePlayers(ctx context.Context, qtx *Queries, playerIDs []int64) error {
+	for _, playerID := range playerIDs {
+		if err := qtx.DeletePlayerStats(ctx, playerID); err != nil {
+			return fmt.Errorf("failed to clear cached stats: %w", err)
+		}
+		if err := recalculateHandicap(ctx, qtx, playerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateTeeSet adds a tee set and its holes to the course.
+func CreateTeeSet(ctx context.Context, db *sql.DB, q *Queries, courseID int64, in TeeSetInput) (TeeSet, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return TeeSet{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	teeSet, err := qtx.CreateTeeSet(ctx, CreateTeeSetParams{
+		CourseID:     courseID,
+		Name:         in.Name,
+		Color:        in.Color,
+		CourseRating: in.CourseRating,
+		SlopeRating:  in.SlopeRating,
+		Yardage:      in.Yardage,
+	})
+	if isUniqueViolation(err) {
+		return TeeSet{}, ErrTeeSetNameTaken
+	}
+	if err != nil {
+		return TeeSet{}, fmt.Errorf("failed to create tee set: %w", err)
+	}
+	if err := replaceTeeSetHoles(ctx, qtx, teeSet.ID, in.Holes); err != nil {
+		return TeeSet{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return TeeSet{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return teeSet, nil
+}
+
+// UpdateTeeSet overwrites a tee set, replacing all of its holes, and
+// recalculates the handicaps of players who played from it. It returns
+// sql.ErrNoRows when the tee set isn't on the course.
+func UpdateTeeSet(ctx context.Context, db *sql.DB, q *Queries, courseID, teeSetID int64, in TeeSetInput) (TeeSet, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return TeeSet{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	teeSet, err := qtx.UpdateTeeSet(ctx, UpdateTeeSetParams{
+		ID:           teeSetID,
+		CourseID:     courseID,
+		Name:         in.Name,
+		Color:        in.Color,
+		CourseRating: in.CourseRating,
+		SlopeRating:  in.SlopeRating,
+		Yardage:      in.Yardage,
+	})
+	if isUniqueViolation(err) {
+		return TeeSet{}, ErrTeeSetNameTaken
+	}
+	if err != nil {
+		return TeeSet{}, err
+	}
+	if err := replaceTeeSetHoles(ctx, qtx, teeSet.ID, in.Holes); err != nil {
+		return TeeSet{}, err
+	}
+	playerIDs, err := teeSetPlayerIDs(ctx, qtx, teeSet.ID)
+	if err != nil {
+		return TeeSet{}, err
+	}
+	if err := rescorePlayers(ctx, qtx, playerIDs); err != nil {
+		return TeeSet{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return TeeSet{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return teeSet, nil
+}
+
+// ImportTeeSets loads a course's tee sets in one go, such as from a
+// scorecard published by the course. Tee sets are matched by name, so
+// importing again updates them in place, recalculating the handicaps of
+// players who played from them; ones missing from the import are left alone.
+func ImportTeeSets(ctx context.Context, db *sql.DB, q *Queries, courseID int64, teeSets []TeeSetInput) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	var playerIDs []int64
+	seen := map[int64]bool{}
+	for _, in := range teeSets {
+		teeSet, err := qtx.UpsertTeeSet(ctx, UpsertTeeSetParams{
+			CourseID:     courseID,
+			Name:         in.Name,
+			Color:        in.Color,
+			CourseRating: in.CourseRating,
+			SlopeRating:  in.SlopeRating,
+			Yardage:      in.Yardage,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save tee set: %w", err)
+		}
+		if err := replaceTeeSetHoles(ctx, qtx, teeSet.ID, in.Holes); err != nil {
+			return err
+		}
+		ids, err := teeSetPlayerIDs(ctx, qtx, teeSet.ID)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				playerIDs = append(playerIDs, id)
+			}
+		}
+	}
+	if err := rescorePlayers(ctx, qtx, playerIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// DeleteTeeSet removes a tee set and its holes from the course. Scorecards
+// played from it lose their tee set, so they no longer count toward
+// handicaps, and those players' handicaps are recalculated. It returns
+// sql.ErrNoRows when the tee set isn't on the course.
+func DeleteTeeSet(ctx context.Context, db *sql.DB, q *Queries, courseID, teeSetID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	// Listed first, since deleting the tee set clears it from their cards.
+	playerIDs, err := teeSetPlayerIDs(ctx, qtx, teeSetID)
+	if err != nil {
+		return err
+	}
+	n, err := qtx.DeleteTeeSet(ctx, DeleteTeeSetParams{
+		ID:       teeSetID,
+		CourseID: courseID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete tee set: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	if err := rescorePlayers(ctx, qtx, playerIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
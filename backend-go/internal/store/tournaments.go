@@ -0,0 +1,423 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ericrabun/findfore-go/internal/bracket"
+	"github.com/ericrabun/findfore-go/internal/games"
+)
+
+var (
+	ErrTournamentStarted    = errors.New("tournament has already started")
+	ErrTournamentNotStarted = errors.New("tournament hasn't started")
+	ErrTournamentCompleted  = errors.New("tournament is over")
+	ErrAlreadyRegistered    = errors.New("player is already registered")
+	ErrTooFewEntrants       = errors.New("tournaments need at least two players")
+	ErrMatchNotReady        = errors.New("match is waiting on an earlier round")
+	ErrMatchDecided         = errors.New("match has already been decided")
+	ErrMatchScheduled       = errors.New("match is already scheduled")
+	ErrMatchNotPlayed       = errors.New("match round hasn't been completed")
+	ErrMatchHalved          = errors.New("match was halved")
+	ErrNotInMatch           = errors.New("winner must be playing in the match")
+)
+
+// tournament_matches.result enum: 0=played, 1=bye, 2=walkover
+const (
+	matchPlayed   int32 = 0
+	matchBye      int32 = 1
+	matchWalkover int32 = 2
+)
+
+// CreateTournament opens registration for a tournament with the given
+// players already entered.
+func CreateTournament(ctx context.Context, db *sql.DB, q *Queries, params CreateTournamentParams, playerIDs []int64) (Tournament, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return Tournament{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	t, err := qtx.CreateTournament(ctx, params)
+	if err != nil {
+		return Tournament{}, fmt.Errorf("failed to create tournament: %w", err)
+	}
+	for _, id := range playerIDs {
+		if _, err := qtx.CreateTournamentPlayer(ctx, CreateTournamentPlayerParams{TournamentID: t.ID, PlayerID: id}); err != nil {
+			return Tournament{}, fmt.Errorf("failed to register player: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Tournament{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return t, nil
+}
+
+// lockTournamentInRegistration locks the tournament and checks the bracket
+// hasn't been drawn yet.
+func lockTournamentInRegistration(ctx context.Context, qtx *Queries, tournamentID int64) (Tournament, error) {
+	t, err := qtx.LockTournamentForUpdate(ctx, tournamentID)
+	if err != nil {
+		return Tournament{}, err
+	}
+	if t.Status != 0 { // registration
+		return Tournament{}, ErrTournamentStarted
+	}
+	return t, nil
+}
+
+// lockTournamentInProgress locks the tournament and checks it's being
+// played.
+func lockTournamentInProgress(ctx context.Context, qtx *Queries, tournamentID int64) (Tournament, error) {
+	t, err := qtx.LockTournamentForUpdate(ctx, tournamentID)
+	if err != nil {
+		return Tournament{}, err
+	}
+	switch t.Status {
+	case 0: // registration
+		return Tournament{}, ErrTournamentNotStarted
+	case 2: // completed
+		return Tournament{}, ErrTournamentCompleted
+	}
+	return t, nil
+}
+
+// RegisterTournamentPlayer enters the player while registration is open.
+func RegisterTournamentPlayer(ctx context.Context, db *sql.DB, q *Queries, tournamentID, playerID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if _, err := lockTournamentInRegistration(ctx, qtx, tournamentID); err != nil {
+		return err
+	}
+	n, err := qtx.CreateTournamentPlayer(ctx, CreateTournamentPlayerParams{TournamentID: tournamentID, PlayerID: playerID})
+	if err != nil {
+		return fmt.Errorf("failed to register player: %w", err)
+	}
+	if n == 0 {
+		return ErrAlreadyRegistered
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// WithdrawTournamentPlayer removes the player while registration is open.
+// Returns sql.ErrNoRows if they weren't registered.
+func WithdrawTournamentPlayer(ctx context.Context, db *sql.DB, q *Queries, tournamentID, playerID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	if _, err := lockTournamentInRegistration(ctx, qtx, tournamentID); err != nil {
+		return err
+	}
+	n, err := qtx.DeleteTournamentPlayer(ctx, DeleteTournamentPlayerParams{TournamentID: tournamentID, PlayerID: playerID})
+	if err != nil {
+		return fmt.Errorf("failed to withdraw player: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// StartTournament closes registration and draws the bracket. Players are
+// seeded by handicap index, every match through the final is created, and
+// byes are decided straight away so those players wait in the second round.
+func StartTournament(ctx context.Context, db *sql.DB, q *Queries, tournamentID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	t, err := lockTournamentInRegistration(ctx, qtx, tournamentID)
+	if err != nil {
+		return err
+	}
+	players, err := qtx.ListTournamentPlayers(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch players: %w", err)
+	}
+	if len(players) < 2 {
+		return ErrTooFewEntrants
+	}
+
+	entrants := make([]bracket.Entrant, len(players))
+	for i, p := range players {
+		entrants[i] = bracket.Entrant{PlayerID: p.PlayerID}
+		if p.HandicapIndex.Valid {
+			handicap := p.HandicapIndex.Float64
+			entrants[i].Handicap = &handicap
+		}
+	}
+	seeds := bracket.Seed(entrants)
+	for i, id := range seeds {
+		if err := qtx.SetTournamentPlayerSeed(ctx, SetTournamentPlayerSeedParams{
+			TournamentID: tournamentID,
+			PlayerID:     id,
+			Seed:         sql.NullInt32{Int32: int32(i + 1), Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to seed player: %w", err)
+		}
+	}
+
+	first := bracket.FirstRound(seeds)
+	rounds := int32(bracket.Rounds(len(seeds)))
+	for i, m := range first {
+		if _, err := qtx.CreateTournamentMatch(ctx, CreateTournamentMatchParams{
+			TournamentID: tournamentID,
+			Round:        1,
+			Position:     int32(i),
+			Player1ID:    sql.NullInt64{Int64: m.Player1, Valid: m.Player1 != 0},
+			Player2ID:    sql.NullInt64{Int64: m.Player2, Valid: m.Player2 != 0},
+		}); err != nil {
+			return fmt.Errorf("failed to create match: %w", err)
+		}
+	}
+	for round, matches := int32(2), len(first)/2; round <= rounds; round, matches = round+1, matches/2 {
+		for i := 0; i < matches; i++ {
+			if _, err := qtx.CreateTournamentMatch(ctx, CreateTournamentMatchParams{
+				TournamentID: tournamentID,
+				Round:        round,
+				Position:     int32(i),
+			}); err != nil {
+				return fmt.Errorf("failed to create match: %w", err)
+			}
+		}
+	}
+
+	if err := qtx.SetTournamentStatus(ctx, SetTournamentStatusParams{ID: tournamentID, Status: 1}); err != nil { // in_progress
+		return fmt.Errorf("failed to start tournament: %w", err)
+	}
+
+	matches, err := qtx.ListTournamentMatches(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch matches: %w", err)
+	}
+	for _, m := range matches {
+		if m.Round == 1 && !m.Player2ID.Valid {
+			if err := decideMatch(ctx, qtx, t, rounds, m, m.Player1ID.Int64, matchBye); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// decideMatch records the match's winner and moves them into their slot in
+// the next round, or crowns them if it was the final.
+func decideMatch(ctx context.Context, qtx *Queries, t Tournament, rounds int32, m TournamentMatch, winnerID int64, result int32) error {
+	if err := qtx.DecideTournamentMatch(ctx, DecideTournamentMatchParams{
+		ID:       m.ID,
+		WinnerID: sql.NullInt64{Int64: winnerID, Valid: true},
+		Result:   sql.NullInt32{Int32: result, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to decide match: %w", err)
+	}
+
+	if m.Round == rounds {
+		if err := qtx.SetTournamentStatus(ctx, SetTournamentStatusParams{
+			ID:       t.ID,
+			Status:   2, // completed
+			WinnerID: sql.NullInt64{Int64: winnerID, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to complete tournament: %w", err)
+		}
+		return nil
+	}
+
+	position, first := bracket.Next(int(m.Position))
+	var err error
+	if first {
+		err = qtx.SetTournamentMatchPlayer1(ctx, SetTournamentMatchPlayer1Params{
+			TournamentID: t.ID,
+			Round:        m.Round + 1,
+			Position:     int32(position),
+			Player1ID:    sql.NullInt64{Int64: winnerID, Valid: true},
+		})
+	} else {
+		err = qtx.SetTournamentMatchPlayer2(ctx, SetTournamentMatchPlayer2Params{
+			TournamentID: t.ID,
+			Round:        m.Round + 1,
+			Position:     int32(position),
+			Player2ID:    sql.NullInt64{Int64: winnerID, Valid: true},
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to advance winner: %w", err)
+	}
+	return nil
+}
+
+// lockOpenMatch locks the tournament and loads a match that has both its
+// players and is still to be decided.
+func lockOpenMatch(ctx context.Context, qtx *Queries, tournamentID, matchID int64) (Tournament, TournamentMatch, error) {
+	t, err := lockTournamentInProgress(ctx, qtx, tournamentID)
+	if err != nil {
+		return Tournament{}, TournamentMatch{}, err
+	}
+	m, err := qtx.GetTournamentMatch(ctx, GetTournamentMatchParams{ID: matchID, TournamentID: tournamentID})
+	if err != nil {
+		return Tournament{}, TournamentMatch{}, err
+	}
+	if m.WinnerID.Valid {
+		return Tournament{}, TournamentMatch{}, ErrMatchDecided
+	}
+	if !m.Player1ID.Valid || !m.Player2ID.Valid {
+		return Tournament{}, TournamentMatch{}, ErrMatchNotReady
+	}
+	return t, m, nil
+}
+
+// ScheduleTournamentMatch creates a private event at the tournament's course
+// for the match, hosted by the first player with their opponent invited. A
+// match can be rescheduled once its event has been cancelled.
+func ScheduleTournamentMatch(ctx context.Context, db *sql.DB, q *Queries, tournamentID, matchID int64, date, teeTime string) (int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	t, m, err := lockOpenMatch(ctx, qtx, tournamentID, matchID)
+	if err != nil {
+		return 0, err
+	}
+	if m.EventID.Valid {
+		event, err := qtx.LockEventForUpdate(ctx, m.EventID.Int64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch match event: %w", err)
+		}
+		if !event.CancelledAt.Valid {
+			return 0, ErrMatchScheduled
+		}
+	}
+
+	eventID, err := createEventWithInvites(ctx, qtx, CreateEventWithInvitesParams{
+		CourseID:      int32(t.CourseID),
+		Date:          date,
+		TeeTime:       teeTime,
+		OpenSpots:     2,
+		NumberOfHoles: t.NumberOfHoles,
+		Private:       true,
+		HostID:        int32(m.Player1ID.Int64),
+		Invitees:      []int64{m.Player2ID.Int64},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := qtx.SetTournamentMatchEvent(ctx, SetTournamentMatchEventParams{
+		ID:      m.ID,
+		EventID: sql.NullInt64{Int64: eventID, Valid: true},
+	}); err != nil {
+		return 0, fmt.Errorf("failed to schedule match: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return eventID, nil
+}
+
+// DecideTournamentMatch settles the match and advances the winner. With no
+// winnerID the match is played out from the scorecards of its completed
+// event, gross or net as the tournament is set up, once both players' cards
+// have every hole. A winnerID records the
+// result directly, as a walkover if the opponent didn't play.
+func DecideTournamentMatch(ctx context.Context, db *sql.DB, q *Queries, tournamentID, matchID, winnerID int64, walkover bool) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := q.WithTx(tx)
+
+	t, m, err := lockOpenMatch(ctx, qtx, tournamentID, matchID)
+	if err != nil {
+		return err
+	}
+
+	result := matchPlayed
+	if walkover {
+		result = matchWalkover
+	}
+	if winnerID != 0 {
+		if winnerID != m.Player1ID.Int64 && winnerID != m.Player2ID.Int64 {
+			return ErrNotInMatch
+		}
+	} else {
+		if !m.EventID.Valid {
+			return ErrMatchNotPlayed
+		}
+		event, err := qtx.LockEventForUpdate(ctx, m.EventID.Int64)
+		if err != nil {
+			return fmt.Errorf("failed to fetch match event: %w", err)
+		}
+		if event.Status != 2 { // completed
+			return ErrMatchNotPlayed
+		}
+		cards, err := loadCards(ctx, qtx, m.EventID.Int64)
+		if err != nil {
+			return err
+		}
+		// Match play only counts holes both players finished, so both cards
+		// need the whole round.
+		holes := int(HoleCount(event.NumberOfHoles.String))
+		a, b := cards[m.Player1ID.Int64], cards[m.Player2ID.Int64]
+		if len(a.Holes) < holes || len(b.Holes) < holes {
+			return ErrMatchNotPlayed
+		}
+		var ok bool
+		if winnerID, ok = games.Match(t.Net, a, b); !ok {
+			return ErrMatchHalved
+		}
+	}
+
+	matches, err := qtx.ListTournamentMatches(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch matches: %w", err)
+	}
+	rounds := int32(0)
+	for _, other := range matches {
+		rounds = max(rounds, other.Round)
+	}
+	if err := decideMatch(ctx, qtx, t, rounds, m, winnerID, result); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"github.com/ericrabun/findfore-go/internal/config"
 	"github.com/ericrabun/findfore-go/internal/database"
 	"github.com/ericrabun/findfore-go/internal/handler"
+	"github.com/ericrabun/findfore-go/internal/jobs"
 	"github.com/ericrabun/findfore-go/internal/router"
 	"github.com/ericrabun/findfore-go/internal/store"
 )
@@ -25,6 +27,16 @@ func main() {
 	defer db.Close()
 
 	queries := store.New(db)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheduler := jobs.NewScheduler(jobs.Interval,
+		jobs.AdvanceEventStatuses(queries),
+		jobs.ExpireInvitations(queries),
+		jobs.SendRsvpReminders(db, queries),
+	)
+	go scheduler.Start(ctx)
+
 	h := handler.New(queries, db, cfg.JWTSecret)
 	r := router.New(h, cfg.JWTSecret)
 
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS index_events_on_series_id_and_occurrence_date;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS fk_events_event_series;
+ALTER TABLE events DROP COLUMN IF EXISTS occurrence_date;
+ALTER TABLE events DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS event_series_rsvps;
+DROP TABLE IF EXISTS event_series_exceptions;
+DROP TABLE IF EXISTS event_series_invitees;
+DROP TABLE IF EXISTS event_series;
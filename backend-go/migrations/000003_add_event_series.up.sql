@@ -0,0 +1,55 @@
+CREATE TABLE IF NOT EXISTS event_series (
+    id BIGSERIAL PRIMARY KEY,
+    host_id BIGINT NOT NULL,
+    course_id BIGINT NOT NULL,
+    starts_on DATE NOT NULL,
+    tee_time VARCHAR NOT NULL,
+    open_spots INTEGER NOT NULL,
+    number_of_holes VARCHAR NOT NULL,
+    private BOOLEAN NOT NULL DEFAULT FALSE,
+    rrule VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_series_players FOREIGN KEY (host_id) REFERENCES players(id),
+    CONSTRAINT fk_event_series_courses FOREIGN KEY (course_id) REFERENCES courses(id)
+);
+
+CREATE TABLE IF NOT EXISTS event_series_invitees (
+    id BIGSERIAL PRIMARY KEY,
+    series_id BIGINT NOT NULL,
+    player_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_series_invitees_series FOREIGN KEY (series_id) REFERENCES event_series(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_series_invitees_players FOREIGN KEY (player_id) REFERENCES players(id),
+    CONSTRAINT uq_event_series_invitees_series_player UNIQUE (series_id, player_id)
+);
+
+CREATE TABLE IF NOT EXISTS event_series_exceptions (
+    id BIGSERIAL PRIMARY KEY,
+    series_id BIGINT NOT NULL,
+    occurrence_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_series_exceptions_series FOREIGN KEY (series_id) REFERENCES event_series(id) ON DELETE CASCADE,
+    CONSTRAINT uq_event_series_exceptions_series_date UNIQUE (series_id, occurrence_date)
+);
+
+CREATE TABLE IF NOT EXISTS event_series_rsvps (
+    id BIGSERIAL PRIMARY KEY,
+    series_id BIGINT NOT NULL,
+    player_id BIGINT NOT NULL,
+    invite_status INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_series_rsvps_series FOREIGN KEY (series_id) REFERENCES event_series(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_series_rsvps_players FOREIGN KEY (player_id) REFERENCES players(id),
+    CONSTRAINT uq_event_series_rsvps_series_player UNIQUE (series_id, player_id)
+);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS series_id BIGINT;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+ALTER TABLE events
+    ADD CONSTRAINT fk_events_event_series FOREIGN KEY (series_id) REFERENCES event_series(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_events_on_series_id_and_occurrence_date ON events (series_id, occurrence_date);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS index_events_on_host_id;
+DROP INDEX IF EXISTS index_events_on_course_id;
+DROP INDEX IF EXISTS index_events_on_starts_at;
+ALTER TABLE events DROP COLUMN IF EXISTS starts_at;
@@ -0,0 +1,15 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS starts_at TIMESTAMP;
+
+-- Backfill from the legacy date/tee_time strings. Older rows use MM-DD-YYYY.
+UPDATE events
+SET starts_at = CASE
+    WHEN date ~ '^\d{4}-\d{2}-\d{2}$' AND tee_time ~ '^\d{1,2}:\d{2}$'
+        THEN to_timestamp(date || ' ' || tee_time, 'YYYY-MM-DD HH24:MI')::timestamp
+    WHEN date ~ '^\d{2}-\d{2}-\d{4}$' AND tee_time ~ '^\d{1,2}:\d{2}$'
+        THEN to_timestamp(date || ' ' || tee_time, 'MM-DD-YYYY HH24:MI')::timestamp
+END
+WHERE starts_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS index_events_on_starts_at ON events (starts_at, id);
+CREATE INDEX IF NOT EXISTS index_events_on_course_id ON events (course_id);
+CREATE INDEX IF NOT EXISTS index_events_on_host_id ON events (host_id);
@@ -0,0 +1,19 @@
+-- Restore the fan-out: every player other than the host gets an unanswered
+-- row on each public event they aren't already part of, closed (3) when the
+-- event is already full. This rebuilds the fan-out as the old code kept it
+-- rather than the exact rows deleted, so their timestamps are the rollback's.
+INSERT INTO player_events (player_id, event_id, invite_status, created_at, updated_at)
+SELECT p.id, e.id,
+  CASE WHEN e.open_spots <= (
+    SELECT COUNT(*) FROM player_events a
+    WHERE a.event_id = e.id AND a.invite_status = 1
+  ) THEN 3 ELSE 0 END,
+  NOW(), NOW()
+FROM events e
+CROSS JOIN players p
+WHERE e.private = false
+  AND p.id <> e.host_id
+  AND NOT EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = p.id
+  );
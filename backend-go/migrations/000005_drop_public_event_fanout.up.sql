@@ -0,0 +1,8 @@
+-- Public events used to get a pending player_events row for every player.
+-- Visibility is now implicit, so drop the unanswered fan-out rows; targeted
+-- invites were never created for public events before this change.
+DELETE FROM player_events pe
+USING events e
+WHERE pe.event_id = e.id
+  AND e.private = false
+  AND pe.invite_status IN (0, 3);
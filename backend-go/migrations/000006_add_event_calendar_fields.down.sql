@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS index_players_on_calendar_token;
+ALTER TABLE players DROP COLUMN IF EXISTS calendar_token;
+ALTER TABLE events DROP COLUMN IF EXISTS cancelled_at;
+ALTER TABLE events DROP COLUMN IF EXISTS sequence;
@@ -0,0 +1,8 @@
+-- sequence is the iCalendar SEQUENCE and is bumped whenever an event's
+-- details change, so calendar clients replace their copy.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS sequence INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP;
+
+ALTER TABLE players ADD COLUMN IF NOT EXISTS calendar_token VARCHAR;
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_players_on_calendar_token ON players (calendar_token);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS event_invite_links (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    created_by BIGINT NOT NULL,
+    expires_at TIMESTAMP,
+    max_uses INTEGER,
+    uses INTEGER NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_invite_links_events FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_invite_links_players FOREIGN KEY (created_by) REFERENCES players(id)
+);
+
+CREATE INDEX IF NOT EXISTS index_event_invite_links_on_event_id ON event_invite_links (event_id);
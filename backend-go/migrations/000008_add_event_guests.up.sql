@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS event_guests (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    sponsor_id BIGINT NOT NULL,
+    name VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_guests_events FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_guests_players FOREIGN KEY (sponsor_id) REFERENCES players(id)
+);
+
+CREATE INDEX IF NOT EXISTS index_event_guests_on_event_id ON event_guests (event_id);
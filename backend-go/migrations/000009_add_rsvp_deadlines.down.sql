@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS notifications;
+DROP INDEX IF EXISTS index_events_on_respond_by;
+ALTER TABLE player_events DROP COLUMN IF EXISTS reminded_at;
+-- Expired invitations (status 4) go back to pending.
+UPDATE player_events SET invite_status = 0 WHERE invite_status = 4;
+ALTER TABLE events DROP COLUMN IF EXISTS respond_by;
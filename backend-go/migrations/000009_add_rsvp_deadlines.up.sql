@@ -0,0 +1,21 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS respond_by TIMESTAMP;
+
+-- Set once a pending invitee has been reminded about the respond_by deadline.
+ALTER TABLE player_events ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS index_events_on_respond_by ON events (respond_by);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    player_id BIGINT NOT NULL,
+    event_id BIGINT,
+    kind VARCHAR NOT NULL,
+    message VARCHAR NOT NULL,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_notifications_players FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+    CONSTRAINT fk_notifications_events FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS index_notifications_on_player_id ON notifications (player_id, id);
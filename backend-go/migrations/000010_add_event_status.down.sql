@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS index_events_on_status_and_starts_at;
+ALTER TABLE events DROP COLUMN IF EXISTS status;
@@ -0,0 +1,9 @@
+-- Event lifecycle: 0=scheduled, 1=in_progress, 2=completed, 3=cancelled.
+-- The scheduler advances events by starts_at; cancelling sets 3 directly.
+-- Existing events are advanced in 000025, once courses have a time zone to
+-- compare their local start times in.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS status INTEGER NOT NULL DEFAULT 0;
+
+UPDATE events SET status = 3 WHERE cancelled_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS index_events_on_status_and_starts_at ON events (status, starts_at);
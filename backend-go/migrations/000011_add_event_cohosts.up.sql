@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS event_cohosts (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    player_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_cohosts_events FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_cohosts_players FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_event_cohosts_on_event_id_and_player_id ON event_cohosts (event_id, player_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS pairing_preferences;
+DROP TABLE IF EXISTS event_group_members;
+DROP TABLE IF EXISTS event_groups;
+ALTER TABLE players DROP COLUMN IF EXISTS handicap_index;
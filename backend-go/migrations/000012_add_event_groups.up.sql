@@ -0,0 +1,44 @@
+-- Self-reported until handicaps are calculated from scores; used to balance
+-- pairings.
+ALTER TABLE players ADD COLUMN IF NOT EXISTS handicap_index DOUBLE PRECISION;
+
+-- Groups split an outing's accepted players across several tee times.
+CREATE TABLE IF NOT EXISTS event_groups (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    tee_time VARCHAR NOT NULL,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_groups_events FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS index_event_groups_on_event_id ON event_groups (event_id);
+
+CREATE TABLE IF NOT EXISTS event_group_members (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    group_id BIGINT NOT NULL,
+    player_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_group_members_events FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_group_members_event_groups FOREIGN KEY (group_id) REFERENCES event_groups(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_group_members_players FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_event_group_members_on_event_id_and_player_id ON event_group_members (event_id, player_id);
+
+-- preference: 0=play_with, 1=avoid
+CREATE TABLE IF NOT EXISTS pairing_preferences (
+    id BIGSERIAL PRIMARY KEY,
+    player_id BIGINT NOT NULL,
+    other_player_id BIGINT NOT NULL,
+    preference INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_pairing_preferences_players FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+    CONSTRAINT fk_pairing_preferences_other_players FOREIGN KEY (other_player_id) REFERENCES players(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_pairing_preferences_on_player_id_and_other_player_id ON pairing_preferences (player_id, other_player_id);
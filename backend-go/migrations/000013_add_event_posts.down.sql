@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS index_posts_on_event_id_and_created_at;
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS fk_posts_events;
+ALTER TABLE posts DROP COLUMN IF EXISTS event_id;
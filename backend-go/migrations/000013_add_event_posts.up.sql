@@ -0,0 +1,8 @@
+-- Posts with an event_id belong to that event's discussion thread and are
+-- kept out of the global feed.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS event_id BIGINT;
+
+ALTER TABLE posts
+    ADD CONSTRAINT fk_posts_events FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS index_posts_on_event_id_and_created_at ON posts (event_id, created_at DESC);
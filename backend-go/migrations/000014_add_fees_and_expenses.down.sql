@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS settlements;
+DROP TABLE IF EXISTS event_expenses;
+ALTER TABLE events DROP COLUMN IF EXISTS fee_currency;
+ALTER TABLE events DROP COLUMN IF EXISTS fee_cents;
+ALTER TABLE courses DROP COLUMN IF EXISTS fee_currency;
+ALTER TABLE courses DROP COLUMN IF EXISTS fee_cents;
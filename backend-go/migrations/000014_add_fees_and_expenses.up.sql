@@ -0,0 +1,52 @@
+-- Fees are integer cents in an ISO 4217 currency. courses.cost stays as the
+-- free-text description; plain amounts like "$45" or "45.00" are carried
+-- over.
+ALTER TABLE courses ADD COLUMN IF NOT EXISTS fee_cents INTEGER;
+ALTER TABLE courses ADD COLUMN IF NOT EXISTS fee_currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+UPDATE courses
+SET fee_cents = ROUND(REGEXP_REPLACE(cost, '[$,[:space:]]', '', 'g')::numeric * 100)
+WHERE fee_cents IS NULL AND cost ~ '^[[:space:]]*\$?[0-9][0-9,]*(\.[0-9]{1,2})?[[:space:]]*$';
+
+-- The per-player fee for the round, which defaults to the course's.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS fee_cents INTEGER;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS fee_currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+UPDATE events e
+SET fee_cents = c.fee_cents, fee_currency = c.fee_currency
+FROM courses c
+WHERE c.id = e.course_id AND e.fee_cents IS NULL;
+
+-- Payments a player made for the group, split between the accepted players.
+CREATE TABLE IF NOT EXISTS event_expenses (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    payer_id BIGINT NOT NULL,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+    currency VARCHAR(3) NOT NULL,
+    description VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_expenses_events FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_expenses_players FOREIGN KEY (payer_id) REFERENCES players(id)
+);
+
+CREATE INDEX IF NOT EXISTS index_event_expenses_on_event_id ON event_expenses (event_id);
+CREATE INDEX IF NOT EXISTS index_event_expenses_on_payer_id ON event_expenses (payer_id);
+
+-- Settle-ups record one player paying another back outside the app.
+CREATE TABLE IF NOT EXISTS settlements (
+    id BIGSERIAL PRIMARY KEY,
+    payer_id BIGINT NOT NULL,
+    payee_id BIGINT NOT NULL,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+    currency VARCHAR(3) NOT NULL,
+    note VARCHAR,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_settlements_payers FOREIGN KEY (payer_id) REFERENCES players(id),
+    CONSTRAINT fk_settlements_payees FOREIGN KEY (payee_id) REFERENCES players(id)
+);
+
+CREATE INDEX IF NOT EXISTS index_settlements_on_payer_id ON settlements (payer_id);
+CREATE INDEX IF NOT EXISTS index_settlements_on_payee_id ON settlements (payee_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS scorecard_holes;
+DROP TABLE IF EXISTS scorecards;
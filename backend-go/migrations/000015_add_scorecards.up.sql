@@ -0,0 +1,30 @@
+-- One scorecard per accepted player in a round.
+CREATE TABLE IF NOT EXISTS scorecards (
+    id BIGSERIAL PRIMARY KEY,
+    player_event_id BIGINT NOT NULL,
+    entered_by BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_scorecards_player_events FOREIGN KEY (player_event_id) REFERENCES player_events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_scorecards_players FOREIGN KEY (entered_by) REFERENCES players(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_scorecards_on_player_event_id ON scorecards (player_event_id);
+
+-- Par is recorded per hole so to-par works before the course's holes are
+-- known.
+CREATE TABLE IF NOT EXISTS scorecard_holes (
+    id BIGSERIAL PRIMARY KEY,
+    scorecard_id BIGINT NOT NULL,
+    hole_number INTEGER NOT NULL,
+    strokes INTEGER NOT NULL,
+    putts INTEGER,
+    fairway_hit BOOLEAN,
+    green_in_regulation BOOLEAN,
+    par INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_scorecard_holes_scorecards FOREIGN KEY (scorecard_id) REFERENCES scorecards(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_scorecard_holes_on_scorecard_id_and_hole_number ON scorecard_holes (scorecard_id, hole_number);
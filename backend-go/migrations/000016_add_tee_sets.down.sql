@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tee_set_holes;
+DROP TABLE IF EXISTS tee_sets;
+ALTER TABLE players DROP COLUMN IF EXISTS admin;
@@ -0,0 +1,32 @@
+-- Admins maintain course data such as tee sets.
+ALTER TABLE players ADD COLUMN IF NOT EXISTS admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The tees a course can be played from, rated for handicap calculations.
+CREATE TABLE IF NOT EXISTS tee_sets (
+    id BIGSERIAL PRIMARY KEY,
+    course_id BIGINT NOT NULL,
+    name VARCHAR NOT NULL,
+    color VARCHAR,
+    course_rating DOUBLE PRECISION NOT NULL,
+    slope_rating INTEGER NOT NULL,
+    yardage INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_tee_sets_courses FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_tee_sets_on_course_id_and_name ON tee_sets (course_id, name);
+
+CREATE TABLE IF NOT EXISTS tee_set_holes (
+    id BIGSERIAL PRIMARY KEY,
+    tee_set_id BIGINT NOT NULL,
+    hole_number INTEGER NOT NULL,
+    par INTEGER NOT NULL,
+    stroke_index INTEGER NOT NULL,
+    yardage INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_tee_set_holes_tee_sets FOREIGN KEY (tee_set_id) REFERENCES tee_sets(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_tee_set_holes_on_tee_set_id_and_hole_number ON tee_set_holes (tee_set_id, hole_number);
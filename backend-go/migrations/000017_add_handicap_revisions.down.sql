@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS handicap_revisions;
+ALTER TABLE scorecards DROP CONSTRAINT IF EXISTS fk_scorecards_tee_sets;
+ALTER TABLE scorecards DROP COLUMN IF EXISTS tee_set_id;
@@ -0,0 +1,22 @@
+-- The tees a scorecard was played from, needed to rate the score.
+ALTER TABLE scorecards ADD COLUMN IF NOT EXISTS tee_set_id BIGINT;
+ALTER TABLE scorecards ADD CONSTRAINT fk_scorecards_tee_sets FOREIGN KEY (tee_set_id) REFERENCES tee_sets(id) ON DELETE SET NULL;
+
+-- A player's handicap after each posted round, rebuilt from their
+-- scorecards whenever one changes.
+CREATE TABLE IF NOT EXISTS handicap_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    player_id BIGINT NOT NULL,
+    scorecard_id BIGINT NOT NULL,
+    played_at TIMESTAMP NOT NULL,
+    adjusted_gross_score INTEGER NOT NULL,
+    score_differential DOUBLE PRECISION NOT NULL,
+    handicap_index DOUBLE PRECISION,
+    low_handicap_index DOUBLE PRECISION,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_handicap_revisions_players FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+    CONSTRAINT fk_handicap_revisions_scorecards FOREIGN KEY (scorecard_id) REFERENCES scorecards(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS index_handicap_revisions_on_player_id ON handicap_revisions (player_id);
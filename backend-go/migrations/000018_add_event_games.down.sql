@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS game_payouts;
+DROP TABLE IF EXISTS event_game_players;
+DROP TABLE IF EXISTS event_games;
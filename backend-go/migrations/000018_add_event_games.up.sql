@@ -0,0 +1,48 @@
+-- Money games played during an event's round. format is 0=skins, 1=nassau,
+-- 2=stableford, 3=match_play, and stake_cents is per skin, per point or per
+-- match in the event's fee currency.
+CREATE TABLE IF NOT EXISTS event_games (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    format INTEGER NOT NULL,
+    net BOOLEAN NOT NULL DEFAULT FALSE,
+    stake_cents INTEGER NOT NULL CHECK (stake_cents > 0),
+    carryover BOOLEAN NOT NULL DEFAULT TRUE,
+    settled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_games_events FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS index_event_games_on_event_id ON event_games (event_id);
+
+CREATE TABLE IF NOT EXISTS event_game_players (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL,
+    player_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_game_players_games FOREIGN KEY (game_id) REFERENCES event_games(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_game_players_players FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_event_game_players_on_game_id_and_player_id ON event_game_players (game_id, player_id);
+
+-- What each player owed another when the games were settled, fixed so later
+-- scorecard corrections don't change money already owed.
+CREATE TABLE IF NOT EXISTS game_payouts (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL,
+    payer_id BIGINT NOT NULL,
+    payee_id BIGINT NOT NULL,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+    currency VARCHAR(3) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_game_payouts_games FOREIGN KEY (game_id) REFERENCES event_games(id) ON DELETE CASCADE,
+    CONSTRAINT fk_game_payouts_payers FOREIGN KEY (payer_id) REFERENCES players(id),
+    CONSTRAINT fk_game_payouts_payees FOREIGN KEY (payee_id) REFERENCES players(id)
+);
+
+CREATE INDEX IF NOT EXISTS index_game_payouts_on_game_id ON game_payouts (game_id);
+CREATE INDEX IF NOT EXISTS index_game_payouts_on_payer_id ON game_payouts (payer_id);
+CREATE INDEX IF NOT EXISTS index_game_payouts_on_payee_id ON game_payouts (payee_id);
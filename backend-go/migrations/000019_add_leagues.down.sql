@@ -0,0 +1,5 @@
+ALTER TABLE events DROP CONSTRAINT IF EXISTS fk_events_league_seasons;
+ALTER TABLE events DROP COLUMN IF EXISTS league_season_id;
+DROP TABLE IF EXISTS league_seasons;
+DROP TABLE IF EXISTS league_members;
+DROP TABLE IF EXISTS leagues;
@@ -0,0 +1,51 @@
+-- Leagues are standing groups run by a commissioner.
+CREATE TABLE IF NOT EXISTS leagues (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    commissioner_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_leagues_players FOREIGN KEY (commissioner_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS league_members (
+    id BIGSERIAL PRIMARY KEY,
+    league_id BIGINT NOT NULL,
+    player_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_league_members_leagues FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE,
+    CONSTRAINT fk_league_members_players FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_league_members_on_league_id_and_player_id ON league_members (league_id, player_id);
+CREATE INDEX IF NOT EXISTS index_league_members_on_player_id ON league_members (player_id);
+
+-- A season's schedule is generated into events from the rrule. finish_points
+-- are awarded by finishing place in each round, and match points for each
+-- match won or halved against the other members in the round.
+CREATE TABLE IF NOT EXISTS league_seasons (
+    id BIGSERIAL PRIMARY KEY,
+    league_id BIGINT NOT NULL,
+    name VARCHAR NOT NULL,
+    course_id BIGINT NOT NULL,
+    starts_on DATE NOT NULL,
+    tee_time VARCHAR NOT NULL,
+    open_spots INTEGER NOT NULL,
+    number_of_holes VARCHAR NOT NULL,
+    rrule VARCHAR NOT NULL,
+    net BOOLEAN NOT NULL DEFAULT FALSE,
+    finish_points INTEGER[] NOT NULL DEFAULT '{}',
+    match_win_points INTEGER NOT NULL DEFAULT 0,
+    match_halve_points INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_league_seasons_leagues FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE,
+    CONSTRAINT fk_league_seasons_courses FOREIGN KEY (course_id) REFERENCES courses(id)
+);
+
+CREATE INDEX IF NOT EXISTS index_league_seasons_on_league_id ON league_seasons (league_id);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS league_season_id BIGINT;
+ALTER TABLE events ADD CONSTRAINT fk_events_league_seasons FOREIGN KEY (league_season_id) REFERENCES league_seasons(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS index_events_on_league_season_id ON events (league_season_id);
@@ -0,0 +1,53 @@
+-- Single elimination match play tournaments. status is 0=registration,
+-- 1=in_progress, 2=completed.
+CREATE TABLE IF NOT EXISTS tournaments (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    host_id BIGINT NOT NULL,
+    course_id BIGINT NOT NULL,
+    number_of_holes VARCHAR NOT NULL,
+    net BOOLEAN NOT NULL DEFAULT FALSE,
+    status INTEGER NOT NULL DEFAULT 0,
+    winner_id BIGINT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_tournaments_hosts FOREIGN KEY (host_id) REFERENCES players(id),
+    CONSTRAINT fk_tournaments_courses FOREIGN KEY (course_id) REFERENCES courses(id),
+    CONSTRAINT fk_tournaments_winners FOREIGN KEY (winner_id) REFERENCES players(id)
+);
+
+-- seed is set when the bracket is drawn.
+CREATE TABLE IF NOT EXISTS tournament_players (
+    id BIGSERIAL PRIMARY KEY,
+    tournament_id BIGINT NOT NULL,
+    player_id BIGINT NOT NULL,
+    seed INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_tournament_players_tournaments FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tournament_players_players FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_tournament_players_on_tournament_id_and_player_id ON tournament_players (tournament_id, player_id);
+
+-- Every match in the bracket, created when it's drawn. Later rounds fill in
+-- as winners advance. result is 0=played, 1=bye, 2=walkover once decided.
+CREATE TABLE IF NOT EXISTS tournament_matches (
+    id BIGSERIAL PRIMARY KEY,
+    tournament_id BIGINT NOT NULL,
+    round INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    player1_id BIGINT,
+    player2_id BIGINT,
+    event_id BIGINT,
+    winner_id BIGINT,
+    result INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_tournament_matches_tournaments FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tournament_matches_player1 FOREIGN KEY (player1_id) REFERENCES players(id),
+    CONSTRAINT fk_tournament_matches_player2 FOREIGN KEY (player2_id) REFERENCES players(id),
+    CONSTRAINT fk_tournament_matches_events FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE SET NULL,
+    CONSTRAINT fk_tournament_matches_winners FOREIGN KEY (winner_id) REFERENCES players(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_tournament_matches_on_tournament_id_round_and_position ON tournament_matches (tournament_id, round, position);
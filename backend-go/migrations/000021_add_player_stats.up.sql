@@ -0,0 +1,12 @@
+-- Cached player statistics, one row per player and filter. Rows are deleted
+-- whenever the player's scorecards change and rebuilt on the next request.
+CREATE TABLE IF NOT EXISTS player_stats (
+    id BIGSERIAL PRIMARY KEY,
+    player_id BIGINT NOT NULL,
+    filter VARCHAR NOT NULL,
+    summary JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_player_stats_players FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_player_stats_on_player_id_and_filter ON player_stats (player_id, filter);
@@ -0,0 +1 @@
+ALTER TABLE players DROP COLUMN IF EXISTS scores_visibility;
@@ -0,0 +1,3 @@
+-- Who can see a player's rounds on leaderboards. 0=everyone, 1=friends (the
+-- players they follow), 2=only_me.
+ALTER TABLE players ADD COLUMN IF NOT EXISTS scores_visibility INTEGER NOT NULL DEFAULT 0;
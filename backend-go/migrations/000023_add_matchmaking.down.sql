@@ -0,0 +1,4 @@
+ALTER TABLE players DROP CONSTRAINT IF EXISTS fk_players_courses;
+ALTER TABLE players DROP COLUMN IF EXISTS home_course_id;
+ALTER TABLE courses DROP COLUMN IF EXISTS longitude;
+ALTER TABLE courses DROP COLUMN IF EXISTS latitude;
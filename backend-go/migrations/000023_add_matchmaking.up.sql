@@ -0,0 +1,8 @@
+-- Course locations in degrees, used to recommend events near a player's
+-- home course.
+ALTER TABLE courses ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE courses ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+ALTER TABLE players ADD COLUMN IF NOT EXISTS home_course_id INTEGER;
+ALTER TABLE players
+    ADD CONSTRAINT fk_players_courses FOREIGN KEY (home_course_id) REFERENCES courses(id) ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS player_preferred_courses;
+DROP TABLE IF EXISTS player_availability;
@@ -0,0 +1,24 @@
+-- Weekly windows when a player is free to play, in minutes after midnight.
+-- day_of_week: 0=sunday .. 6=saturday
+CREATE TABLE IF NOT EXISTS player_availability (
+    id BIGSERIAL PRIMARY KEY,
+    player_id BIGINT NOT NULL,
+    day_of_week INTEGER NOT NULL,
+    start_minute INTEGER NOT NULL,
+    end_minute INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_player_availability_players FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS index_player_availability_on_player_id ON player_availability (player_id);
+
+CREATE TABLE IF NOT EXISTS player_preferred_courses (
+    id BIGSERIAL PRIMARY KEY,
+    player_id BIGINT NOT NULL,
+    course_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_player_preferred_courses_players FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+    CONSTRAINT fk_player_preferred_courses_courses FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS index_player_preferred_courses_on_player_id_and_course_id ON player_preferred_courses (player_id, course_id);
@@ -0,0 +1,15 @@
+-- Tee times and events.starts_at are the course's local wall clock time, so
+-- they can only be compared with the current time in the course's zone.
+-- Every existing course is in Colorado.
+ALTER TABLE courses ADD COLUMN IF NOT EXISTS time_zone VARCHAR NOT NULL DEFAULT 'America/Denver';
+
+-- Backfill the status of existing events against the current time in each
+-- course's zone.
+UPDATE events e
+SET status = CASE
+    WHEN e.starts_at + CASE WHEN e.number_of_holes = '9' THEN INTERVAL '2 hours 15 minutes' ELSE INTERVAL '4 hours 30 minutes' END <= NOW() AT TIME ZONE c.time_zone THEN 2
+    WHEN e.starts_at <= NOW() AT TIME ZONE c.time_zone THEN 1
+    ELSE 0
+  END
+FROM courses c
+WHERE c.id = e.course_id AND e.status IN (0, 1, 2) AND e.starts_at IS NOT NULL;
@@ -32,7 +32,7 @@ func main() {
 	q := store.New(db)
 
 	// Clean existing data in correct order
-	for _, table := range []string{"player_events", "friendships", "events", "courses", "players"} {
+	for _, table := range []string{"player_availability", "player_preferred_courses", "player_stats", "tournament_matches", "tournament_players", "tournaments", "league_seasons", "league_members", "leagues", "game_payouts", "event_game_players", "event_games", "handicap_revisions", "tee_set_holes", "tee_sets", "scorecard_holes", "scorecards", "settlements", "event_expenses", "replies", "reactions", "posts", "pairing_preferences", "event_group_members", "event_groups", "event_cohosts", "notifications", "event_guests", "event_invite_links", "event_series_rsvps", "event_series_exceptions", "event_series_invitees", "player_events", "friendships", "events", "event_series", "courses", "players"} {
 		if _, err := db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", table)); err != nil {
 			log.Fatalf("Failed to clean %s: %v", table, err)
 		}
@@ -69,26 +69,57 @@ func main() {
 	}
 	fmt.Println("Created 7 players")
 
+	if _, err := db.ExecContext(ctx, "UPDATE players SET admin = TRUE WHERE id = 7"); err != nil {
+		log.Fatalf("Failed to make admin: %v", err)
+	}
+
 	// Create courses (using raw SQL to match Rails IDs)
 	courses := []struct {
 		name, street, city, state, zip, phone, cost string
+		feeCents                                    int32
+		latitude, longitude                         float64
 	}{
-		{"Green Valley Ranch Golf Club", "4900 Himalaya Road", "Denver", "Colorado", "80249", "303.371.3131", "80"},
-		{"City Park Golf Course", "3181 E. 23rd Avenue", "Denver", "Colorado", "80205", "720.865.3410", "65"},
-		{"Riverdale Golf Club", "13300 Riverdale Road", "Brighton", "Colorado", "80602", "303.659.4700", "74"},
-		{"Willis Case Golf Course", "4999 Vrain Street", "Denver", "Colorado", "80212", "720.865.0700", "58"},
+		{"Green Valley Ranch Golf Club", "4900 Himalaya Road", "Denver", "Colorado", "80249", "303.371.3131", "80", 8000, 39.7794, -104.7636},
+		{"City Park Golf Course", "3181 E. 23rd Avenue", "Denver", "Colorado", "80205", "720.865.3410", "65", 6500, 39.7509, -104.9520},
+		{"Riverdale Golf Club", "13300 Riverdale Road", "Brighton", "Colorado", "80602", "303.659.4700", "74", 7400, 39.9396, -104.8554},
+		{"Willis Case Golf Course", "4999 Vrain Street", "Denver", "Colorado", "80212", "720.865.0700", "58", 5800, 39.7849, -105.0497},
 	}
 
 	for _, c := range courses {
 		_, err := db.ExecContext(ctx,
-			"INSERT INTO courses (name, street, city, state, zip_code, phone, cost, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())",
-			c.name, c.street, c.city, c.state, c.zip, c.phone, c.cost)
+			"INSERT INTO courses (name, street, city, state, zip_code, phone, cost, fee_cents, fee_currency, latitude, longitude, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'USD', $9, $10, NOW(), NOW())",
+			c.name, c.street, c.city, c.state, c.zip, c.phone, c.cost, c.feeCents, c.latitude, c.longitude)
 		if err != nil {
 			log.Fatalf("Failed to create course %s: %v", c.name, err)
 		}
 	}
 	fmt.Println("Created 4 courses")
 
+	// Give every course a white tee set with a standard par 72 layout
+	pars := []int32{4, 4, 3, 5, 4, 4, 3, 4, 5, 4, 3, 4, 5, 4, 4, 3, 5, 4}
+	strokeIndexes := []int32{7, 3, 15, 11, 1, 9, 17, 5, 13, 8, 16, 4, 12, 2, 10, 18, 14, 6}
+	ratings := []struct {
+		courseRating float64
+		slopeRating  int32
+	}{{70.4, 128}, {69.1, 121}, {71.2, 131}, {68.5, 118}}
+	for i, rating := range ratings {
+		holes := make([]store.CreateTeeSetHoleParams, len(pars))
+		for j := range pars {
+			holes[j] = store.CreateTeeSetHoleParams{HoleNumber: int32(j + 1), Par: pars[j], StrokeIndex: strokeIndexes[j]}
+		}
+		err := store.ImportTeeSets(ctx, db, q, int64(i+1), []store.TeeSetInput{{
+			Name:         "White",
+			Color:        ns("white"),
+			CourseRating: rating.courseRating,
+			SlopeRating:  rating.slopeRating,
+			Holes:        holes,
+		}})
+		if err != nil {
+			log.Fatalf("Failed to create tee set for course %d: %v", i+1, err)
+		}
+	}
+	fmt.Println("Created 4 tee sets")
+
 	// Create events
 	events := []store.CreateEventParams{
 		{CourseID: ni32(1), Date: ns("08-01-2021"), TeeTime: ns("13:20"), OpenSpots: ni32(3), NumberOfHoles: ns("9"), HostID: ni32(1), Private: nb(true)},
@@ -98,6 +129,11 @@ func main() {
 	}
 
 	for _, e := range events {
+		if startsAt, err := store.EventStartsAt(e.Date.String, e.TeeTime.String); err == nil {
+			e.StartsAt = sql.NullTime{Time: startsAt, Valid: true}
+		}
+		e.FeeCents = sql.NullInt32{Int32: courses[e.CourseID.Int32-1].feeCents, Valid: true}
+		e.FeeCurrency = "USD"
 		_, err := q.CreateEvent(ctx, e)
 		if err != nil {
 			log.Fatalf("Failed to create event: %v", err)
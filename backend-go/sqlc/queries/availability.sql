@@ -0,0 +1,39 @@
+-- name: CreatePlayerAvailability :exec
+INSERT INTO player_availability (player_id, day_of_week, start_minute, end_minute, created_at)
+VALUES ($1, $2, $3, $4, NOW());
+
+-- name: DeletePlayerAvailability :exec
+DELETE FROM player_availability WHERE player_id = $1;
+
+-- name: ListPlayerAvailability :many
+SELECT player_id, day_of_week, start_minute, end_minute
+FROM player_availability
+WHERE player_id = ANY(@player_ids::bigint[])
+ORDER BY player_id, day_of_week, start_minute;
+
+-- name: CreatePlayerPreferredCourse :exec
+INSERT INTO player_preferred_courses (player_id, course_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (player_id, course_id) DO NOTHING;
+
+-- name: DeletePlayerPreferredCourses :exec
+DELETE FROM player_preferred_courses WHERE player_id = $1;
+
+-- name: ListPlayerPreferredCourses :many
+SELECT player_id, course_id
+FROM player_preferred_courses
+WHERE player_id = ANY(@player_ids::bigint[])
+ORDER BY player_id, course_id;
+
+-- name: ListPlayerCommitments :many
+-- Events the players have accepted that start in the range, to keep
+-- proposals clear of games they're already playing.
+SELECT pe.player_id, e.starts_at, e.number_of_holes
+FROM player_events pe
+JOIN events e ON e.id = pe.event_id
+WHERE pe.player_id = ANY(@player_ids::bigint[])
+  AND pe.invite_status = 1
+  AND e.cancelled_at IS NULL
+  AND e.starts_at >= @starts_after::timestamp
+  AND e.starts_at < @starts_before::timestamp
+ORDER BY pe.player_id, e.starts_at;
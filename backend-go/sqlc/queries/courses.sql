@@ -1,4 +1,14 @@
 -- name: ListCourses :many
-SELECT id, name, street, city, state, zip_code, phone, cost
+SELECT id, name, street, city, state, zip_code, phone, cost, fee_cents, fee_currency, latitude, longitude, time_zone
 FROM courses
 ORDER BY id;
+
+-- name: GetCourseFee :one
+SELECT fee_cents, fee_currency
+FROM courses
+WHERE id = $1;
+
+-- name: GetCourseByID :one
+SELECT id, name, street, city, state, zip_code, phone, cost, fee_cents, fee_currency, latitude, longitude, time_zone
+FROM courses
+WHERE id = $1;
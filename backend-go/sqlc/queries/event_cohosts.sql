@@ -0,0 +1,23 @@
+-- name: CreateEventCohost :exec
+INSERT INTO event_cohosts (event_id, player_id, created_at, updated_at)
+VALUES ($1, $2, NOW(), NOW())
+ON CONFLICT (event_id, player_id) DO NOTHING;
+
+-- name: DeleteEventCohost :execrows
+DELETE FROM event_cohosts
+WHERE event_id = $1 AND player_id = $2;
+
+-- name: IsEventManager :one
+SELECT EXISTS (
+  SELECT 1 FROM events e
+  WHERE e.id = @event_id::bigint AND e.host_id = @player_id::bigint
+) OR EXISTS (
+  SELECT 1 FROM event_cohosts ec
+  WHERE ec.event_id = @event_id::bigint AND ec.player_id = @player_id::bigint
+) AS is_manager;
+
+-- name: ListCohostsByEventIDs :many
+SELECT event_id, player_id
+FROM event_cohosts
+WHERE event_id = ANY(@event_ids::bigint[])
+ORDER BY id;
@@ -0,0 +1,34 @@
+-- name: CreateEventExpense :one
+INSERT INTO event_expenses (event_id, payer_id, amount_cents, currency, description, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+RETURNING id, event_id, payer_id, amount_cents, currency, description, created_at, updated_at;
+
+-- name: DeleteEventExpense :execrows
+DELETE FROM event_expenses
+WHERE id = $1 AND event_id = $2;
+
+-- name: ListExpensesByEventIDs :many
+SELECT id, event_id, payer_id, amount_cents, currency, description, created_at, updated_at
+FROM event_expenses
+WHERE event_id = ANY(@event_ids::bigint[])
+ORDER BY event_id, id;
+
+-- name: ListExpenseEventIDsByPlayerID :many
+-- Events with expenses the player paid or shares in.
+SELECT DISTINCT x.event_id
+FROM event_expenses x
+WHERE x.payer_id = @player_id::bigint OR EXISTS (
+  SELECT 1 FROM player_events pe
+  WHERE pe.event_id = x.event_id AND pe.player_id = @player_id::bigint AND pe.invite_status = 1
+)
+ORDER BY x.event_id;
+
+-- name: ListExpenseSharesByEventIDs :many
+-- Accepted players weighted by the spots they take, guests included.
+SELECT pe.event_id, pe.player_id, 1 + (
+  SELECT COUNT(*) FROM event_guests g
+  WHERE g.event_id = pe.event_id AND g.sponsor_id = pe.player_id
+) AS weight
+FROM player_events pe
+WHERE pe.event_id = ANY(@event_ids::bigint[]) AND pe.invite_status = 1
+ORDER BY pe.event_id, pe.player_id;
@@ -0,0 +1,77 @@
+-- name: CreateEventGame :one
+INSERT INTO event_games (event_id, format, net, stake_cents, carryover, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+RETURNING id, event_id, format, net, stake_cents, carryover, settled_at, created_at, updated_at;
+
+-- name: CreateEventGamePlayer :exec
+INSERT INTO event_game_players (game_id, player_id, created_at)
+VALUES ($1, $2, NOW());
+
+-- name: GetEventGame :one
+SELECT id, event_id, format, net, stake_cents, carryover, settled_at, created_at, updated_at
+FROM event_games
+WHERE id = $1 AND event_id = $2;
+
+-- name: DeleteEventGame :exec
+DELETE FROM event_games
+WHERE id = $1;
+
+-- name: ListEventGames :many
+SELECT id, event_id, format, net, stake_cents, carryover, settled_at, created_at, updated_at
+FROM event_games
+WHERE event_id = $1
+ORDER BY id;
+
+-- name: ListEventGamePlayers :many
+SELECT gp.game_id, gp.player_id
+FROM event_game_players gp
+JOIN event_games g ON g.id = gp.game_id
+WHERE g.event_id = $1
+ORDER BY gp.game_id, gp.player_id;
+
+-- name: SettleEventGame :exec
+UPDATE event_games
+SET settled_at = NOW(), updated_at = NOW()
+WHERE id = $1;
+
+-- name: ListGameHandicapsByEventID :many
+-- Each accepted player's handicap index and the rating of the tees they
+-- played, to work out course handicaps for net games.
+SELECT pe.player_id, p.handicap_index, ts.course_rating, ts.slope_rating,
+  (SELECT COALESCE(SUM(tsh.par), 0) FROM tee_set_holes tsh WHERE tsh.tee_set_id = ts.id)::integer AS par
+FROM player_events pe
+JOIN players p ON p.id = pe.player_id
+LEFT JOIN scorecards sc ON sc.player_event_id = pe.id
+LEFT JOIN tee_sets ts ON ts.id = sc.tee_set_id
+WHERE pe.event_id = @event_id::bigint AND pe.invite_status = 1
+ORDER BY pe.player_id;
+
+-- name: ListGameHolesByEventID :many
+-- Scored holes with the stroke index from the card's tee set, or 0 when the
+-- card has none.
+SELECT pe.player_id, h.hole_number, h.strokes,
+  COALESCE(h.par, 0)::integer AS par,
+  COALESCE(tsh.stroke_index, 0)::integer AS stroke_index
+FROM scorecard_holes h
+JOIN scorecards sc ON sc.id = h.scorecard_id
+JOIN player_events pe ON pe.id = sc.player_event_id
+LEFT JOIN tee_set_holes tsh ON tsh.tee_set_id = sc.tee_set_id AND tsh.hole_number = h.hole_number
+WHERE pe.event_id = @event_id::bigint AND pe.invite_status = 1
+ORDER BY pe.player_id, h.hole_number;
+
+-- name: CreateGamePayout :exec
+INSERT INTO game_payouts (game_id, payer_id, payee_id, amount_cents, currency, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW(), NOW());
+
+-- name: ListGamePayoutsByEventID :many
+SELECT gp.id, gp.game_id, gp.payer_id, gp.payee_id, gp.amount_cents, gp.currency, gp.created_at, gp.updated_at
+FROM game_payouts gp
+JOIN event_games g ON g.id = gp.game_id
+WHERE g.event_id = $1
+ORDER BY gp.game_id, gp.id;
+
+-- name: ListGamePayoutsByPlayerID :many
+SELECT id, game_id, payer_id, payee_id, amount_cents, currency, created_at, updated_at
+FROM game_payouts
+WHERE payer_id = $1 OR payee_id = $1
+ORDER BY id;
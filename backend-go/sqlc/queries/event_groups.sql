@@ -0,0 +1,38 @@
+-- name: CreateEventGroup :one
+INSERT INTO event_groups (event_id, tee_time, position, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
+RETURNING id, event_id, tee_time, position, created_at, updated_at;
+
+-- name: CreateEventGroupMember :exec
+INSERT INTO event_group_members (event_id, group_id, player_id, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW());
+
+-- name: DeleteEventGroupMember :exec
+DELETE FROM event_group_members
+WHERE event_id = $1 AND player_id = $2;
+
+-- name: DeleteEventGroups :exec
+DELETE FROM event_groups
+WHERE event_id = $1;
+
+-- name: ListEventGroupMembers :many
+SELECT group_id, player_id
+FROM event_group_members
+WHERE event_id = $1
+ORDER BY player_id;
+
+-- name: ListEventGroups :many
+SELECT id, event_id, tee_time, position, created_at, updated_at
+FROM event_groups
+WHERE event_id = $1
+ORDER BY position;
+
+-- name: ListPairingCandidates :many
+SELECT pe.player_id, p.handicap_index, (
+  SELECT COUNT(*) FROM event_guests g
+  WHERE g.event_id = pe.event_id AND g.sponsor_id = pe.player_id
+) AS guest_count
+FROM player_events pe
+JOIN players p ON p.id = pe.player_id
+WHERE pe.event_id = $1 AND pe.invite_status = 1
+ORDER BY pe.player_id;
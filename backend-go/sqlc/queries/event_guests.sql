@@ -0,0 +1,23 @@
+-- name: CreateEventGuest :one
+INSERT INTO event_guests (event_id, sponsor_id, name, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
+RETURNING id, event_id, sponsor_id, name, created_at, updated_at;
+
+-- name: DeleteEventGuest :execrows
+DELETE FROM event_guests
+WHERE id = $1 AND event_id = $2;
+
+-- name: DeleteEventGuestsBySponsor :exec
+DELETE FROM event_guests
+WHERE event_id = $1 AND sponsor_id = $2;
+
+-- name: GetEventGuest :one
+SELECT id, event_id, sponsor_id, name, created_at, updated_at
+FROM event_guests
+WHERE id = $1 AND event_id = $2;
+
+-- name: ListGuestsByEventIDs :many
+SELECT id, event_id, sponsor_id, name, created_at, updated_at
+FROM event_guests
+WHERE event_id = ANY(@event_ids::bigint[])
+ORDER BY id;
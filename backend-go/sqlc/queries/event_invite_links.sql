@@ -0,0 +1,25 @@
+-- name: CreateEventInviteLink :one
+INSERT INTO event_invite_links (event_id, created_by, expires_at, max_uses, created_at, updated_at)
+VALUES ($1, $2, $3, $4, NOW(), NOW())
+RETURNING id, event_id, created_by, expires_at, max_uses, uses, revoked_at, created_at, updated_at;
+
+-- name: GetEventInviteLink :one
+SELECT id, event_id, created_by, expires_at, max_uses, uses, revoked_at, created_at, updated_at
+FROM event_invite_links
+WHERE id = $1;
+
+-- name: LockEventInviteLinkForUpdate :one
+SELECT id, event_id, created_by, expires_at, max_uses, uses, revoked_at, created_at, updated_at
+FROM event_invite_links
+WHERE id = $1
+FOR UPDATE;
+
+-- name: IncrementEventInviteLinkUses :exec
+UPDATE event_invite_links
+SET uses = uses + 1, updated_at = NOW()
+WHERE id = $1;
+
+-- name: RevokeEventInviteLink :execrows
+UPDATE event_invite_links
+SET revoked_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND event_id = $2 AND revoked_at IS NULL;
@@ -0,0 +1,52 @@
+-- name: CreateEventSeries :one
+INSERT INTO event_series (host_id, course_id, starts_on, tee_time, open_spots, number_of_holes, private, rrule, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+RETURNING id, host_id, course_id, starts_on, tee_time, open_spots, number_of_holes, private, rrule;
+
+-- name: GetEventSeriesByID :one
+SELECT id, host_id, course_id, starts_on, tee_time, open_spots, number_of_holes, private, rrule
+FROM event_series
+WHERE id = $1;
+
+-- name: LockEventSeriesForUpdate :one
+-- Serializes materializing a series so concurrent reads don't race to create
+-- the same occurrence.
+SELECT id
+FROM event_series
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CreateEventSeriesInvitee :exec
+INSERT INTO event_series_invitees (series_id, player_id, created_at, updated_at)
+VALUES ($1, $2, NOW(), NOW())
+ON CONFLICT (series_id, player_id) DO NOTHING;
+
+-- name: ListEventSeriesInviteeIDs :many
+SELECT player_id
+FROM event_series_invitees
+WHERE series_id = $1
+ORDER BY player_id;
+
+-- name: CreateEventSeriesException :exec
+INSERT INTO event_series_exceptions (series_id, occurrence_date, created_at, updated_at)
+VALUES ($1, $2, NOW(), NOW())
+ON CONFLICT (series_id, occurrence_date) DO NOTHING;
+
+-- name: ListEventSeriesExceptionDates :many
+SELECT occurrence_date
+FROM event_series_exceptions
+WHERE series_id = $1
+ORDER BY occurrence_date;
+
+-- name: UpsertEventSeriesRsvp :one
+INSERT INTO event_series_rsvps (series_id, player_id, invite_status, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
+ON CONFLICT (series_id, player_id) DO UPDATE
+SET invite_status = EXCLUDED.invite_status, updated_at = NOW()
+RETURNING id, series_id, player_id, invite_status;
+
+-- name: ListEventSeriesRsvps :many
+SELECT player_id, invite_status
+FROM event_series_rsvps
+WHERE series_id = $1
+ORDER BY player_id;
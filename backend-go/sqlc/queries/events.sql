@@ -1,42 +1,99 @@
--- name: ListAllEvents :many
+-- name: GetEventByID :one
 SELECT e.id, e.course_id, e.date, e.tee_time, e.open_spots, e.number_of_holes,
        e.private, e.host_id, c.name AS course_name, p.name AS host_name
 FROM events e
 JOIN courses c ON c.id = e.course_id
 JOIN players p ON p.id = e.host_id
-ORDER BY e.id;
+WHERE e.id = $1;
 
--- name: ListPublicEvents :many
+-- name: ListEventsByIDs :many
 SELECT e.id, e.course_id, e.date, e.tee_time, e.open_spots, e.number_of_holes,
-       e.private, e.host_id, c.name AS course_name, p.name AS host_name
+       e.private, e.host_id, e.cancelled_at, e.respond_by, e.status, e.fee_cents, e.fee_currency,
+       c.name AS course_name, p.name AS host_name
 FROM events e
 JOIN courses c ON c.id = e.course_id
 JOIN players p ON p.id = e.host_id
-WHERE e.private = false
-ORDER BY e.id;
+WHERE e.id = ANY(@ids::bigint[]);
 
--- name: ListEventsByPlayerID :many
-SELECT e.id, e.course_id, e.date, e.tee_time, e.open_spots, e.number_of_holes,
-       e.private, e.host_id, c.name AS course_name, p.name AS host_name
+-- name: CreateEvent :one
+INSERT INTO events (course_id, date, tee_time, open_spots, number_of_holes, private, host_id, series_id, occurrence_date, starts_at, respond_by, fee_cents, fee_currency, league_season_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW(), NOW())
+RETURNING id, course_id, date, tee_time, open_spots, number_of_holes, private, host_id;
+
+-- name: SearchEvents :many
+SELECT e.id, COALESCE(e.starts_at, 'epoch'::timestamp)::timestamp AS sort_key
 FROM events e
 JOIN courses c ON c.id = e.course_id
-JOIN players p ON p.id = e.host_id
-JOIN player_events pe ON pe.event_id = e.id
-WHERE pe.player_id = $1
-ORDER BY e.id;
+WHERE e.status = ANY(@statuses::int[])
+  AND (sqlc.narg('starts_after')::timestamp IS NULL OR e.starts_at >= sqlc.narg('starts_after')::timestamp)
+  AND (sqlc.narg('starts_before')::timestamp IS NULL OR e.starts_at < sqlc.narg('starts_before')::timestamp)
+  AND (sqlc.narg('course_id')::int IS NULL OR e.course_id = sqlc.narg('course_id')::int)
+  AND (sqlc.narg('city')::text IS NULL OR lower(c.city) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('state')::text IS NULL OR lower(c.state) = lower(sqlc.narg('state')::text))
+  AND (sqlc.narg('number_of_holes')::text IS NULL OR e.number_of_holes = sqlc.narg('number_of_holes')::text)
+  AND (sqlc.narg('host_id')::int IS NULL OR e.host_id = sqlc.narg('host_id')::int)
+  AND (sqlc.narg('private')::bool IS NULL OR e.private = sqlc.narg('private')::bool)
+  AND (sqlc.narg('player_id')::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = sqlc.narg('player_id')::bigint
+  ))
+  AND (sqlc.narg('accepted_by')::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = sqlc.narg('accepted_by')::bigint AND pe.invite_status = 1
+  ))
+  AND (sqlc.narg('friends_of')::int IS NULL OR EXISTS (
+    SELECT 1 FROM friendships f
+    WHERE f.follower_id = sqlc.narg('friends_of')::int
+      AND (f.followee_id = e.host_id OR EXISTS (
+        SELECT 1 FROM player_events pe
+        WHERE pe.event_id = e.id AND pe.player_id = f.followee_id AND pe.invite_status = 1
+      ))
+  ))
+  AND (sqlc.narg('available_to')::bigint IS NULL OR (
+    e.private = false AND NOT EXISTS (
+      SELECT 1 FROM player_events pe
+      WHERE pe.event_id = e.id AND pe.player_id = sqlc.narg('available_to')::bigint
+    )
+  ) OR EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = sqlc.narg('available_to')::bigint AND pe.invite_status = 0
+  ))
+  AND (NOT @has_open_spots::bool OR e.open_spots > (
+    SELECT COUNT(*) FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.invite_status = 1
+  ) + (
+    SELECT COUNT(*) FROM event_guests g
+    WHERE g.event_id = e.id
+  ))
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+    OR (NOT @sort_desc::bool AND (COALESCE(e.starts_at, 'epoch'::timestamp), e.id) > (sqlc.narg('cursor_starts_at')::timestamp, sqlc.narg('cursor_id')::bigint))
+    OR (@sort_desc::bool AND (COALESCE(e.starts_at, 'epoch'::timestamp), e.id) < (sqlc.narg('cursor_starts_at')::timestamp, sqlc.narg('cursor_id')::bigint)))
+ORDER BY
+  CASE WHEN NOT @sort_desc::bool THEN COALESCE(e.starts_at, 'epoch'::timestamp) END ASC,
+  CASE WHEN NOT @sort_desc::bool THEN e.id END ASC,
+  CASE WHEN @sort_desc::bool THEN COALESCE(e.starts_at, 'epoch'::timestamp) END DESC,
+  CASE WHEN @sort_desc::bool THEN e.id END DESC
+LIMIT sqlc.narg('page_limit')::int;
 
--- name: GetEventByID :one
-SELECT e.id, e.course_id, e.date, e.tee_time, e.open_spots, e.number_of_holes,
-       e.private, e.host_id, c.name AS course_name, p.name AS host_name
+-- name: CancelEvent :execrows
+UPDATE events
+SET cancelled_at = NOW(), status = 3, sequence = sequence + 1, updated_at = NOW()
+WHERE id = $1 AND status IN (0, 1);
+
+-- name: ListCalendarEvents :many
+SELECT e.id, e.number_of_holes, e.starts_at, e.sequence, e.cancelled_at, e.updated_at,
+       c.name AS course_name, c.street, c.city, c.state, c.zip_code, p.name AS host_name
 FROM events e
 JOIN courses c ON c.id = e.course_id
 JOIN players p ON p.id = e.host_id
-WHERE e.id = $1;
-
--- name: CreateEvent :one
-INSERT INTO events (course_id, date, tee_time, open_spots, number_of_holes, private, host_id, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
-RETURNING id, course_id, date, tee_time, open_spots, number_of_holes, private, host_id;
+WHERE e.starts_at IS NOT NULL
+  AND (sqlc.narg('event_id')::bigint IS NULL OR e.id = sqlc.narg('event_id')::bigint)
+  AND (sqlc.narg('player_id')::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = sqlc.narg('player_id')::bigint AND pe.invite_status = 1
+  ))
+  AND (sqlc.narg('starts_after')::timestamp IS NULL OR e.starts_at >= sqlc.narg('starts_after')::timestamp)
+ORDER BY e.starts_at, e.id;
 
 -- name: DeleteEvent :exec
 DELETE FROM events WHERE id = $1;
@@ -45,7 +102,8 @@ DELETE FROM events WHERE id = $1;
 SELECT DISTINCT e.id
 FROM events e
 JOIN player_events pe ON pe.event_id = e.id AND pe.invite_status = 1
-WHERE pe.player_id IN (
+WHERE e.status IN (0, 1)
+AND pe.player_id IN (
   SELECT followee_id FROM friendships WHERE follower_id = $1
 )
 AND NOT EXISTS (
@@ -55,4 +113,60 @@ AND NOT EXISTS (
 AND e.open_spots > (
   SELECT COUNT(*) FROM player_events pe3
   WHERE pe3.event_id = e.id AND pe3.invite_status = 1
+) + (
+  SELECT COUNT(*) FROM event_guests g
+  WHERE g.event_id = e.id
 );
+
+-- name: LockEventForUpdate :one
+SELECT id, open_spots, private, cancelled_at, respond_by, status, host_id, starts_at, number_of_holes
+FROM events
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListSeriesOccurrences :many
+SELECT id, occurrence_date
+FROM events
+WHERE series_id = $1 AND occurrence_date >= $2
+ORDER BY occurrence_date;
+
+-- name: GetSeriesOccurrence :one
+SELECT id, occurrence_date
+FROM events
+WHERE series_id = $1 AND occurrence_date = $2;
+
+-- name: UpdateEventDetails :exec
+UPDATE events
+SET course_id = $2, tee_time = $3, open_spots = $4, number_of_holes = $5, starts_at = $6,
+    sequence = sequence + 1, updated_at = NOW()
+WHERE id = $1;
+
+-- name: UpdateEventHost :exec
+UPDATE events
+SET host_id = $2, sequence = sequence + 1, updated_at = NOW()
+WHERE id = $1;
+
+-- name: StartDueEvents :execrows
+-- starts_at is the course's local time, so now is compared in the course's
+-- time zone.
+UPDATE events e
+SET status = 1, updated_at = NOW()
+FROM courses c
+WHERE c.id = e.course_id
+  AND e.status = 0
+  AND e.starts_at <= (@now::timestamptz AT TIME ZONE c.time_zone);
+
+-- name: CompleteFinishedEvents :execrows
+-- The interval matches EstimatedDuration. Like StartDueEvents, now is
+-- compared in the course's time zone.
+UPDATE events e
+SET status = 2, updated_at = NOW()
+FROM courses c
+WHERE c.id = e.course_id
+  AND e.status IN (0, 1)
+  AND e.starts_at + CASE WHEN e.number_of_holes = '9' THEN INTERVAL '2 hours 15 minutes' ELSE INTERVAL '4 hours 30 minutes' END <= (@now::timestamptz AT TIME ZONE c.time_zone);
+
+-- name: GetEventFee :one
+SELECT fee_cents, fee_currency
+FROM events
+WHERE id = $1;
@@ -16,3 +16,8 @@ WHERE follower_id = $1 AND followee_id = $2;
 SELECT followee_id
 FROM friendships
 WHERE follower_id = $1;
+
+-- name: ListFriendshipsAmong :many
+SELECT follower_id, followee_id
+FROM friendships
+WHERE follower_id = ANY(@player_ids::int[]) AND followee_id = ANY(@player_ids::int[]);
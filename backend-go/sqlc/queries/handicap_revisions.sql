@@ -0,0 +1,36 @@
+-- name: LockPlayerForUpdate :one
+SELECT id
+FROM players
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListHandicapRoundHoles :many
+-- Holes from the player's rated scorecards, oldest round first. Cancelled
+-- and not yet started rounds are left out.
+SELECT sc.id AS scorecard_id, COALESCE(e.starts_at, sc.created_at)::timestamp AS played_at,
+  t.course_rating, t.slope_rating, h.hole_number, h.strokes, th.par, th.stroke_index
+FROM scorecards sc
+JOIN player_events pe ON pe.id = sc.player_event_id
+JOIN events e ON e.id = pe.event_id
+JOIN tee_sets t ON t.id = sc.tee_set_id
+JOIN scorecard_holes h ON h.scorecard_id = sc.id
+JOIN tee_set_holes th ON th.tee_set_id = t.id AND th.hole_number = h.hole_number
+WHERE pe.player_id = @player_id::bigint AND pe.invite_status = 1
+  AND e.cancelled_at IS NULL AND e.status IN (1, 2)
+ORDER BY played_at, sc.id, h.hole_number;
+
+-- name: DeleteHandicapRevisions :exec
+DELETE FROM handicap_revisions
+WHERE player_id = $1;
+
+-- name: CreateHandicapRevision :exec
+INSERT INTO handicap_revisions (player_id, scorecard_id, played_at, adjusted_gross_score, score_differential, handicap_index, low_handicap_index, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW());
+
+-- name: ListHandicapRevisions :many
+SELECT r.id, r.scorecard_id, pe.event_id, r.played_at, r.adjusted_gross_score, r.score_differential, r.handicap_index, r.low_handicap_index
+FROM handicap_revisions r
+JOIN scorecards sc ON sc.id = r.scorecard_id
+JOIN player_events pe ON pe.id = sc.player_event_id
+WHERE r.player_id = $1
+ORDER BY r.played_at, r.id;
@@ -0,0 +1,29 @@
+-- name: ListLeaderboardRounds :many
+-- Complete 18 hole rounds the viewer may see, with the player's handicap
+-- index and tee rating for net scores. Players choose who sees their rounds:
+-- everyone, the players they follow, or only themselves.
+SELECT sc.id AS scorecard_id, pe.player_id, p.name AS player_name, e.id AS event_id, e.course_id,
+  COALESCE(e.starts_at, sc.created_at)::timestamp AS played_at,
+  SUM(h.strokes)::int AS strokes,
+  p.handicap_index, ts.course_rating, ts.slope_rating,
+  (SELECT COALESCE(SUM(tsh.par), 0) FROM tee_set_holes tsh WHERE tsh.tee_set_id = ts.id)::integer AS par
+FROM scorecards sc
+JOIN player_events pe ON pe.id = sc.player_event_id
+JOIN players p ON p.id = pe.player_id
+JOIN events e ON e.id = pe.event_id
+JOIN scorecard_holes h ON h.scorecard_id = sc.id
+LEFT JOIN tee_sets ts ON ts.id = sc.tee_set_id
+WHERE pe.invite_status = 1 AND e.cancelled_at IS NULL AND e.status IN (1, 2)
+  AND e.number_of_holes = '18'
+  AND (sqlc.narg('course_id')::int IS NULL OR e.course_id = sqlc.narg('course_id')::int)
+  AND (sqlc.narg('player_ids')::bigint[] IS NULL OR pe.player_id = ANY(sqlc.narg('player_ids')::bigint[]))
+  AND (sqlc.narg('played_from')::timestamp IS NULL OR COALESCE(e.starts_at, sc.created_at) >= sqlc.narg('played_from')::timestamp)
+  AND (p.scores_visibility = 0 OR p.id = @viewer_id::bigint OR (
+    p.scores_visibility = 1 AND EXISTS (
+      SELECT 1 FROM friendships f
+      WHERE f.follower_id = p.id AND f.followee_id = @viewer_id::bigint
+    )
+  ))
+GROUP BY sc.id, pe.id, p.id, e.id, ts.id
+HAVING COUNT(*) = 18
+ORDER BY sc.id;
@@ -0,0 +1,68 @@
+-- name: CreateLeague :one
+INSERT INTO leagues (name, commissioner_id, created_at, updated_at)
+VALUES ($1, $2, NOW(), NOW())
+RETURNING id, name, commissioner_id, created_at, updated_at;
+
+-- name: GetLeague :one
+SELECT id, name, commissioner_id, created_at, updated_at
+FROM leagues
+WHERE id = $1;
+
+-- name: CreateLeagueMember :exec
+INSERT INTO league_members (league_id, player_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (league_id, player_id) DO NOTHING;
+
+-- name: InviteToLeagueEvents :exec
+-- Invites a new member to the league's events that haven't been played.
+INSERT INTO player_events (player_id, event_id, invite_status, created_at, updated_at)
+SELECT @player_id::bigint, e.id, 0, NOW(), NOW()
+FROM events e
+JOIN league_seasons s ON s.id = e.league_season_id
+WHERE s.league_id = @league_id::bigint AND e.status = 0 AND e.cancelled_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = @player_id::bigint
+  );
+
+-- name: DeleteLeagueEventInvitations :exec
+-- Withdraws a former member's unanswered invitations, open or closed, to the
+-- league's events that haven't been played.
+DELETE FROM player_events pe
+USING events e, league_seasons s
+WHERE pe.event_id = e.id AND s.id = e.league_season_id
+  AND pe.player_id = @player_id::bigint AND s.league_id = @league_id::bigint
+  AND pe.invite_status IN (0, 3) AND e.status = 0;
+
+-- name: DeleteLeagueMember :execrows
+DELETE FROM league_members
+WHERE league_id = $1 AND player_id = $2;
+
+-- name: IsLeagueMember :one
+SELECT EXISTS (
+  SELECT 1 FROM league_members
+  WHERE league_id = $1 AND player_id = $2
+);
+
+-- name: ListLeagueMemberIDs :many
+SELECT player_id
+FROM league_members
+WHERE league_id = $1
+ORDER BY player_id;
+
+-- name: CreateLeagueSeason :one
+INSERT INTO league_seasons (league_id, name, course_id, starts_on, tee_time, open_spots, number_of_holes, rrule, net, finish_points, match_win_points, match_halve_points, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW())
+RETURNING id, league_id, name, course_id, starts_on, tee_time, open_spots, number_of_holes, rrule, net, finish_points, match_win_points, match_halve_points, created_at, updated_at;
+
+-- name: ListLeagueSeasons :many
+SELECT id, league_id, name, course_id, starts_on, tee_time, open_spots, number_of_holes, rrule, net, finish_points, match_win_points, match_halve_points, created_at, updated_at
+FROM league_seasons
+WHERE league_id = $1
+ORDER BY starts_on, id;
+
+-- name: ListLeagueSeasonEvents :many
+SELECT id, league_season_id, date, number_of_holes, status
+FROM events
+WHERE league_season_id = ANY(@season_ids::bigint[])
+ORDER BY starts_at, id;
@@ -0,0 +1,60 @@
+-- name: GetMatchmakingPlayer :one
+SELECT p.handicap_index, c.latitude, c.longitude, c.time_zone
+FROM players p
+LEFT JOIN courses c ON c.id = p.home_course_id
+WHERE p.id = $1;
+
+-- name: ListMatchmakingEvents :many
+-- Public scheduled events in the window that the player isn't part of, that
+-- still have open spots and haven't teed off yet at their course.
+SELECT e.id, e.starts_at, c.latitude, c.longitude
+FROM events e
+JOIN courses c ON c.id = e.course_id
+WHERE e.starts_at >= @starts_after::timestamp
+  AND e.starts_at < @starts_before::timestamp
+  AND e.starts_at > (NOW() AT TIME ZONE c.time_zone)
+  AND e.status = 0
+  AND e.cancelled_at IS NULL
+  AND e.private = false
+  AND (e.respond_by IS NULL OR e.respond_by > @now::timestamp)
+  AND e.host_id <> @player_id::bigint
+  AND NOT EXISTS (
+    SELECT 1 FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.player_id = @player_id::bigint
+  )
+  AND e.open_spots > (
+    SELECT COUNT(*) FROM player_events pe
+    WHERE pe.event_id = e.id AND pe.invite_status = 1
+  ) + (
+    SELECT COUNT(*) FROM event_guests g
+    WHERE g.event_id = e.id
+  )
+ORDER BY e.starts_at, e.id;
+
+-- name: ListMatchmakingRosters :many
+-- The accepted players of each event and how they relate to the searching
+-- player. Avoid is set when either player asked to avoid the other.
+SELECT pe.event_id, p.id AS player_id, p.handicap_index,
+       EXISTS (
+         SELECT 1 FROM friendships f
+         WHERE f.follower_id = @player_id::bigint AND f.followee_id = p.id
+       ) AS followed,
+       (
+         SELECT COUNT(*) FROM friendships f1
+         JOIN friendships f2 ON f2.follower_id = f1.followee_id
+         WHERE f1.follower_id = @player_id::bigint AND f2.followee_id = p.id
+       ) AS friends_following,
+       EXISTS (
+         SELECT 1 FROM pairing_preferences pp
+         WHERE pp.player_id = @player_id::bigint AND pp.other_player_id = p.id AND pp.preference = 0
+       ) AS play_with,
+       EXISTS (
+         SELECT 1 FROM pairing_preferences pp
+         WHERE pp.preference = 1
+           AND ((pp.player_id = @player_id::bigint AND pp.other_player_id = p.id)
+             OR (pp.player_id = p.id AND pp.other_player_id = @player_id::bigint))
+       ) AS avoid
+FROM player_events pe
+JOIN players p ON p.id = pe.player_id
+WHERE pe.event_id = ANY(@event_ids::bigint[]) AND pe.invite_status = 1
+ORDER BY pe.event_id, p.id;
@@ -0,0 +1,16 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (player_id, event_id, kind, message, created_at, updated_at)
+VALUES ($1, $2, $3, $4, NOW(), NOW())
+RETURNING id, player_id, event_id, kind, message, read_at, created_at, updated_at;
+
+-- name: ListNotificationsByPlayerID :many
+SELECT id, player_id, event_id, kind, message, read_at, created_at, updated_at
+FROM notifications
+WHERE player_id = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: MarkNotificationRead :execrows
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW()), updated_at = NOW()
+WHERE id = $1 AND player_id = $2;
@@ -0,0 +1,22 @@
+-- name: DeletePairingPreference :execrows
+DELETE FROM pairing_preferences
+WHERE player_id = $1 AND other_player_id = $2;
+
+-- name: ListPairingPreferencesAmong :many
+SELECT player_id, other_player_id, preference
+FROM pairing_preferences
+WHERE player_id = ANY(@player_ids::bigint[]) AND other_player_id = ANY(@player_ids::bigint[])
+ORDER BY id;
+
+-- name: ListPairingPreferencesByPlayerID :many
+SELECT id, player_id, other_player_id, preference, created_at, updated_at
+FROM pairing_preferences
+WHERE player_id = $1
+ORDER BY other_player_id;
+
+-- name: UpsertPairingPreference :one
+INSERT INTO pairing_preferences (player_id, other_player_id, preference, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
+ON CONFLICT (player_id, other_player_id) DO UPDATE
+SET preference = EXCLUDED.preference, updated_at = NOW()
+RETURNING id, player_id, other_player_id, preference, created_at, updated_at;
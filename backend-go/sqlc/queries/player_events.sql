@@ -14,14 +14,16 @@ SET invite_status = $3, updated_at = NOW()
 WHERE player_id = $1 AND event_id = $2
 RETURNING id, player_id, event_id, invite_status;
 
--- name: ListPlayerIDsByEventAndStatus :many
-SELECT player_id
+-- name: ListInvitesByEventIDs :many
+SELECT event_id, invite_status, array_agg(player_id ORDER BY id)::bigint[] AS player_ids
 FROM player_events
-WHERE event_id = $1 AND invite_status = $2;
+WHERE event_id = ANY(@event_ids::bigint[]) AND player_id IS NOT NULL
+GROUP BY event_id, invite_status;
 
--- name: CountAcceptedForEvent :one
-SELECT COUNT(*) FROM player_events
-WHERE event_id = $1 AND invite_status = 1;
+-- name: CountFilledSpotsForEvent :one
+-- Accepted players plus guests, which take a spot without a player_events row.
+SELECT (SELECT COUNT(*) FROM player_events pe WHERE pe.event_id = @event_id::bigint AND pe.invite_status = 1)
+     + (SELECT COUNT(*) FROM event_guests g WHERE g.event_id = @event_id::bigint) AS filled_spots;
 
 -- name: ClosePendingForEvent :exec
 UPDATE player_events
@@ -38,5 +40,53 @@ SELECT event_id
 FROM player_events
 WHERE player_id = $1 AND invite_status = 1;
 
--- name: ListPlayersExceptHost :many
-SELECT id FROM players WHERE id != $1;
+-- name: ExpirePendingInvitations :execrows
+UPDATE player_events pe
+SET invite_status = 4, updated_at = NOW()
+FROM events e
+WHERE pe.event_id = e.id
+  AND pe.invite_status = 0
+  AND e.respond_by IS NOT NULL
+  AND e.respond_by <= @now::timestamp;
+
+-- name: ListInvitationsDueForReminder :many
+SELECT pe.id, pe.player_id, pe.event_id, e.respond_by, c.name AS course_name
+FROM player_events pe
+JOIN events e ON e.id = pe.event_id
+JOIN courses c ON c.id = e.course_id
+WHERE pe.invite_status = 0
+  AND pe.reminded_at IS NULL
+  AND e.cancelled_at IS NULL
+  AND e.respond_by > @now::timestamp
+  AND e.respond_by <= @remind_before::timestamp
+ORDER BY pe.id
+FOR UPDATE OF pe SKIP LOCKED;
+
+-- name: MarkInvitationReminded :exec
+UPDATE player_events
+SET reminded_at = NOW(), updated_at = NOW()
+WHERE id = $1;
+
+-- name: FindConflictingAcceptedEvent :one
+-- The interval matches EstimatedDuration.
+SELECT e.id
+FROM events e
+JOIN player_events pe ON pe.event_id = e.id AND pe.invite_status = 1
+WHERE pe.player_id = @player_id::bigint
+  AND e.id <> @event_id::bigint
+  AND e.status IN (0, 1)
+  AND e.starts_at < @ends_at::timestamp
+  AND e.starts_at + CASE WHEN e.number_of_holes = '9' THEN INTERVAL '2 hours 15 minutes' ELSE INTERVAL '4 hours 30 minutes' END > @starts_at::timestamp
+ORDER BY e.starts_at, e.id
+LIMIT 1;
+
+-- name: IsEventParticipant :one
+-- The host and anyone invited, whatever their RSVP. Co-hosts are always
+-- accepted players so they're covered by player_events.
+SELECT EXISTS (
+  SELECT 1 FROM events e
+  WHERE e.id = @event_id::bigint AND e.host_id = @player_id::bigint
+) OR EXISTS (
+  SELECT 1 FROM player_events pe
+  WHERE pe.event_id = @event_id::bigint AND pe.player_id = @player_id::bigint
+) AS is_participant;
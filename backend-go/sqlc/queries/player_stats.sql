@@ -0,0 +1,55 @@
+-- name: ListPlayerStatRounds :many
+-- Totals for each of the player's complete scorecards, oldest first. Rounds
+-- count as they do for handicaps: accepted, started and not cancelled.
+SELECT sc.id AS scorecard_id, e.id AS event_id, e.course_id,
+  COALESCE(e.starts_at, sc.created_at)::timestamp AS played_at,
+  COUNT(*)::int AS holes,
+  SUM(h.strokes)::int AS strokes,
+  (CASE WHEN COUNT(h.par) = COUNT(*) THEN SUM(h.par) END)::int AS par,
+  COALESCE(SUM(h.putts), 0)::int AS putts,
+  COUNT(h.putts)::int AS putt_holes,
+  (COUNT(*) FILTER (WHERE h.fairway_hit))::int AS fairways_hit,
+  COUNT(h.fairway_hit)::int AS fairway_holes,
+  (COUNT(*) FILTER (WHERE h.green_in_regulation))::int AS greens_hit,
+  COUNT(h.green_in_regulation)::int AS green_holes,
+  COALESCE(SUM(h.strokes) FILTER (WHERE h.par = 3), 0)::int AS par3_strokes,
+  (COUNT(*) FILTER (WHERE h.par = 3))::int AS par3_holes,
+  COALESCE(SUM(h.strokes) FILTER (WHERE h.par = 4), 0)::int AS par4_strokes,
+  (COUNT(*) FILTER (WHERE h.par = 4))::int AS par4_holes,
+  COALESCE(SUM(h.strokes) FILTER (WHERE h.par = 5), 0)::int AS par5_strokes,
+  (COUNT(*) FILTER (WHERE h.par = 5))::int AS par5_holes
+FROM scorecards sc
+JOIN player_events pe ON pe.id = sc.player_event_id
+JOIN events e ON e.id = pe.event_id
+JOIN scorecard_holes h ON h.scorecard_id = sc.id
+WHERE pe.player_id = @player_id::bigint AND pe.invite_status = 1
+  AND e.cancelled_at IS NULL AND e.status IN (1, 2)
+  AND (sqlc.narg('played_from')::timestamp IS NULL OR COALESCE(e.starts_at, sc.created_at) >= sqlc.narg('played_from')::timestamp)
+  AND (sqlc.narg('played_before')::timestamp IS NULL OR COALESCE(e.starts_at, sc.created_at) < sqlc.narg('played_before')::timestamp)
+  AND (sqlc.narg('course_id')::int IS NULL OR e.course_id = sqlc.narg('course_id')::int)
+GROUP BY sc.id, e.id
+HAVING COUNT(*) = CASE WHEN e.number_of_holes ~ '^[1-9][0-9]*$' THEN e.number_of_holes::int ELSE 18 END
+ORDER BY played_at, sc.id;
+
+-- name: GetPlayerStats :one
+SELECT summary
+FROM player_stats
+WHERE player_id = $1 AND filter = $2;
+
+-- name: SavePlayerStats :exec
+INSERT INTO player_stats (player_id, filter, summary, created_at)
+VALUES ($1, $2, $3, NOW())
+ON CONFLICT (player_id, filter) DO UPDATE
+SET summary = EXCLUDED.summary,
+    created_at = NOW();
+
+-- name: DeletePlayerStats :exec
+DELETE FROM player_stats
+WHERE player_id = $1;
+
+-- name: DeleteEventPlayerStats :exec
+-- Clears the cached stats of everyone playing in the event.
+DELETE FROM player_stats
+WHERE player_id IN (
+  SELECT pe.player_id FROM player_events pe WHERE pe.event_id = @event_id::bigint
+);
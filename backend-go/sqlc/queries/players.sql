@@ -4,7 +4,7 @@ FROM players
 ORDER BY id;
 
 -- name: GetPlayerByID :one
-SELECT id, name, phone, email, username
+SELECT id, name, phone, email, username, handicap_index
 FROM players
 WHERE id = $1;
 
@@ -17,3 +17,48 @@ WHERE email = $1;
 INSERT INTO players (name, phone, email, username, password_digest, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
 RETURNING id, name, phone, email, username;
+
+-- name: GetPlayerCalendarToken :one
+SELECT calendar_token
+FROM players
+WHERE id = $1;
+
+-- name: SetPlayerCalendarToken :execrows
+UPDATE players
+SET calendar_token = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetPlayerHandicapIndex :one
+SELECT handicap_index
+FROM players
+WHERE id = $1;
+
+-- name: SetPlayerHandicapIndex :execrows
+UPDATE players
+SET handicap_index = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: IsPlayerAdmin :one
+SELECT admin
+FROM players
+WHERE id = $1;
+
+-- name: GetPlayerScoresVisibility :one
+SELECT scores_visibility
+FROM players
+WHERE id = $1;
+
+-- name: SetPlayerScoresVisibility :execrows
+UPDATE players
+SET scores_visibility = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetPlayerHomeCourse :one
+SELECT home_course_id
+FROM players
+WHERE id = $1;
+
+-- name: SetPlayerHomeCourse :execrows
+UPDATE players
+SET home_course_id = $2, updated_at = NOW()
+WHERE id = $1;
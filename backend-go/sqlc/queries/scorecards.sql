@@ -0,0 +1,35 @@
+-- name: UpsertScorecard :one
+INSERT INTO scorecards (player_event_id, entered_by, tee_set_id, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
+ON CONFLICT (player_event_id) DO UPDATE
+SET entered_by = EXCLUDED.entered_by,
+    tee_set_id = COALESCE(EXCLUDED.tee_set_id, scorecards.tee_set_id),
+    updated_at = NOW()
+RETURNING id, player_event_id, entered_by, created_at, updated_at, tee_set_id;
+
+-- name: UpsertScorecardHole :exec
+INSERT INTO scorecard_holes (scorecard_id, hole_number, strokes, putts, fairway_hit, green_in_regulation, par, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+ON CONFLICT (scorecard_id, hole_number) DO UPDATE
+SET strokes = EXCLUDED.strokes,
+    putts = EXCLUDED.putts,
+    fairway_hit = EXCLUDED.fairway_hit,
+    green_in_regulation = EXCLUDED.green_in_regulation,
+    par = EXCLUDED.par,
+    updated_at = NOW();
+
+-- name: ListScorecardsByEventID :many
+-- Every accepted player, with their scorecard if one has been started.
+SELECT pe.player_id, sc.id AS scorecard_id, sc.entered_by, sc.updated_at, sc.tee_set_id
+FROM player_events pe
+LEFT JOIN scorecards sc ON sc.player_event_id = pe.id
+WHERE pe.event_id = @event_id::bigint AND pe.invite_status = 1
+ORDER BY pe.player_id;
+
+-- name: ListScorecardHolesByEventID :many
+SELECT pe.player_id, h.hole_number, h.strokes, h.putts, h.fairway_hit, h.green_in_regulation, h.par
+FROM scorecard_holes h
+JOIN scorecards sc ON sc.id = h.scorecard_id
+JOIN player_events pe ON pe.id = sc.player_event_id
+WHERE pe.event_id = @event_id::bigint AND pe.invite_status = 1
+ORDER BY pe.player_id, h.hole_number;
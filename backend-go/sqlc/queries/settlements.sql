@@ -0,0 +1,10 @@
+-- name: CreateSettlement :one
+INSERT INTO settlements (payer_id, payee_id, amount_cents, currency, note, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+RETURNING id, payer_id, payee_id, amount_cents, currency, note, created_at, updated_at;
+
+-- name: ListSettlementsByPlayerID :many
+SELECT id, payer_id, payee_id, amount_cents, currency, note, created_at, updated_at
+FROM settlements
+WHERE payer_id = $1 OR payee_id = $1
+ORDER BY created_at DESC, id DESC;
@@ -0,0 +1,65 @@
+-- name: CreateTeeSet :one
+INSERT INTO tee_sets (course_id, name, color, course_rating, slope_rating, yardage, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+RETURNING id, course_id, name, color, course_rating, slope_rating, yardage, created_at, updated_at;
+
+-- name: UpdateTeeSet :one
+UPDATE tee_sets
+SET name = $3, color = $4, course_rating = $5, slope_rating = $6, yardage = $7, updated_at = NOW()
+WHERE id = $1 AND course_id = $2
+RETURNING id, course_id, name, color, course_rating, slope_rating, yardage, created_at, updated_at;
+
+-- name: UpsertTeeSet :one
+INSERT INTO tee_sets (course_id, name, color, course_rating, slope_rating, yardage, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+ON CONFLICT (course_id, name) DO UPDATE
+SET color = EXCLUDED.color,
+    course_rating = EXCLUDED.course_rating,
+    slope_rating = EXCLUDED.slope_rating,
+    yardage = EXCLUDED.yardage,
+    updated_at = NOW()
+RETURNING id, course_id, name, color, course_rating, slope_rating, yardage, created_at, updated_at;
+
+-- name: DeleteTeeSet :execrows
+DELETE FROM tee_sets
+WHERE id = $1 AND course_id = $2;
+
+-- name: ListTeeSetsByCourseID :many
+SELECT id, course_id, name, color, course_rating, slope_rating, yardage, created_at, updated_at
+FROM tee_sets
+WHERE course_id = $1
+ORDER BY course_rating DESC, id;
+
+-- name: CreateTeeSetHole :exec
+INSERT INTO tee_set_holes (tee_set_id, hole_number, par, stroke_index, yardage, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW(), NOW());
+
+-- name: DeleteTeeSetHoles :exec
+DELETE FROM tee_set_holes
+WHERE tee_set_id = $1;
+
+-- name: ListTeeSetHolesByCourseID :many
+SELECT h.id, h.tee_set_id, h.hole_number, h.par, h.stroke_index, h.yardage, h.created_at, h.updated_at
+FROM tee_set_holes h
+JOIN tee_sets t ON t.id = h.tee_set_id
+WHERE t.course_id = $1
+ORDER BY h.tee_set_id, h.hole_number;
+
+-- name: GetTeeSetByID :one
+SELECT id, course_id, name, color, course_rating, slope_rating, yardage, created_at, updated_at
+FROM tee_sets
+WHERE id = $1;
+
+-- name: ListTeeSetHolesByTeeSetID :many
+SELECT id, tee_set_id, hole_number, par, stroke_index, yardage, created_at, updated_at
+FROM tee_set_holes
+WHERE tee_set_id = $1
+ORDER BY hole_number;
+
+-- name: ListTeeSetPlayerIDs :many
+-- Players with a scorecard played from the tee set.
+SELECT DISTINCT pe.player_id
+FROM scorecards sc
+JOIN player_events pe ON pe.id = sc.player_event_id
+WHERE sc.tee_set_id = $1
+ORDER BY pe.player_id;
@@ -0,0 +1,78 @@
+-- name: CreateTournament :one
+INSERT INTO tournaments (name, host_id, course_id, number_of_holes, net, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+RETURNING id, name, host_id, course_id, number_of_holes, net, status, winner_id, created_at, updated_at;
+
+-- name: GetTournament :one
+SELECT id, name, host_id, course_id, number_of_holes, net, status, winner_id, created_at, updated_at
+FROM tournaments
+WHERE id = $1;
+
+-- name: LockTournamentForUpdate :one
+SELECT id, name, host_id, course_id, number_of_holes, net, status, winner_id, created_at, updated_at
+FROM tournaments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: SetTournamentStatus :exec
+UPDATE tournaments
+SET status = $2, winner_id = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: CreateTournamentPlayer :execrows
+INSERT INTO tournament_players (tournament_id, player_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (tournament_id, player_id) DO NOTHING;
+
+-- name: DeleteTournamentPlayer :execrows
+DELETE FROM tournament_players
+WHERE tournament_id = $1 AND player_id = $2;
+
+-- name: ListTournamentPlayers :many
+-- Registered players in seed order once drawn, otherwise by registration.
+SELECT tp.player_id, tp.seed, p.handicap_index
+FROM tournament_players tp
+JOIN players p ON p.id = tp.player_id
+WHERE tp.tournament_id = $1
+ORDER BY tp.seed NULLS LAST, tp.id;
+
+-- name: SetTournamentPlayerSeed :exec
+UPDATE tournament_players
+SET seed = $3
+WHERE tournament_id = $1 AND player_id = $2;
+
+-- name: CreateTournamentMatch :one
+INSERT INTO tournament_matches (tournament_id, round, position, player1_id, player2_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+RETURNING id, tournament_id, round, position, player1_id, player2_id, event_id, winner_id, result, created_at, updated_at;
+
+-- name: GetTournamentMatch :one
+SELECT id, tournament_id, round, position, player1_id, player2_id, event_id, winner_id, result, created_at, updated_at
+FROM tournament_matches
+WHERE id = $1 AND tournament_id = $2;
+
+-- name: ListTournamentMatches :many
+SELECT id, tournament_id, round, position, player1_id, player2_id, event_id, winner_id, result, created_at, updated_at
+FROM tournament_matches
+WHERE tournament_id = $1
+ORDER BY round, position;
+
+-- name: SetTournamentMatchEvent :exec
+UPDATE tournament_matches
+SET event_id = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: DecideTournamentMatch :exec
+UPDATE tournament_matches
+SET winner_id = $2, result = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetTournamentMatchPlayer1 :exec
+UPDATE tournament_matches
+SET player1_id = $4, updated_at = NOW()
+WHERE tournament_id = $1 AND round = $2 AND position = $3;
+
+-- name: SetTournamentMatchPlayer2 :exec
+UPDATE tournament_matches
+SET player2_id = $4, updated_at = NOW()
+WHERE tournament_id = $1 AND round = $2 AND position = $3;